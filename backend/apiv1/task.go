@@ -0,0 +1,106 @@
+package apiv1
+
+import (
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// Task é a tarefa como a versão 1 da API REST a representa. O formato fica
+// congelado: mudanças em models.Task só chegam ao JSON da v1 quando mapeadas
+// aqui, e campos novos ou renomeados vão para uma versão seguinte.
+type Task struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Status      string          `json:"status"`
+	Completed   bool            `json:"completed"`
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	Archived    bool            `json:"archived"`
+	ArchivedAt  *time.Time      `json:"archivedAt,omitempty"`
+	Checklist   []ChecklistItem `json:"checklist,omitempty"`
+	Labels      []string        `json:"labels,omitempty"`
+	Priority    string          `json:"priority"`
+	DueDate     *time.Time      `json:"dueDate,omitempty"`
+	Occurrence  *Occurrence     `json:"occurrence,omitempty"`
+	Progress    int             `json:"progress"`
+}
+
+type ChecklistItem struct {
+	ID      string `json:"id"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	Order   int    `json:"order"`
+}
+
+type Occurrence struct {
+	RecurringTaskID string    `json:"recurringTaskId"`
+	ScheduledAt     time.Time `json:"scheduledAt"`
+}
+
+type TaskDependencies struct {
+	TaskID    string `json:"taskId"`
+	BlockedBy []Task `json:"blockedBy"`
+	Blocks    []Task `json:"blocks"`
+}
+
+// FromTask converte a tarefa do domínio na representação da v1
+func FromTask(task *models.Task) Task {
+	dto := Task{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      string(task.Status),
+		Completed:   task.Completed,
+		CompletedAt: task.CompletedAt,
+		Archived:    task.Archived,
+		ArchivedAt:  task.ArchivedAt,
+		Labels:      task.Labels,
+		Priority:    string(task.Priority),
+		DueDate:     task.DueDate,
+		Progress:    task.Progress(),
+	}
+	if task.Checklist != nil {
+		dto.Checklist = make([]ChecklistItem, len(task.Checklist))
+		for i, item := range task.Checklist {
+			dto.Checklist[i] = ChecklistItem(item)
+		}
+	}
+	if task.Occurrence != nil {
+		occurrence := Occurrence(*task.Occurrence)
+		dto.Occurrence = &occurrence
+	}
+	return dto
+}
+
+// FromTasks converte uma lista de tarefas; a lista nunca é nil, para que o
+// JSON seja sempre um array
+func FromTasks(tasks []*models.Task) []Task {
+	dtos := make([]Task, len(tasks))
+	for i, task := range tasks {
+		dtos[i] = FromTask(task)
+	}
+	return dtos
+}
+
+// FromDependencies converte os bloqueios de uma tarefa
+func FromDependencies(deps *models.TaskDependencies) TaskDependencies {
+	return TaskDependencies{
+		TaskID:    deps.TaskID,
+		BlockedBy: FromTasks(deps.BlockedBy),
+		Blocks:    FromTasks(deps.Blocks),
+	}
+}
+
+// Mapper aplica as conversões da v1 às respostas do handlers.TaskHandler
+type Mapper struct{}
+
+// Task converte uma tarefa
+func (Mapper) Task(task *models.Task) any {
+	return FromTask(task)
+}
+
+// Dependencies converte os bloqueios de uma tarefa
+func (Mapper) Dependencies(deps *models.TaskDependencies) any {
+	return FromDependencies(deps)
+}
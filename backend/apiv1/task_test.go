@@ -0,0 +1,68 @@
+package apiv1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+// A v1 mantém o JSON que o frontend já consome, com todos os campos preenchidos
+func TestFromTaskKeepsTheV1Format(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	task := &models.Task{
+		ID:          "1",
+		Title:       "Write docs",
+		Description: "API v1",
+		Status:      models.StatusDone,
+		Completed:   true,
+		CompletedAt: &now,
+		Archived:    true,
+		ArchivedAt:  &now,
+		Checklist: []models.ChecklistItem{
+			{ID: "a", Text: "draft", Checked: true, Order: 0},
+			{ID: "b", Text: "review", Order: 1},
+		},
+		Labels:     []string{"docs"},
+		Priority:   models.PriorityHigh,
+		DueDate:    &now,
+		Occurrence: &models.Occurrence{RecurringTaskID: "r1", ScheduledAt: now},
+	}
+
+	got, err := json.Marshal(FromTask(task))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	want := `{"id":"1","title":"Write docs","description":"API v1","status":"done","completed":true,` +
+		`"completedAt":"2026-10-19T12:00:00Z","archived":true,"archivedAt":"2026-10-19T12:00:00Z",` +
+		`"checklist":[{"id":"a","text":"draft","checked":true,"order":0},{"id":"b","text":"review","checked":false,"order":1}],` +
+		`"labels":["docs"],"priority":"high","dueDate":"2026-10-19T12:00:00Z",` +
+		`"occurrence":{"recurringTaskId":"r1","scheduledAt":"2026-10-19T12:00:00Z"},"progress":50}`
+	if string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestFromTaskOmitsEmptyFields(t *testing.T) {
+	got, err := json.Marshal(FromTask(&models.Task{ID: "1", Title: "x", Status: models.StatusTodo, Priority: models.PriorityMedium}))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	want := `{"id":"1","title":"x","status":"todo","completed":false,"archived":false,"priority":"medium","progress":0}`
+	if string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestFromDependenciesAlwaysListsArrays(t *testing.T) {
+	got, err := json.Marshal(FromDependencies(&models.TaskDependencies{TaskID: "1"}))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if want := `{"taskId":"1","blockedBy":[],"blocks":[]}`; string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// Componentes usados para publicar as tarefas
+const (
+	ComponentEvent = "VEVENT"
+	ComponentTodo  = "VTODO"
+)
+
+// ContentType é o tipo MIME do feed
+const ContentType = "text/calendar; charset=utf-8"
+
+const prodID = "-//acauhi//Kanban//PT"
+
+var ErrUnsupportedComponent = errors.New("unsupported component, use event or todo")
+
+// todoStatus mapeia o status da tarefa para o STATUS de um VTODO
+var todoStatus = map[models.Status]string{
+	models.StatusTodo:       "NEEDS-ACTION",
+	models.StatusInProgress: "IN-PROCESS",
+	models.StatusDone:       "COMPLETED",
+}
+
+// icalPriority mapeia a prioridade para a escala de 1 (mais alta) a 9 do iCalendar
+var icalPriority = map[models.Priority]int{
+	models.PriorityUrgent: 1,
+	models.PriorityHigh:   3,
+	models.PriorityMedium: 5,
+	models.PriorityLow:    9,
+}
+
+// Feed descreve o calendário publicado
+type Feed struct {
+	Name        string
+	Component   string
+	GeneratedAt time.Time
+	Tasks       []models.ReportTask
+}
+
+// Write escreve o feed como um VCALENDAR (RFC 5545). Em VTODO o prazo vira DUE
+// e o status é mapeado para STATUS/COMPLETED; em VEVENT, que muitos
+// calendários exibem melhor, o prazo vira DTSTART e tarefas concluídas são
+// marcadas no título.
+func Write(w io.Writer, feed Feed) error {
+	if feed.Component != ComponentEvent && feed.Component != ComponentTodo {
+		return ErrUnsupportedComponent
+	}
+
+	out := &writer{w: w}
+	out.begin("VCALENDAR")
+	out.raw("VERSION", "2.0")
+	out.raw("PRODID", prodID)
+	out.raw("CALSCALE", "GREGORIAN")
+	out.raw("METHOD", "PUBLISH")
+	out.text("X-WR-CALNAME", feed.Name)
+
+	for _, task := range feed.Tasks {
+		writeTask(out, feed, task)
+	}
+
+	out.end("VCALENDAR")
+	return out.err
+}
+
+// writeTask escreve o componente de uma tarefa
+func writeTask(out *writer, feed Feed, task models.ReportTask) {
+	done := task.Completed || task.Status == models.StatusDone
+
+	out.begin(feed.Component)
+	out.text("UID", task.ID+"@kanban")
+	out.time("DTSTAMP", feed.GeneratedAt)
+
+	summary := task.Title
+	if feed.Component == ComponentTodo {
+		out.time("DUE", *task.DueDate)
+		if done {
+			out.raw("STATUS", todoStatus[models.StatusDone])
+			if task.CompletedAt != nil {
+				out.time("COMPLETED", *task.CompletedAt)
+			}
+		} else {
+			out.raw("STATUS", todoStatus[task.Status])
+		}
+		if len(task.Checklist) > 0 {
+			out.raw("PERCENT-COMPLETE", strconv.Itoa(task.Progress()))
+		}
+	} else {
+		out.time("DTSTART", *task.DueDate)
+		out.raw("TRANSP", "TRANSPARENT")
+		if done {
+			summary = "✔ " + summary
+		}
+	}
+
+	out.text("SUMMARY", summary)
+	if task.Description != "" {
+		out.text("DESCRIPTION", task.Description)
+	}
+	if priority, ok := icalPriority[task.Priority]; ok {
+		out.raw("PRIORITY", strconv.Itoa(priority))
+	}
+	if len(task.LabelNames) > 0 {
+		out.textList("CATEGORIES", task.LabelNames)
+	}
+	out.end(feed.Component)
+}
@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxLineOctets é o limite de octetos por linha, sem contar o CRLF (RFC 5545, 3.1)
+	maxLineOctets = 75
+	// utcFormat é o formato DATE-TIME em UTC
+	utcFormat = "20060102T150405Z"
+)
+
+// textEscaper aplica o escape de valores TEXT (RFC 5545, 3.3.11)
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// writer escreve as linhas de conteúdo com CRLF e dobra; o primeiro erro é
+// guardado e as escritas seguintes são ignoradas
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+// begin abre um componente (BEGIN:VEVENT)
+func (w *writer) begin(component string) {
+	w.line("BEGIN:" + component)
+}
+
+// end fecha um componente (END:VEVENT)
+func (w *writer) end(component string) {
+	w.line("END:" + component)
+}
+
+// text escreve uma propriedade do tipo TEXT, com escape
+func (w *writer) text(name, value string) {
+	w.line(name + ":" + escapeText(value))
+}
+
+// textList escreve uma propriedade com vários valores TEXT separados por vírgula
+func (w *writer) textList(name string, values []string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeText(value)
+	}
+	w.line(name + ":" + strings.Join(escaped, ","))
+}
+
+// time escreve uma propriedade DATE-TIME em UTC
+func (w *writer) time(name string, t time.Time) {
+	w.line(name + ":" + t.UTC().Format(utcFormat))
+}
+
+// raw escreve uma propriedade cujo valor já está no formato final
+func (w *writer) raw(name, value string) {
+	w.line(name + ":" + value)
+}
+
+// line dobra a linha de conteúdo e a escreve terminada em CRLF
+func (w *writer) line(content string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, fold(content))
+}
+
+// escapeText escapa barras, ponto e vírgula, vírgulas e quebras de linha
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+// fold quebra a linha em trechos de até 75 octetos, sem partir caracteres
+// UTF-8; cada continuação começa com um espaço (RFC 5545, 3.1)
+func fold(content string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// O espaço inicial conta no limite das linhas de continuação
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,103 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+func TestEscapeText(t *testing.T) {
+	got := escapeText("a;b,c\\d\r\ne\nf")
+	want := `a\;b\,c\\d\ne\nf`
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestFoldKeepsLinesWithinLimit(t *testing.T) {
+	content := "DESCRIPTION:" + strings.Repeat("ação ", 40)
+
+	folded := fold(content)
+	if !strings.HasSuffix(folded, "\r\n") {
+		t.Fatalf("expected CRLF terminator, got %q", folded)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected the line to be folded, got %d lines", len(lines))
+	}
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %d has %d octets", i, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d must start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	if unfolded.String() != content {
+		t.Errorf("expected unfolding to restore the content")
+	}
+
+	if short := fold("VERSION:2.0"); short != "VERSION:2.0\r\n" {
+		t.Errorf("expected short line untouched, got %q", short)
+	}
+}
+
+func TestWriteFeed(t *testing.T) {
+	due := time.Date(2025, 3, 1, 18, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	completed := time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)
+	feed := Feed{
+		Name:        "Kanban (alice)",
+		Component:   ComponentTodo,
+		GeneratedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Tasks: []models.ReportTask{
+			{Task: &models.Task{ID: "1", Title: "Deploy, v2", Description: "line1\nline2", Status: models.StatusInProgress, Priority: models.PriorityUrgent, DueDate: &due,
+				Checklist: []models.ChecklistItem{{Checked: true}, {}}}, LabelNames: []string{"ops", "a,b"}},
+			{Task: &models.Task{ID: "2", Title: "Done", Status: models.StatusDone, Completed: true, CompletedAt: &completed, Priority: models.PriorityLow, DueDate: &due}},
+		},
+	}
+
+	var todo strings.Builder
+	if err := Write(&todo, feed); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VTODO\r\nUID:1@kanban\r\nDTSTAMP:20250201T000000Z\r\nDUE:20250301T210000Z\r\nSTATUS:IN-PROCESS\r\nPERCENT-COMPLETE:50\r\n",
+		"SUMMARY:Deploy\\, v2\r\nDESCRIPTION:line1\\nline2\r\nPRIORITY:1\r\nCATEGORIES:ops,a\\,b\r\n",
+		"STATUS:COMPLETED\r\nCOMPLETED:20250228T100000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(todo.String(), want) {
+			t.Errorf("expected feed to contain %q, got:\n%s", want, todo.String())
+		}
+	}
+
+	feed.Component = ComponentEvent
+	var event strings.Builder
+	if err := Write(&event, feed); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !strings.Contains(event.String(), "BEGIN:VEVENT\r\n") || !strings.Contains(event.String(), "DTSTART:20250301T210000Z\r\n") ||
+		!strings.Contains(event.String(), "SUMMARY:✔ Done\r\n") || strings.Contains(event.String(), "STATUS:") {
+		t.Errorf("unexpected event feed:\n%s", event.String())
+	}
+
+	feed.Component = "VJOURNAL"
+	if err := Write(&event, feed); err != ErrUnsupportedComponent {
+		t.Errorf("expected ErrUnsupportedComponent, got %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// Valores padrão da política de novas tentativas
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 200 * time.Millisecond
+)
+
+// idempotentMethods podem ser repetidos sem risco de efeito duplicado. O
+// cliente só envia PATCH como merge patch, que também é idempotente.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// apiPrefix é a versão da API REST usada pelo cliente
+const apiPrefix = "/api/v1"
+
+// mergePatchContentType é o tipo dos corpos de PATCH (RFC 7396)
+const mergePatchContentType = "application/merge-patch+json"
+
+// retryableStatus são as respostas consideradas falhas transitórias
+var retryableStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// Client acessa a API REST de tarefas
+type Client struct {
+	baseURL        *url.URL
+	httpClient     *http.Client
+	token          string
+	maxRetries     int
+	retryDelayBase time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient define o http.Client usado nas requisições (padrão: http.DefaultClient)
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken envia o token em Authorization: Bearer em todas as requisições
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetry define quantas vezes uma requisição idempotente é repetida e a
+// espera inicial entre tentativas (padrão: 3 vezes, a partir de 200ms).
+// maxRetries 0 desativa as novas tentativas.
+func WithRetry(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryDelayBase = baseDelay
+	}
+}
+
+// New cria um cliente para o servidor em baseURL (ex: http://localhost:8080)
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL:        u,
+		httpClient:     http.DefaultClient,
+		maxRetries:     defaultMaxRetries,
+		retryDelayBase: defaultRetryDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// ListOptions filtra e ordena a listagem de tarefas
+type ListOptions struct {
+	Archived bool
+	Label    string
+	Sort     string
+	Desc     bool
+}
+
+// GetAllTasks lista as tarefas do quadro
+func (c *Client) GetAllTasks(ctx context.Context, opts ListOptions) ([]*models.Task, error) {
+	query := url.Values{}
+	if opts.Archived {
+		query.Set("archived", "true")
+	}
+	if opts.Label != "" {
+		query.Set("label", opts.Label)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+		if opts.Desc {
+			query.Set("order", "desc")
+		}
+	}
+
+	var tasks []*models.Task
+	if err := c.do(ctx, http.MethodGet, "/tasks", query, nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTaskByID busca uma tarefa pelo ID
+func (c *Client) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
+	var task models.Task
+	if err := c.do(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id), nil, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// CreateTask cria uma nova tarefa
+func (c *Client) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
+	var task models.Task
+	if err := c.do(ctx, http.MethodPost, "/tasks", nil, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// UpdateTask atualiza os campos informados da tarefa, enviados como merge patch
+func (c *Client) UpdateTask(ctx context.Context, id string, req models.UpdateTaskRequest) (*models.Task, error) {
+	var task models.Task
+	if err := c.do(ctx, http.MethodPatch, "/tasks/"+url.PathEscape(id), nil, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ReplaceTask substitui todos os campos editáveis da tarefa; os ausentes
+// voltam ao padrão
+func (c *Client) ReplaceTask(ctx context.Context, id string, req models.ReplaceTaskRequest) (*models.Task, error) {
+	var task models.Task
+	if err := c.do(ctx, http.MethodPut, "/tasks/"+url.PathEscape(id), nil, req, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// DeleteTask remove a tarefa
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/tasks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// do executa a requisição, codificando body em JSON e decodificando a
+// resposta em out; respostas de erro viram *Error. Métodos idempotentes são
+// repetidos em falhas de rede e respostas 429, 502, 503 e 504.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := *c.baseURL
+	u.Path = c.baseURL.Path + apiPrefix + path
+	u.RawQuery = query.Encode()
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		var err error
+		resp, err = c.send(ctx, method, u.String(), payload)
+
+		delay, retry := c.retryDelay(method, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return err
+			}
+			break
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return newError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// send monta e envia uma tentativa da requisição
+func (c *Client) send(ctx context.Context, method, target string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil && method == http.MethodPatch {
+		req.Header.Set("Content-Type", mergePatchContentType)
+	} else if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// retryDelay decide se a tentativa deve ser repetida e quanto esperar:
+// backoff exponencial com jitter ou o Retry-After (em segundos) do servidor
+func (c *Client) retryDelay(method string, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= c.maxRetries || !idempotentMethods[method] {
+		return 0, false
+	}
+	if err != nil {
+		// Cancelamento do contexto não é falha transitória
+		return c.backoff(attempt), !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	if !retryableStatus[resp.StatusCode] {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return c.backoff(attempt), true
+}
+
+// backoff dobra a espera a cada tentativa e soma até 50% de jitter
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.retryDelayBase << attempt
+	return delay + time.Duration(rand.Int64N(int64(delay)/2+1))
+}
+
+// sleep espera d ou até o contexto ser cancelado
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/handlers"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+// newTestServer sobe o TaskHandler real sobre um repositório em memória
+func newTestServer(t *testing.T) *Client {
+	t.Helper()
+	handler := handlers.NewTaskHandler(service.NewTaskService(repository.NewInMemoryTaskRepository()))
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/tasks", http.StripPrefix("/api/v1", handler))
+	mux.Handle("/api/v1/tasks/", http.StripPrefix("/api/v1", handler))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, WithRetry(0, 0))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return c
+}
+
+func TestClientTaskLifecycle(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+
+	created, err := c.CreateTask(ctx, models.CreateTaskRequest{Title: "From SDK", Priority: models.PriorityHigh})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if created.ID == "" || created.Status != models.StatusTodo {
+		t.Errorf("unexpected created task %+v", created)
+	}
+
+	status := models.StatusDone
+	updated, err := c.UpdateTask(ctx, created.ID, models.UpdateTaskRequest{Status: &status})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !updated.Completed {
+		t.Errorf("expected task to be completed, got %+v", updated)
+	}
+
+	tasks, err := c.GetAllTasks(ctx, ListOptions{Sort: "title"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "From SDK" {
+		t.Errorf("expected the created task, got %+v", tasks)
+	}
+
+	if err := c.DeleteTask(ctx, created.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := c.GetTaskByID(ctx, created.ID); !errors.Is(err, ErrTaskNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrTaskNotFound and ErrNotFound, got %v", err)
+	}
+}
+
+func TestClientReplaceTask(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+
+	created, _ := c.CreateTask(ctx, models.CreateTaskRequest{Title: "Original", Description: "Old", Priority: models.PriorityHigh})
+	replaced, err := c.ReplaceTask(ctx, created.ID, models.ReplaceTaskRequest{Title: "Replaced", Status: models.StatusInProgress})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if replaced.Title != "Replaced" || replaced.Description != "" || replaced.Priority != models.PriorityMedium {
+		t.Errorf("expected omitted fields to be reset, got %+v", replaced)
+	}
+
+	// UpdateTask só altera os campos informados
+	title := "Updated"
+	updated, err := c.UpdateTask(ctx, created.ID, models.UpdateTaskRequest{Title: &title})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Title != title || updated.Status != models.StatusInProgress {
+		t.Errorf("expected only the title to change, got %+v", updated)
+	}
+}
+
+func TestClientMapsValidationErrors(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+
+	_, err := c.CreateTask(ctx, models.CreateTaskRequest{})
+	if !errors.Is(err, ErrInvalidTitle) || !errors.Is(err, ErrBadRequest) {
+		t.Errorf("expected ErrInvalidTitle, got %v", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected *Error with status 400, got %#v", err)
+	}
+	if apiErr.Code != "invalid_title" || len(apiErr.Fields) != 1 || apiErr.Fields[0].Field != "title" {
+		t.Errorf("expected code and field of the problem, got %+v", apiErr)
+	}
+
+	task, _ := c.CreateTask(ctx, models.CreateTaskRequest{Title: "x"})
+	status := models.Status("blocked")
+	if _, err := c.UpdateTask(ctx, task.ID, models.UpdateTaskRequest{Status: &status}); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestClientMapsLegacyErrorBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "Task not found"}`))
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetry(0, 0))
+	if _, err := c.GetTaskByID(context.Background(), "1"); !errors.Is(err, ErrTaskNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrTaskNotFound and ErrNotFound, got %v", err)
+	}
+}
+
+func TestClientRetriesIdempotentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetry(3, time.Millisecond))
+	if _, err := c.GetAllTasks(context.Background(), ListOptions{}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetry(2, time.Millisecond))
+	err := c.DeleteTask(context.Background(), "1")
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 1 attempt plus 2 retries, got %d", calls.Load())
+	}
+}
+
+func TestClientDoesNotRetryPost(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetry(3, time.Millisecond))
+	if _, err := c.CreateTask(context.Background(), models.CreateTaskRequest{Title: "x"}); !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected POST not to be retried, got %d attempts", calls.Load())
+	}
+}
+
+func TestClientRetryStopsWhenContextIsCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c, _ := New(server.URL)
+	start := time.Now()
+	if _, err := c.GetTaskByID(ctx, "1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("expected Retry-After wait to be interrupted by the context")
+	}
+}
+
+func TestNewRejectsInvalidURL(t *testing.T) {
+	if _, err := New("localhost:8080"); err == nil {
+		t.Error("expected error for URL without scheme")
+	}
+}
+
+func TestClientMapsEveryInvalidField(t *testing.T) {
+	c := newTestServer(t)
+
+	_, err := c.CreateTask(context.Background(), models.CreateTaskRequest{Title: " ", Priority: "meh"})
+	if !errors.Is(err, ErrInvalidTitle) || !errors.Is(err, ErrInvalidPriority) || !errors.Is(err, ErrBadRequest) {
+		t.Errorf("expected ErrInvalidTitle and ErrInvalidPriority, got %v", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "validation_failed" || len(apiErr.Fields) != 2 {
+		t.Errorf("expected validation_failed with two fields, got %+v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/problem"
+)
+
+// Erros por classe de status HTTP; toda resposta de erro corresponde a um deles
+var (
+	ErrBadRequest = errors.New("bad request")
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrServer     = errors.New("server error")
+)
+
+// Erros específicos retornados pelos handlers de tarefas, com as mesmas
+// mensagens dos erros do servidor
+var (
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrLabelNotFound        = errors.New("label not found")
+	ErrInvalidTitle         = errors.New("title is required")
+	ErrInvalidStatus        = errors.New("invalid status")
+	ErrInvalidPriority      = errors.New("invalid priority")
+	ErrInvalidChecklistText = errors.New("checklist item text is required")
+	ErrTaskBlocked          = errors.New("task has open blockers")
+)
+
+// codeErrors associa o campo "code" das respostas application/problem+json
+// aos erros específicos
+var codeErrors = map[string]error{
+	"task_not_found":         ErrTaskNotFound,
+	"label_not_found":        ErrLabelNotFound,
+	"invalid_title":          ErrInvalidTitle,
+	"invalid_status":         ErrInvalidStatus,
+	"invalid_priority":       ErrInvalidPriority,
+	"invalid_checklist_text": ErrInvalidChecklistText,
+	"task_blocked":           ErrTaskBlocked,
+}
+
+// Error é uma resposta de erro da API (RFC 7807). errors.Is reconhece tanto
+// o erro específico (ex: ErrTaskNotFound) quanto a classe do status (ex:
+// ErrNotFound).
+type Error struct {
+	StatusCode int
+	// Code é o código estável do erro, ex: "task_not_found"
+	Code    string
+	Message string
+	// Fields lista os campos da requisição que não passaram na validação
+	Fields []problem.FieldError
+}
+
+// Error formata a mensagem e o status retornados pelo servidor
+func (e *Error) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s (HTTP %d)", message, e.StatusCode)
+}
+
+// Unwrap retorna o erro específico do código, se conhecido, os dos campos
+// listados em Fields e o da classe do status. Sem código reconhecido, como nas respostas {"error": "..."} de
+// servidores antigos ou nos erros de schema, a mensagem é comparada com a dos
+// erros específicos.
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if known, ok := codeErrors[e.Code]; ok {
+		errs = append(errs, known)
+	} else {
+		for _, known := range codeErrors {
+			if strings.EqualFold(e.Message, known.Error()) {
+				errs = append(errs, known)
+				break
+			}
+		}
+	}
+	for _, field := range e.Fields {
+		if known, ok := codeErrors[field.Code]; ok {
+			errs = append(errs, known)
+		}
+	}
+	if class := statusError(e.StatusCode); class != nil {
+		errs = append(errs, class)
+	}
+	return errs
+}
+
+// statusError retorna o erro da classe do status HTTP
+func statusError(code int) error {
+	switch {
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusConflict:
+		return ErrConflict
+	case code >= 500:
+		return ErrServer
+	case code >= 400:
+		return ErrBadRequest
+	default:
+		return nil
+	}
+}
+
+// newError lê o corpo de erro da resposta
+func newError(resp *http.Response) *Error {
+	var body struct {
+		problem.Problem
+		// Error é o formato anterior ao application/problem+json
+		Error string `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(data, &body)
+	message := body.Detail
+	if message == "" {
+		message = body.Error
+	}
+	return &Error{StatusCode: resp.StatusCode, Code: body.Code, Message: message, Fields: body.Errors}
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/client"
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// statusAliases aceita nomes curtos para os status na linha de comando
+var statusAliases = map[string]models.Status{
+	"todo":        models.StatusTodo,
+	"doing":       models.StatusInProgress,
+	"in_progress": models.StatusInProgress,
+	"progress":    models.StatusInProgress,
+	"done":        models.StatusDone,
+}
+
+// cli executa os comandos sobre o cliente da API
+type cli struct {
+	api    *client.Client
+	out    io.Writer
+	output string
+}
+
+// list executa "list [-archived] [-label ID] [-sort campo] [-desc]"
+func (c *cli) list(ctx context.Context, args []string) error {
+	flags := newFlagSet("list", "[-archived] [-label ID] [-sort dueDate|priority|title] [-desc]")
+	var opts client.ListOptions
+	flags.BoolVar(&opts.Archived, "archived", false, "lista as tarefas arquivadas")
+	flags.StringVar(&opts.Label, "label", "", "filtra pela etiqueta")
+	flags.StringVar(&opts.Sort, "sort", "", "ordena por dueDate, priority ou title")
+	flags.BoolVar(&opts.Desc, "desc", false, "inverte a ordenação")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+
+	tasks, err := c.api.GetAllTasks(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return c.printTasks(tasks)
+}
+
+// add executa "add [-d descrição] [-p prioridade] [-due data] [-label ID]... TÍTULO"
+func (c *cli) add(ctx context.Context, args []string) error {
+	flags := newFlagSet("add", "[-d descrição] [-p prioridade] [-due 2025-03-01] [-label ID]... TÍTULO")
+	var req models.CreateTaskRequest
+	var priority, due string
+	var labels stringList
+	flags.StringVar(&req.Description, "d", "", "descrição")
+	flags.StringVar(&priority, "p", "", "prioridade: low, medium, high ou urgent")
+	flags.StringVar(&due, "due", "", "prazo (2025-03-01 ou RFC 3339)")
+	flags.Var(&labels, "label", "ID de etiqueta (pode repetir)")
+	if err := parseArgs(flags, args, -1); err != nil {
+		return err
+	}
+
+	req.Title = strings.Join(flags.Args(), " ")
+	req.Priority = models.Priority(priority)
+	req.Labels = labels
+	if due != "" {
+		dueDate, err := parseDue(due)
+		if err != nil {
+			return err
+		}
+		req.DueDate = &dueDate
+	}
+
+	task, err := c.api.CreateTask(ctx, req)
+	if err != nil {
+		return err
+	}
+	return c.printTask(task)
+}
+
+// move executa "move ID STATUS"
+func (c *cli) move(ctx context.Context, args []string) error {
+	flags := newFlagSet("move", "ID todo|doing|done")
+	if err := parseArgs(flags, args, 2); err != nil {
+		return err
+	}
+
+	status, ok := statusAliases[strings.ToLower(flags.Arg(1))]
+	if !ok {
+		return fmt.Errorf("invalid status %q, use todo, doing or done", flags.Arg(1))
+	}
+
+	task, err := c.api.UpdateTask(ctx, flags.Arg(0), models.UpdateTaskRequest{Status: &status})
+	if err != nil {
+		return err
+	}
+	return c.printTask(task)
+}
+
+// edit executa "edit [-title título] [-d descrição] [-p prioridade] [-due data] ID"
+func (c *cli) edit(ctx context.Context, args []string) error {
+	flags := newFlagSet("edit", "[-title título] [-d descrição] [-p prioridade] [-due 2025-03-01] ID")
+	title := flags.String("title", "", "novo título")
+	description := flags.String("d", "", "nova descrição")
+	priority := flags.String("p", "", "nova prioridade")
+	due := flags.String("due", "", "novo prazo")
+	if err := parseArgs(flags, args, 1); err != nil {
+		return err
+	}
+
+	// Só envia os campos passados explicitamente, permitindo limpar a descrição com -d ""
+	var req models.UpdateTaskRequest
+	var err error
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "title":
+			req.Title = title
+		case "d":
+			req.Description = description
+		case "p":
+			p := models.Priority(*priority)
+			req.Priority = &p
+		case "due":
+			var dueDate time.Time
+			if dueDate, err = parseDue(*due); err == nil {
+				req.DueDate = &dueDate
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	task, err := c.api.UpdateTask(ctx, flags.Arg(0), req)
+	if err != nil {
+		return err
+	}
+	return c.printTask(task)
+}
+
+// delete executa "delete ID..."
+func (c *cli) delete(ctx context.Context, args []string) error {
+	flags := newFlagSet("delete", "ID...")
+	if err := parseArgs(flags, args, -1); err != nil {
+		return err
+	}
+
+	for _, id := range flags.Args() {
+		if err := c.api.DeleteTask(ctx, id); err != nil {
+			return fmt.Errorf("%s: %w", id, err)
+		}
+		if c.output == outputTable {
+			fmt.Fprintf(c.out, "Tarefa %s removida\n", id)
+		}
+	}
+	return nil
+}
+
+// watch executa "watch [-interval 2s]", consultando o quadro periodicamente e
+// imprimindo as tarefas criadas, movidas, editadas e removidas até Ctrl+C
+func (c *cli) watch(ctx context.Context, args []string) error {
+	flags := newFlagSet("watch", "[-interval 2s]")
+	interval := flags.Duration("interval", 2*time.Second, "intervalo entre consultas")
+	if err := parseArgs(flags, args, 0); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+
+	var previous map[string]*models.Task
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		tasks, err := c.api.GetAllTasks(ctx, client.ListOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		current := make(map[string]*models.Task, len(tasks))
+		for _, task := range tasks {
+			current[task.ID] = task
+		}
+		if previous == nil {
+			if err := c.printTasks(tasks); err != nil {
+				return err
+			}
+		} else if err := c.printChanges(diffTasks(previous, current, tasks)); err != nil {
+			return err
+		}
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// newFlagSet cria o FlagSet de um comando com a linha de uso
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Uso: kanban %s %s\n", name, synopsis)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseArgs interpreta as flags e confere a quantidade de argumentos
+// posicionais (-1 exige ao menos um)
+func parseArgs(flags *flag.FlagSet, args []string, want int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if (want < 0 && flags.NArg() == 0) || (want >= 0 && flags.NArg() != want) {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	return nil
+}
+
+// parseDue aceita uma data (fim do dia em UTC) ou um timestamp RFC 3339
+func parseDue(value string) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date.Add(24*time.Hour - time.Second), nil
+	}
+	due, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q, use 2025-03-01 or RFC 3339", value)
+	}
+	return due, nil
+}
+
+// stringList é uma flag que pode ser repetida
+type stringList []string
+
+// String retorna os valores separados por vírgula
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set acrescenta um valor
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
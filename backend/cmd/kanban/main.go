@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/acauhi/kanban-backend/client"
+)
+
+const (
+	defaultServer = "http://localhost:8080"
+	usage         = `Uso: kanban [-server URL] [-token TOKEN] [-o table|json] <comando> [argumentos]
+
+Comandos:
+  list    Lista as tarefas
+  add     Cria uma tarefa
+  move    Move uma tarefa para outro status
+  edit    Edita título, descrição, prioridade ou prazo
+  delete  Remove uma tarefa
+  watch   Acompanha as mudanças do quadro
+
+Configuração (da maior para a menor precedência): flags, variáveis
+KANBAN_SERVER e KANBAN_TOKEN, arquivo kanban/config.json no diretório de
+configuração do usuário ({"server": "...", "token": "..."}).
+`
+)
+
+// config reúne as opções globais da linha de comando
+type config struct {
+	Server string `json:"server"`
+	Token  string `json:"token"`
+	Output string `json:"output"`
+}
+
+// main interpreta as opções globais e executa o comando pedido
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "kanban:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run executa a linha de comando com a saída em out
+func run(ctx context.Context, args []string, out io.Writer) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("kanban", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	flags.StringVar(&cfg.Server, "server", cfg.Server, "URL do servidor")
+	flags.StringVar(&cfg.Token, "token", cfg.Token, "token de acesso")
+	flags.StringVar(&cfg.Output, "o", cfg.Output, "formato de saída: table ou json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+	if cfg.Output != outputTable && cfg.Output != outputJSON {
+		return fmt.Errorf("invalid output %q, use table or json", cfg.Output)
+	}
+
+	api, err := client.New(cfg.Server, client.WithToken(cfg.Token))
+	if err != nil {
+		return err
+	}
+	cli := &cli{api: api, out: out, output: cfg.Output}
+
+	command, rest := flags.Arg(0), flags.Args()[1:]
+	switch command {
+	case "list":
+		return cli.list(ctx, rest)
+	case "add":
+		return cli.add(ctx, rest)
+	case "move":
+		return cli.move(ctx, rest)
+	case "edit":
+		return cli.edit(ctx, rest)
+	case "delete":
+		return cli.delete(ctx, rest)
+	case "watch":
+		return cli.watch(ctx, rest)
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// loadConfig lê o arquivo de configuração (se existir) e aplica as variáveis de ambiente
+func loadConfig() (config, error) {
+	cfg := config{Server: defaultServer, Output: outputTable}
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		data, err := os.ReadFile(filepath.Join(dir, "kanban", "config.json"))
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("read config: %w", err)
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return cfg, err
+		}
+	}
+
+	if server := os.Getenv("KANBAN_SERVER"); server != "" {
+		cfg.Server = server
+	}
+	if token := os.Getenv("KANBAN_TOKEN"); token != "" {
+		cfg.Token = token
+	}
+	return cfg, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// Formatos de saída
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// change é uma mudança observada pelo watch
+type change struct {
+	Event string       `json:"event"`
+	Task  *models.Task `json:"task"`
+}
+
+// printTasks imprime a lista como tabela ou JSON
+func (c *cli) printTasks(tasks []*models.Task) error {
+	if c.output == outputJSON {
+		return c.printJSON(tasks)
+	}
+
+	tw := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tPRIORIDADE\tPRAZO\tTÍTULO")
+	for _, task := range tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", task.ID, task.Status, task.Priority, formatDue(task), task.Title)
+	}
+	return tw.Flush()
+}
+
+// printTask imprime uma tarefa
+func (c *cli) printTask(task *models.Task) error {
+	if c.output == outputJSON {
+		return c.printJSON(task)
+	}
+	return c.printTasks([]*models.Task{task})
+}
+
+// printChanges imprime as mudanças do watch, uma por linha
+func (c *cli) printChanges(changes []change) error {
+	for _, ch := range changes {
+		if c.output == outputJSON {
+			if err := json.NewEncoder(c.out).Encode(ch); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(c.out, "%-8s %s [%s] %s\n", ch.Event, ch.Task.ID, ch.Task.Status, ch.Task.Title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printJSON imprime o valor em JSON indentado
+func (c *cli) printJSON(v any) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// diffTasks compara duas consultas do quadro; ordered mantém a ordem da consulta atual
+func diffTasks(previous, current map[string]*models.Task, ordered []*models.Task) []change {
+	var changes []change
+	for _, task := range ordered {
+		old, ok := previous[task.ID]
+		switch {
+		case !ok:
+			changes = append(changes, change{Event: "added", Task: task})
+		case old.Status != task.Status:
+			changes = append(changes, change{Event: "moved", Task: task})
+		case old.Title != task.Title || old.Description != task.Description || old.Priority != task.Priority ||
+			!equalDue(old.DueDate, task.DueDate):
+			changes = append(changes, change{Event: "edited", Task: task})
+		}
+	}
+	var deleted []*models.Task
+	for id, task := range previous {
+		if _, ok := current[id]; !ok {
+			deleted = append(deleted, task)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i].ID < deleted[j].ID })
+	for _, task := range deleted {
+		changes = append(changes, change{Event: "deleted", Task: task})
+	}
+	return changes
+}
+
+// equalDue compara prazos opcionais
+func equalDue(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// formatDue formata o prazo para a tabela
+func formatDue(task *models.Task) string {
+	if task.DueDate == nil {
+		return "-"
+	}
+	return task.DueDate.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,595 @@
+package compression
+
+import (
+	"io"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+// CodingBrotli é o content-coding do brotli (RFC 7932)
+const CodingBrotli = "br"
+
+// O encoder brotli usa LZ77 guloso com janela de 64 KiB e códigos de prefixo
+// próprios em cada meta-bloco, sem o dicionário estático nem modelagem de
+// contexto do formato: comprime como o gzip, mas com o coding que os
+// navegadores preferem. Um encoder mais forte pode substituí-lo com
+// WithEncoder(CodingBrotli, ...).
+const (
+	// brotliWindowBits é o WBITS do stream, codificado com um único bit 0
+	brotliWindowBits  = 16
+	brotliMaxDistance = 1<<brotliWindowBits - 16
+	// brotliBlockSize é o maior meta-bloco; com até 2^16 bytes, MLEN-1 cabe
+	// em 4 nibbles
+	brotliBlockSize = 1 << 16
+
+	brotliMinMatch    = 4
+	brotliHashBits    = 15
+	brotliChainLength = 32
+
+	brotliLiteralAlphabet  = 256
+	brotliCommandAlphabet  = 704
+	brotliDistanceAlphabet = 64 // 16 + NDIRECT + 48<<NPOSTFIX, com ambos em zero
+
+	brotliMaxCodeLength           = 15
+	brotliMaxCodeLengthCodeLength = 5
+	brotliRepeatZeroCode          = 17
+)
+
+// Bases e bits extras dos códigos de comprimento de inserção e de cópia
+var (
+	brotliInsertBase  = [24]int{0, 1, 2, 3, 4, 5, 6, 8, 10, 14, 18, 26, 34, 50, 66, 98, 130, 194, 322, 578, 1090, 2114, 6210, 22594}
+	brotliInsertExtra = [24]uint{0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 7, 8, 9, 10, 12, 14, 24}
+	brotliCopyBase    = [24]int{2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 14, 18, 22, 30, 38, 54, 70, 102, 134, 198, 326, 582, 1094, 2118}
+	brotliCopyExtra   = [24]uint{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 7, 8, 9, 10, 24}
+)
+
+// brotliCommandCells é o primeiro símbolo de inserção e cópia de cada célula
+// [código de inserção / 8][código de cópia / 8] com distância explícita
+var brotliCommandCells = [3][3]int{
+	{128, 192, 384},
+	{256, 320, 512},
+	{448, 576, 640},
+}
+
+// brotliCodeLengthOrder é a ordem em que os comprimentos do código de
+// comprimentos são transmitidos
+var brotliCodeLengthOrder = [18]int{1, 2, 3, 4, 0, 5, 17, 6, 16, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// brotliCodeLengthPrefix é o código fixo (valor, bits) dos comprimentos 0 a 5
+// do código de comprimentos
+var brotliCodeLengthPrefix = [6][2]uint{{0, 2}, {7, 4}, {3, 3}, {2, 2}, {1, 2}, {15, 4}}
+
+// brotliCommand insere insertLen literais e copia copyLen bytes de distance
+// bytes atrás; copyLen zero encerra o meta-bloco depois dos literais
+type brotliCommand struct {
+	insertLen int
+	copyLen   int
+	distance  int
+}
+
+// brotliWriter comprime o que recebe em meta-blocos de até brotliBlockSize
+type brotliWriter struct {
+	w   io.Writer
+	out bitWriter
+
+	// buf guarda a janela já comprimida seguida do que falta comprimir;
+	// buf[0] é a posição start do stream
+	buf     []byte
+	start   int
+	pending int
+
+	head  []int32
+	chain []int32
+
+	wroteHeader bool
+	err         error
+}
+
+var brotliWriters = sync.Pool{
+	New: func() any {
+		return &brotliWriter{
+			head:  make([]int32, 1<<brotliHashBits),
+			chain: make([]int32, brotliBlockSize),
+		}
+	},
+}
+
+// pooledBrotliWriter devolve o brotliWriter ao pool ao fechar
+type pooledBrotliWriter struct {
+	*brotliWriter
+}
+
+func newBrotliWriter(w io.Writer) io.WriteCloser {
+	bw := brotliWriters.Get().(*brotliWriter)
+	bw.reset(w)
+	return pooledBrotliWriter{bw}
+}
+
+func (w pooledBrotliWriter) Close() error {
+	err := w.brotliWriter.Close()
+	w.reset(nil)
+	brotliWriters.Put(w.brotliWriter)
+	return err
+}
+
+// reset prepara o writer para um novo stream sobre w
+func (w *brotliWriter) reset(dst io.Writer) {
+	w.w = dst
+	w.out.reset()
+	w.buf = w.buf[:0]
+	w.start = 0
+	w.pending = 0
+	clear(w.head)
+	w.wroteHeader = false
+	w.err = nil
+}
+
+func (w *brotliWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := len(p)
+	for len(p) > 0 {
+		chunk := min(len(p), brotliBlockSize-w.pending)
+		w.buf = append(w.buf, p[:chunk]...)
+		w.pending += chunk
+		p = p[chunk:]
+		if w.pending == brotliBlockSize {
+			w.compressPending()
+			if err := w.emit(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush comprime o que está pendente e alinha o stream ao byte com um
+// meta-bloco de metadados vazio, para que o cliente decodifique tudo o que
+// já foi enviado
+func (w *brotliWriter) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.compressPending()
+	w.out.writeBits(0, 1) // ISLAST
+	w.out.writeBits(3, 2) // MNIBBLES = 0: metadados
+	w.out.writeBits(0, 1) // reservado
+	w.out.writeBits(0, 2) // MSKIPBYTES
+	w.out.alignByte()
+	return w.emit()
+}
+
+// Close comprime o que está pendente e encerra o stream
+func (w *brotliWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.compressPending()
+	w.out.writeBits(1, 1) // ISLAST
+	w.out.writeBits(1, 1) // ISLASTEMPTY
+	w.out.alignByte()
+	return w.emit()
+}
+
+// emit repassa os bytes completos ao destino
+func (w *brotliWriter) emit() error {
+	if _, err := w.w.Write(w.out.bytes); err != nil {
+		w.err = err
+		return err
+	}
+	w.out.bytes = w.out.bytes[:0]
+	return nil
+}
+
+// compressPending escreve o que falta comprimir como um meta-bloco e descarta
+// da janela o que ficou além da maior distância
+func (w *brotliWriter) compressPending() {
+	if !w.wroteHeader {
+		w.out.writeBits(0, 1) // WBITS = 16
+		w.wroteHeader = true
+	}
+	if w.pending == 0 {
+		return
+	}
+
+	blockStart := len(w.buf) - w.pending
+	commands := w.findMatches(blockStart)
+	w.writeMetaBlock(blockStart, commands)
+	w.pending = 0
+
+	if drop := len(w.buf) - brotliMaxDistance; drop > 0 {
+		w.buf = w.buf[:copy(w.buf, w.buf[drop:])]
+		w.start += drop
+	}
+}
+
+// hash4 espalha os 4 bytes a partir de p na tabela de hash
+func hash4(p []byte) uint32 {
+	v := uint32(p[0]) | uint32(p[1])<<8 | uint32(p[2])<<16 | uint32(p[3])<<24
+	return (v * 0x1e35a7bd) >> (32 - brotliHashBits)
+}
+
+// findMatches percorre o bloco a partir de blockStart com LZ77 guloso. head
+// e chain guardam posições do stream mais um, de modo que zero é vazio.
+func (w *brotliWriter) findMatches(blockStart int) []brotliCommand {
+	var commands []brotliCommand
+	end := len(w.buf)
+	literalStart := blockStart
+
+	insert := func(i int) {
+		if i+brotliMinMatch > end {
+			return
+		}
+		pos := w.start + i
+		h := hash4(w.buf[i:])
+		w.chain[pos%brotliBlockSize] = w.head[h]
+		w.head[h] = int32(pos + 1)
+	}
+
+	for i := blockStart; i < end; {
+		bestLen, bestDist := 0, 0
+		if i+brotliMinMatch <= end {
+			pos := w.start + i
+			candidate := int(w.head[hash4(w.buf[i:])]) - 1
+			for tries := 0; candidate >= 0 && tries < brotliChainLength; tries++ {
+				dist := pos - candidate
+				if dist > brotliMaxDistance || candidate < w.start {
+					break
+				}
+				j := candidate - w.start
+				n := 0
+				for i+n < end && w.buf[j+n] == w.buf[i+n] {
+					n++
+				}
+				if n > bestLen {
+					bestLen, bestDist = n, dist
+				}
+				next := int(w.chain[candidate%brotliBlockSize]) - 1
+				if next >= candidate {
+					break
+				}
+				candidate = next
+			}
+		}
+
+		if bestLen < brotliMinMatch {
+			insert(i)
+			i++
+			continue
+		}
+		commands = append(commands, brotliCommand{insertLen: i - literalStart, copyLen: bestLen, distance: bestDist})
+		for k := range bestLen {
+			insert(i + k)
+		}
+		i += bestLen
+		literalStart = i
+	}
+	if literalStart < end {
+		commands = append(commands, brotliCommand{insertLen: end - literalStart})
+	}
+	return commands
+}
+
+// lengthCode retorna o código cuja faixa contém n
+func lengthCode(base *[24]int, n int) int {
+	code := 0
+	for code+1 < len(base) && base[code+1] <= n {
+		code++
+	}
+	return code
+}
+
+// distanceCode decompõe a distância no código (a partir de 16) e nos bits
+// extras, para NPOSTFIX e NDIRECT iguais a zero
+func distanceCode(distance int) (code int, nbits uint, extra uint64) {
+	v := distance + 3
+	nbits = uint(bits.Len(uint(v)) - 2)
+	prefix := v >> nbits
+	code = 16 + 2*(int(nbits)-1) + prefix - 2
+	return code, nbits, uint64(v - prefix<<nbits)
+}
+
+// writeMetaBlock escreve o meta-bloco com os comandos do bloco iniciado em
+// blockStart
+func (w *brotliWriter) writeMetaBlock(blockStart int, commands []brotliCommand) {
+	literalFreq := make([]uint32, brotliLiteralAlphabet)
+	commandFreq := make([]uint32, brotliCommandAlphabet)
+	distanceFreq := make([]uint32, brotliDistanceAlphabet)
+
+	type encoded struct {
+		symbol               int
+		insertCode, copyCode int
+		distCode             int
+		distBits             uint
+		distExtra            uint64
+	}
+	encodedCommands := make([]encoded, len(commands))
+	pos := blockStart
+	for i, cmd := range commands {
+		e := encoded{insertCode: lengthCode(&brotliInsertBase, cmd.insertLen)}
+		if cmd.copyLen > 0 {
+			e.copyCode = lengthCode(&brotliCopyBase, cmd.copyLen)
+			e.distCode, e.distBits, e.distExtra = distanceCode(cmd.distance)
+			distanceFreq[e.distCode]++
+		}
+		e.symbol = brotliCommandCells[e.insertCode>>3][e.copyCode>>3] + (e.insertCode&7)<<3 + e.copyCode&7
+		commandFreq[e.symbol]++
+		for _, b := range w.buf[pos : pos+cmd.insertLen] {
+			literalFreq[b]++
+		}
+		pos += cmd.insertLen + cmd.copyLen
+		encodedCommands[i] = e
+	}
+
+	out := &w.out
+	mlen := len(w.buf) - blockStart
+	out.writeBits(0, 1)               // ISLAST
+	out.writeBits(0, 2)               // MNIBBLES = 4
+	out.writeBits(uint64(mlen-1), 16) // MLEN - 1
+	out.writeBits(0, 1)               // ISUNCOMPRESSED
+	out.writeBits(0, 3)               // NBLTYPESL, NBLTYPESI e NBLTYPESD = 1
+	out.writeBits(0, 2)               // NPOSTFIX
+	out.writeBits(0, 4)               // NDIRECT
+	out.writeBits(0, 2)               // modo de contexto LSB6 do único tipo de literal
+	out.writeBits(0, 2)               // NTREESL e NTREESD = 1
+
+	literals := out.writePrefixCode(literalFreq)
+	commandCodes := out.writePrefixCode(commandFreq)
+	distances := out.writePrefixCode(distanceFreq)
+
+	pos = blockStart
+	for i, cmd := range commands {
+		e := encodedCommands[i]
+		commandCodes.write(out, e.symbol)
+		out.writeBits(uint64(cmd.insertLen-brotliInsertBase[e.insertCode]), brotliInsertExtra[e.insertCode])
+		// No último comando sem cópia, o comprimento 2 do código 0 é ignorado
+		copyLen := max(cmd.copyLen, brotliCopyBase[e.copyCode])
+		out.writeBits(uint64(copyLen-brotliCopyBase[e.copyCode]), brotliCopyExtra[e.copyCode])
+		for _, b := range w.buf[pos : pos+cmd.insertLen] {
+			literals.write(out, int(b))
+		}
+		if cmd.copyLen > 0 {
+			distances.write(out, e.distCode)
+			out.writeBits(e.distExtra, e.distBits)
+		}
+		pos += cmd.insertLen + cmd.copyLen
+	}
+}
+
+// prefixCode guarda os códigos canônicos, já com os bits invertidos para a
+// escrita a partir do bit menos significativo
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint16
+}
+
+func (c prefixCode) write(out *bitWriter, symbol int) {
+	out.writeBits(uint64(c.codes[symbol]), uint(c.lengths[symbol]))
+}
+
+// writePrefixCode monta o código de prefixo das frequências e o escreve: com
+// um só símbolo, como código simples de zero bits; com mais, como código
+// complexo descrito por comprimentos
+func (out *bitWriter) writePrefixCode(freqs []uint32) prefixCode {
+	alphabetBits := uint(bits.Len(uint(len(freqs) - 1)))
+	var used []int
+	for symbol, f := range freqs {
+		if f > 0 {
+			used = append(used, symbol)
+		}
+	}
+	if len(used) <= 1 {
+		symbol := 0
+		if len(used) == 1 {
+			symbol = used[0]
+		}
+		out.writeBits(1, 2) // HSKIP = 1: código simples
+		out.writeBits(0, 2) // NSYM - 1
+		out.writeBits(uint64(symbol), alphabetBits)
+		return prefixCode{lengths: make([]uint8, len(freqs)), codes: make([]uint16, len(freqs))}
+	}
+
+	lengths := huffmanLengths(freqs, brotliMaxCodeLength)
+	last := used[len(used)-1]
+
+	// Comprimentos em sequência, com o código 17 para três ou mais zeros. Um
+	// 17 logo após outro estenderia a repetição anterior, então corridas
+	// longas são separadas por um zero literal.
+	type lengthSymbol struct {
+		symbol int
+		extra  uint64
+	}
+	var sequence []lengthSymbol
+	for i := 0; i <= last; {
+		if lengths[i] != 0 {
+			sequence = append(sequence, lengthSymbol{symbol: int(lengths[i])})
+			i++
+			continue
+		}
+		run := 0
+		for i+run <= last && lengths[i+run] == 0 {
+			run++
+		}
+		i += run
+		for run > 0 {
+			if run < 3 {
+				for range run {
+					sequence = append(sequence, lengthSymbol{symbol: 0})
+				}
+				break
+			}
+			n := min(run, 10)
+			sequence = append(sequence, lengthSymbol{symbol: brotliRepeatZeroCode, extra: uint64(n - 3)})
+			run -= n
+			if run > 0 {
+				sequence = append(sequence, lengthSymbol{symbol: 0})
+				run--
+			}
+		}
+	}
+
+	clFreqs := make([]uint32, 18)
+	for _, s := range sequence {
+		clFreqs[s.symbol]++
+	}
+	clLengths := huffmanLengths(clFreqs, brotliMaxCodeLengthCodeLength)
+	clCode := canonicalCode(clLengths)
+
+	// Com um só comprimento em uso, o leitor lê os 18 e o símbolo custa zero
+	// bits; com mais, a leitura para quando o código fica completo
+	clUsed, lastIndex := 0, 0
+	for i, symbol := range brotliCodeLengthOrder {
+		if clLengths[symbol] != 0 {
+			clUsed++
+			lastIndex = i
+		}
+	}
+	if clUsed == 1 {
+		lastIndex = len(brotliCodeLengthOrder) - 1
+		clCode = prefixCode{lengths: make([]uint8, 18), codes: make([]uint16, 18)}
+	}
+
+	out.writeBits(0, 2) // HSKIP = 0
+	for _, symbol := range brotliCodeLengthOrder[:lastIndex+1] {
+		prefix := brotliCodeLengthPrefix[clLengths[symbol]]
+		out.writeBits(uint64(prefix[0]), prefix[1])
+	}
+	for _, s := range sequence {
+		clCode.write(out, s.symbol)
+		if s.symbol == brotliRepeatZeroCode {
+			out.writeBits(s.extra, 3)
+		}
+	}
+
+	return canonicalCode(lengths)
+}
+
+// canonicalCode atribui os códigos canônicos aos comprimentos: os mais curtos
+// primeiro e, no mesmo comprimento, na ordem dos símbolos
+func canonicalCode(lengths []uint8) prefixCode {
+	var count [brotliMaxCodeLength + 1]uint16
+	for _, l := range lengths {
+		count[l]++
+	}
+	count[0] = 0
+	var next [brotliMaxCodeLength + 2]uint16
+	code := uint16(0)
+	for l := 1; l <= brotliMaxCodeLength; l++ {
+		code = (code + count[l-1]) << 1
+		next[l] = code
+	}
+
+	codes := make([]uint16, len(lengths))
+	for symbol, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		codes[symbol] = bits.Reverse16(next[l]) >> (16 - l)
+		next[l]++
+	}
+	return prefixCode{lengths: lengths, codes: codes}
+}
+
+// huffmanLengths calcula comprimentos de Huffman limitados a maxBits. Se a
+// árvore passar do limite, as frequências baixas são elevadas a um piso que
+// dobra a cada tentativa, como no encoder de referência.
+func huffmanLengths(freqs []uint32, maxBits int) []uint8 {
+	type node struct {
+		weight      uint64
+		left, right int // -1 nas folhas
+		symbol      int
+	}
+
+	lengths := make([]uint8, len(freqs))
+	for floor := uint64(1); ; floor *= 2 {
+		var nodes []node
+		for symbol, f := range freqs {
+			if f > 0 {
+				nodes = append(nodes, node{weight: max(uint64(f), floor), left: -1, right: -1, symbol: symbol})
+			}
+		}
+		if len(nodes) == 1 {
+			lengths[nodes[0].symbol] = 1
+			return lengths
+		}
+		slices.SortStableFunc(nodes, func(a, b node) int {
+			switch {
+			case a.weight < b.weight:
+				return -1
+			case a.weight > b.weight:
+				return 1
+			}
+			return 0
+		})
+
+		// Duas filas: folhas ordenadas e nós internos, que já nascem em ordem
+		leaves := len(nodes)
+		nextLeaf, nextInternal := 0, leaves
+		pick := func() int {
+			if nextLeaf < leaves && (nextInternal >= len(nodes) || nodes[nextLeaf].weight <= nodes[nextInternal].weight) {
+				nextLeaf++
+				return nextLeaf - 1
+			}
+			nextInternal++
+			return nextInternal - 1
+		}
+		for range leaves - 1 {
+			a, b := pick(), pick()
+			nodes = append(nodes, node{weight: nodes[a].weight + nodes[b].weight, left: a, right: b})
+		}
+
+		depths := make([]int, len(nodes))
+		maxDepth := 0
+		for i := len(nodes) - 1; i >= leaves; i-- {
+			depths[nodes[i].left] = depths[i] + 1
+			depths[nodes[i].right] = depths[i] + 1
+		}
+		for i := range leaves {
+			maxDepth = max(maxDepth, depths[i])
+		}
+		if maxDepth > maxBits {
+			continue
+		}
+		for i := range leaves {
+			lengths[nodes[i].symbol] = uint8(depths[i])
+		}
+		return lengths
+	}
+}
+
+// bitWriter acumula bits a partir do menos significativo de cada byte
+type bitWriter struct {
+	bytes []byte
+	acc   uint64
+	nbits uint
+}
+
+func (b *bitWriter) reset() {
+	b.bytes = b.bytes[:0]
+	b.acc = 0
+	b.nbits = 0
+}
+
+func (b *bitWriter) writeBits(value uint64, n uint) {
+	for n > 0 {
+		chunk := min(n, 32)
+		b.acc |= (value & (1<<chunk - 1)) << b.nbits
+		b.nbits += chunk
+		value >>= chunk
+		n -= chunk
+		for b.nbits >= 8 {
+			b.bytes = append(b.bytes, byte(b.acc))
+			b.acc >>= 8
+			b.nbits -= 8
+		}
+	}
+}
+
+// alignByte completa o byte atual com zeros
+func (b *bitWriter) alignByte() {
+	if b.nbits > 0 {
+		b.writeBits(0, 8-b.nbits)
+	}
+}
@@ -0,0 +1,398 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testBitReader lê bits a partir do menos significativo de cada byte
+type testBitReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *testBitReader) read(n int) int {
+	v := 0
+	for i := range n {
+		if r.pos >= len(r.data)*8 {
+			r.err = io.ErrUnexpectedEOF
+			return 0
+		}
+		v |= int(r.data[r.pos/8]>>(r.pos%8)&1) << i
+		r.pos++
+	}
+	return v
+}
+
+func (r *testBitReader) align() {
+	r.pos = (r.pos + 7) &^ 7
+}
+
+// testPrefixCode decodifica um código canônico bit a bit
+type testPrefixCode struct {
+	symbols map[[2]int]int // {comprimento, código} -> símbolo
+	single  int            // símbolo de zero bits, ou -1
+}
+
+func newTestPrefixCode(lengths []int) testPrefixCode {
+	c := testPrefixCode{symbols: make(map[[2]int]int), single: -1}
+	var used []int
+	for symbol, l := range lengths {
+		if l > 0 {
+			used = append(used, symbol)
+		}
+	}
+	if len(used) == 1 {
+		c.single = used[0]
+		return c
+	}
+	slices.SortStableFunc(used, func(a, b int) int { return lengths[a] - lengths[b] })
+	code, prev := 0, 0
+	for _, symbol := range used {
+		code <<= lengths[symbol] - prev
+		prev = lengths[symbol]
+		c.symbols[[2]int{prev, code}] = symbol
+		code++
+	}
+	return c
+}
+
+func (c testPrefixCode) decode(r *testBitReader) (int, error) {
+	if c.single >= 0 {
+		return c.single, nil
+	}
+	code := 0
+	for l := 1; l <= 15 && r.err == nil; l++ {
+		code = code<<1 | r.read(1)
+		if symbol, ok := c.symbols[[2]int{l, code}]; ok {
+			return symbol, r.err
+		}
+	}
+	return 0, errors.New("invalid prefix code")
+}
+
+// readTestPrefixCode lê um código de prefixo simples ou complexo
+func readTestPrefixCode(r *testBitReader, alphabet int) (testPrefixCode, error) {
+	hskip := r.read(2)
+	if hskip == 1 {
+		nsym := r.read(2) + 1
+		symbols := make([]int, nsym)
+		for i := range symbols {
+			symbols[i] = r.read(bits.Len(uint(alphabet - 1)))
+		}
+		shapes := [][]int{{0}, {1, 1}, {1, 2, 2}, {2, 2, 2, 2}}
+		shape := shapes[nsym-1]
+		if nsym == 4 && r.read(1) == 1 {
+			shape = []int{1, 2, 3, 3}
+		}
+		lengths := make([]int, alphabet)
+		for i, symbol := range symbols {
+			lengths[symbol] = max(shape[i], 1)
+		}
+		if nsym == 1 {
+			return testPrefixCode{single: symbols[0]}, r.err
+		}
+		return newTestPrefixCode(lengths), r.err
+	}
+
+	clLengths := make([]int, 18)
+	space, used := 32, 0
+	for _, symbol := range brotliCodeLengthOrder[hskip:] {
+		v := r.read(2)
+		switch v {
+		case 1:
+			v = 4
+		case 2:
+			v = 3
+		case 3:
+			v = 2
+			if r.read(1) == 1 {
+				v = 1
+				if r.read(1) == 1 {
+					v = 5
+				}
+			}
+		}
+		clLengths[symbol] = v
+		if v != 0 {
+			space -= 32 >> v
+			used++
+			if space <= 0 {
+				break
+			}
+		}
+	}
+	if used != 1 && space != 0 {
+		return testPrefixCode{}, errors.New("incomplete code length code")
+	}
+	cl := newTestPrefixCode(clLengths)
+
+	lengths := make([]int, alphabet)
+	symbolSpace, prev := 32768, 8
+	repeat, repeatLen := 0, 0
+	for symbol := 0; symbol < alphabet && symbolSpace > 0; {
+		c, err := cl.decode(r)
+		if err != nil {
+			return testPrefixCode{}, err
+		}
+		if c < 16 {
+			repeat = 0
+			lengths[symbol] = c
+			symbol++
+			if c != 0 {
+				prev = c
+				symbolSpace -= 32768 >> c
+			}
+			continue
+		}
+		extraBits, newLen := 2, prev
+		if c == 17 {
+			extraBits, newLen = 3, 0
+		}
+		if repeatLen != newLen {
+			repeat, repeatLen = 0, newLen
+		}
+		old := repeat
+		if repeat > 0 {
+			repeat = (repeat - 2) << extraBits
+		}
+		repeat += r.read(extraBits) + 3
+		for range repeat - old {
+			if symbol >= alphabet {
+				return testPrefixCode{}, errors.New("code lengths past the alphabet")
+			}
+			lengths[symbol] = newLen
+			symbol++
+			if newLen != 0 {
+				symbolSpace -= 32768 >> newLen
+			}
+		}
+	}
+	if symbolSpace != 0 {
+		return testPrefixCode{}, errors.New("incomplete prefix code")
+	}
+	return newTestPrefixCode(lengths), r.err
+}
+
+// unbrotli decodifica o subconjunto do formato que o encoder produz. Um
+// stream interrompido num limite de meta-bloco devolve o que já foi
+// decodificado com io.ErrUnexpectedEOF.
+func unbrotli(data []byte) ([]byte, error) {
+	r := &testBitReader{data: data}
+	if r.read(1) != 0 {
+		return nil, errors.New("unexpected window size")
+	}
+	var out []byte
+	for {
+		last := r.read(1)
+		if r.err != nil {
+			return out, r.err
+		}
+		if last == 1 && r.read(1) == 1 {
+			return out, r.err
+		}
+		nibbles := r.read(2)
+		if nibbles == 3 {
+			r.read(1)
+			if r.read(2) != 0 {
+				return nil, errors.New("unexpected metadata")
+			}
+			r.align()
+			continue
+		}
+		mlen := r.read(4*(nibbles+4)) + 1
+		if last == 0 && r.read(1) == 1 {
+			return nil, errors.New("unexpected uncompressed meta-block")
+		}
+		if r.read(3) != 0 || r.read(6) != 0 {
+			return nil, errors.New("unexpected block types or distance parameters")
+		}
+		r.read(2)
+		if r.read(2) != 0 {
+			return nil, errors.New("unexpected context map")
+		}
+
+		var codes [3]testPrefixCode
+		for i, alphabet := range []int{brotliLiteralAlphabet, brotliCommandAlphabet, brotliDistanceAlphabet} {
+			code, err := readTestPrefixCode(r, alphabet)
+			if err != nil {
+				return nil, err
+			}
+			codes[i] = code
+		}
+		literals, commands, distances := codes[0], codes[1], codes[2]
+
+		end := len(out) + mlen
+		for len(out) < end {
+			symbol, err := commands.decode(r)
+			if err != nil {
+				return nil, err
+			}
+			insertCode, copyCode := -1, -1
+			for i, row := range brotliCommandCells {
+				for j, cell := range row {
+					if symbol&^63 == cell {
+						insertCode, copyCode = i*8+symbol>>3&7, j*8+symbol&7
+					}
+				}
+			}
+			if insertCode < 0 {
+				return nil, fmt.Errorf("unexpected implicit distance command %d", symbol)
+			}
+			insertLen := brotliInsertBase[insertCode] + r.read(int(brotliInsertExtra[insertCode]))
+			copyLen := brotliCopyBase[copyCode] + r.read(int(brotliCopyExtra[copyCode]))
+			for range insertLen {
+				b, err := literals.decode(r)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, byte(b))
+			}
+			if len(out) >= end {
+				break
+			}
+			code, err := distances.decode(r)
+			if err != nil {
+				return nil, err
+			}
+			if code < 16 {
+				return nil, fmt.Errorf("unexpected distance code %d", code)
+			}
+			h := code - 16
+			nbits := 1 + h>>1
+			distance := (2+h&1)<<nbits - 4 + r.read(nbits) + 1
+			if distance > len(out) || distance > brotliMaxDistance {
+				return nil, fmt.Errorf("distance %d out of the window", distance)
+			}
+			for range copyLen {
+				out = append(out, out[len(out)-distance])
+			}
+		}
+		if len(out) != end {
+			return nil, fmt.Errorf("meta-block overran its length by %d bytes", len(out)-end)
+		}
+		if last == 1 {
+			return out, r.err
+		}
+	}
+}
+
+// brotli comprime input escrevendo em pedaços de chunk bytes
+func brotli(t *testing.T, input []byte, chunk int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := newBrotliWriter(&buf)
+	for len(input) > 0 {
+		n := min(chunk, len(input))
+		if _, err := w.Write(input[:n]); err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+		input = input[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return buf.Bytes()
+}
+
+func TestBrotliWriterRoundTrip(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 3*brotliBlockSize/2)
+	random.Read(noise)
+	words := []string{"kanban ", "task ", "board ", "done ", "todo\n"}
+	var text strings.Builder
+	for text.Len() < 3*brotliBlockSize {
+		text.WriteString(words[random.Intn(len(words))])
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		chunk int
+	}{
+		{"empty", nil, 1},
+		{"single byte", []byte("a"), 1},
+		{"json", []byte(strings.Repeat(`{"title":"Write docs","status":"todo"},`, 500)), 100},
+		{"text across meta-blocks", []byte(text.String()), 7777},
+		{"random bytes", noise, brotliBlockSize},
+		{"long run", make([]byte, 2*brotliBlockSize+3), 5000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed := brotli(t, tt.input, tt.chunk)
+			got, err := unbrotli(compressed)
+			if err != nil {
+				t.Fatalf(msgExpectedNoError, err)
+			}
+			if !bytes.Equal(got, tt.input) {
+				t.Fatalf("expected %d bytes back, got %d that differ", len(tt.input), len(got))
+			}
+		})
+	}
+
+	json := []byte(strings.Repeat(`{"title":"Write docs"},`, 1000))
+	if compressed := brotli(t, json, len(json)); len(compressed) > len(json)/20 {
+		t.Errorf("expected repetitive input to shrink, got %d of %d bytes", len(compressed), len(json))
+	}
+}
+
+func TestBrotliWriterFlush(t *testing.T) {
+	var buf bytes.Buffer
+	w := newBrotliWriter(&buf)
+	first := strings.Repeat("first chunk ", 50)
+	io.WriteString(w, first)
+	if err := w.(interface{ Flush() error }).Flush(); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	got, err := unbrotli(buf.Bytes())
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected an unfinished stream after Flush, got %v", err)
+	}
+	if string(got) != first {
+		t.Errorf("expected the flushed data to decode, got %q", got)
+	}
+
+	io.WriteString(w, "second")
+	if err := w.Close(); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	got, err = unbrotli(buf.Bytes())
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if string(got) != first+"second" {
+		t.Errorf("expected both writes, got %q", got)
+	}
+}
+
+func TestHuffmanLengthsRespectsLimit(t *testing.T) {
+	// Frequências de Fibonacci geram a árvore mais funda possível
+	freqs := make([]uint32, 30)
+	a, b := uint32(1), uint32(1)
+	for i := range freqs {
+		freqs[i] = a
+		a, b = b, a+b
+	}
+
+	lengths := huffmanLengths(freqs, brotliMaxCodeLength)
+
+	kraft := 0
+	for i, l := range lengths {
+		if l == 0 || l > brotliMaxCodeLength {
+			t.Fatalf("expected symbol %d to have a length from 1 to %d, got %d", i, brotliMaxCodeLength, l)
+		}
+		kraft += 1 << (brotliMaxCodeLength - l)
+	}
+	if kraft != 1<<brotliMaxCodeLength {
+		t.Errorf("expected a complete code, got Kraft sum %d/%d", kraft, 1<<brotliMaxCodeLength)
+	}
+}
@@ -0,0 +1,274 @@
+package compression
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultMinSize é o menor corpo comprimido; abaixo disso o cabeçalho do
+	// formato custa mais do que economiza
+	DefaultMinSize = 1024
+
+	// CodingGzip é o content-coding embutido
+	CodingGzip = "gzip"
+)
+
+// Encoder cria o compressor de um content-coding sobre w. O compressor pode
+// implementar Flush() error para respostas enviadas aos poucos.
+type Encoder func(w io.Writer) io.WriteCloser
+
+// Middleware comprime as respostas no content-coding preferido pelo cliente
+// em Accept-Encoding
+type Middleware struct {
+	encoders map[string]Encoder
+	// preference desempata codings aceitos com o mesmo peso q
+	preference []string
+	minSize    int
+}
+
+type Option func(*Middleware)
+
+// WithEncoder registra um content-coding, ex: "zstd", ou substitui um dos
+// embutidos, ex: "br" com um encoder brotli mais forte. Os registrados têm
+// preferência sobre os embutidos quando o cliente aceita ambos com o mesmo
+// peso.
+func WithEncoder(coding string, encoder Encoder) Option {
+	return func(m *Middleware) {
+		coding = strings.ToLower(coding)
+		m.encoders[coding] = encoder
+		m.preference = slices.Insert(slices.DeleteFunc(m.preference, func(c string) bool { return c == coding }), 0, coding)
+	}
+}
+
+// WithMinSize define o menor corpo comprimido (padrão: DefaultMinSize)
+func WithMinSize(size int) Option {
+	return func(m *Middleware) {
+		m.minSize = size
+	}
+}
+
+// New cria o middleware com brotli e gzip habilitados, nessa ordem de
+// preferência
+func New(opts ...Option) *Middleware {
+	m := &Middleware{
+		encoders:   map[string]Encoder{CodingBrotli: newBrotliWriter, CodingGzip: newGzipWriter},
+		preference: []string{CodingBrotli, CodingGzip},
+		minSize:    DefaultMinSize,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Handler comprime as respostas de next. Ficam de fora respostas sem corpo
+// (HEAD, 204, 304), menores que o mínimo, com Content-Encoding já definido,
+// parciais (Range) e de tipos que não ganham com compressão, como imagens e
+// text/event-stream, que precisa chegar evento a evento.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		coding := m.negotiate(r.Header.Get("Accept-Encoding"))
+		if coding == "" || r.Method == http.MethodHead || r.Header.Get("Range") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &responseWriter{ResponseWriter: w, middleware: m, coding: coding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiate escolhe o coding aceito com maior peso; "*" vale para os codings
+// não citados e q=0 recusa. Retorna "" quando nenhum coding registrado serve.
+func (m *Middleware) negotiate(header string) string {
+	weights := make(map[string]float64)
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		weights[name] = q
+	}
+
+	var best string
+	var bestQ float64
+	for _, coding := range m.preference {
+		q, ok := weights[coding]
+		if !ok {
+			q, ok = weights["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressible indica os tipos de conteúdo textuais, que ganham com compressão
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "text/event-stream" {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/javascript", "application/xml", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// responseWriter guarda o começo do corpo até saber se vale comprimir: a
+// decisão sai ao atingir o tamanho mínimo, num Flush ou no fim do handler
+type responseWriter struct {
+	http.ResponseWriter
+	middleware *Middleware
+	coding     string
+
+	status  int
+	decided bool
+	buf     []byte
+	encoder io.WriteCloser
+}
+
+// WriteHeader adia o status até a decisão, que ainda pode mexer nos headers
+func (w *responseWriter) WriteHeader(status int) {
+	if w.decided || w.status != 0 {
+		return
+	}
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < w.middleware.minSize {
+			return len(p), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// decide escreve os headers e o que estava guardado, comprimindo se a
+// resposta permitir e o corpo for grande o bastante
+func (w *responseWriter) decide(bigEnough bool) error {
+	w.decided = true
+	header := w.Header()
+	if bigEnough && w.status != http.StatusPartialContent &&
+		header.Get("Content-Encoding") == "" && header.Get("Content-Range") == "" &&
+		compressible(header.Get("Content-Type")) {
+		header.Set("Content-Encoding", w.coding)
+		header.Del("Content-Length")
+		// O corpo comprimido tem outros bytes; só um ETag fraco continua válido
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = w.middleware.encoders[w.coding](w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(w.buf)
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf)
+	}
+	w.buf = nil
+	return err
+}
+
+// Flush envia o que já foi escrito, inclusive o que está no compressor
+func (w *responseWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		w.decide(true)
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap expõe o ResponseWriter original ao http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// close conclui a resposta ao fim do handler; corpos abaixo do mínimo saem
+// sem compressão
+func (w *responseWriter) close() {
+	if !w.decided && w.status != 0 {
+		w.decide(false)
+	}
+	if w.encoder != nil {
+		w.encoder.Close()
+	}
+}
+
+var gzipWriters = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(io.Discard)
+	},
+}
+
+// pooledGzipWriter devolve o gzip.Writer ao pool ao fechar
+type pooledGzipWriter struct {
+	*gzip.Writer
+}
+
+func newGzipWriter(w io.Writer) io.WriteCloser {
+	gz := gzipWriters.Get().(*gzip.Writer)
+	gz.Reset(w)
+	return pooledGzipWriter{gz}
+}
+
+func (w pooledGzipWriter) Close() error {
+	err := w.Writer.Close()
+	gzipWriters.Put(w.Writer)
+	return err
+}
@@ -0,0 +1,189 @@
+package compression
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+// bodyHandler responde com o corpo e o tipo de conteúdo informados
+func bodyHandler(contentType, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", "999")
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, body)
+	})
+}
+
+// get envia um GET com o Accept-Encoding informado
+func get(handler http.Handler, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// gunzip descomprime o corpo da resposta
+func gunzip(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	reader, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return string(body)
+}
+
+func TestHandlerCompressesWithGzip(t *testing.T) {
+	body := strings.Repeat(`{"title":"Write docs"},`, 100)
+	rec := get(New().Handler(bodyHandler("application/json", body)), "gzip, deflate")
+
+	if got := rec.Header().Get("Content-Encoding"); got != CodingGzip {
+		t.Fatalf("expected Content-Encoding gzip, got %q", got)
+	}
+	if got := gunzip(t, rec); got != body {
+		t.Errorf("expected the original body after decompressing, got %q", got)
+	}
+	if got := rec.Header().Get("Content-Length"); got != "" {
+		t.Errorf("expected no Content-Length, got %q", got)
+	}
+	if got := rec.Header().Get("ETag"); got != `W/"v1"` {
+		t.Errorf("expected a weak ETag, got %q", got)
+	}
+	if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("expected Vary Accept-Encoding, got %q", got)
+	}
+}
+
+func TestHandlerCompressesWithBrotli(t *testing.T) {
+	body := strings.Repeat(`{"title":"Write docs"},`, 100)
+	rec := get(New().Handler(bodyHandler("application/json", body)), "gzip, deflate, br")
+
+	if got := rec.Header().Get("Content-Encoding"); got != CodingBrotli {
+		t.Fatalf("expected Content-Encoding br, got %q", got)
+	}
+	got, err := unbrotli(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if string(got) != body {
+		t.Errorf("expected the original body after decompressing, got %q", got)
+	}
+}
+
+func TestHandlerSkipsResponses(t *testing.T) {
+	large := strings.Repeat("a", DefaultMinSize)
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		acceptEncoding string
+	}{
+		{"without Accept-Encoding", bodyHandler("application/json", large), ""},
+		{"unsupported coding", bodyHandler("application/json", large), "zstd"},
+		{"refused coding", bodyHandler("application/json", large), "gzip;q=0, br;q=0, *"},
+		{"small body", bodyHandler("application/json", "{}"), "gzip"},
+		{"binary content", bodyHandler("image/png", large), "gzip"},
+		{"event stream", bodyHandler("text/event-stream", large), "gzip"},
+		{"not modified", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotModified)
+		}), "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(New().Handler(tt.handler), tt.acceptEncoding)
+			if got := rec.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("expected no Content-Encoding, got %q", got)
+			}
+			if rec.Code == http.StatusOK && rec.Body.Len() == 0 {
+				t.Error("expected the body to pass through")
+			}
+		})
+	}
+}
+
+func TestHandlerSkipsRangeRequests(t *testing.T) {
+	handler := New().Handler(bodyHandler("text/plain", strings.Repeat("a", DefaultMinSize)))
+	req := httptest.NewRequest(http.MethodGet, "/file", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("Range", "bytes=0-9")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no Content-Encoding for a Range request, got %q", got)
+	}
+}
+
+// upperEncoder simula um coding registrado de fora, como zstd
+type upperEncoder struct {
+	w io.Writer
+}
+
+func (e upperEncoder) Write(p []byte) (int, error) {
+	return e.w.Write([]byte(strings.ToUpper(string(p))))
+}
+
+func (e upperEncoder) Close() error {
+	return nil
+}
+
+func TestHandlerPrefersRegisteredEncoders(t *testing.T) {
+	m := New(WithEncoder("zstd", func(w io.Writer) io.WriteCloser { return upperEncoder{w} }), WithMinSize(1))
+	handler := m.Handler(bodyHandler("text/plain", "abc"))
+
+	tests := map[string]string{
+		"gzip, br, zstd":      "zstd",
+		"gzip, br":            CodingBrotli,
+		"br;q=0.5, gzip":      CodingGzip,
+		"zstd;q=0.8, *;q=1":   CodingBrotli,
+		"GZIP":                CodingGzip,
+		"gzip;q=0.8, *;q=0.9": "zstd",
+	}
+	for acceptEncoding, want := range tests {
+		rec := get(handler, acceptEncoding)
+		if got := rec.Header().Get("Content-Encoding"); got != want {
+			t.Errorf("Accept-Encoding %q: expected %q, got %q", acceptEncoding, want, got)
+		}
+	}
+	if rec := get(handler, "zstd"); rec.Body.String() != "ABC" {
+		t.Errorf("expected the registered encoder to write the body, got %q", rec.Body)
+	}
+}
+
+func TestHandlerFlushesCompressedChunks(t *testing.T) {
+	flushed := make(chan string, 1)
+	handler := New().Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson+json")
+		io.WriteString(w, `{"n":1}`)
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf(msgExpectedNoError, err)
+		}
+		flushed <- w.(*responseWriter).Header().Get("Content-Encoding")
+	}))
+
+	rec := get(handler, "gzip")
+
+	if got := <-flushed; got != CodingGzip {
+		t.Errorf("expected Flush to start the gzip stream, got Content-Encoding %q", got)
+	}
+	if !rec.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	if got := gunzip(t, rec); got != `{"n":1}` {
+		t.Errorf("expected the flushed body, got %q", got)
+	}
+}
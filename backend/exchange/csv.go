@@ -0,0 +1,233 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// csvHeader define as colunas exportadas; na importação a ordem é livre e
+// colunas desconhecidas são ignoradas
+var csvHeader = []string{"id", "title", "description", "status", "priority", "dueDate", "labels", "labelColors", "completed", "archived"}
+
+// labelSeparator separa os nomes de etiquetas dentro da coluna labels e as
+// cores, na mesma ordem, dentro de labelColors. Nomes com o separador ou com
+// "\" os escapam com "\".
+const labelSeparator = ';'
+
+// formulaPrefixes são os caracteres com que planilhas iniciam fórmulas
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeCell neutraliza células que uma planilha executaria como fórmula,
+// ex: "=HYPERLINK(...)", prefixando "'". Células que já começam com "'"
+// antes de um desses caracteres ganham mais um, para que unescapeCell
+// devolva o texto original.
+func escapeCell(value string) string {
+	rest := strings.TrimLeft(value, "'")
+	if rest != "" && strings.ContainsRune(formulaPrefixes, rune(rest[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCell desfaz escapeCell
+func unescapeCell(value string) string {
+	rest := strings.TrimLeft(value, "'")
+	if rest != value && rest != "" && strings.ContainsRune(formulaPrefixes, rune(rest[0])) {
+		return value[1:]
+	}
+	return value
+}
+
+// writeCSV escreve uma linha por tarefa, descarregando o buffer a cada linha
+func writeCSV(w io.Writer, tasks []models.ExportTask) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, exported := range tasks {
+		task := exported.Task
+		names := make([]string, len(exported.Labels))
+		colors := make([]string, len(exported.Labels))
+		for i, label := range exported.Labels {
+			names[i] = label.Name
+			colors[i] = label.Color
+		}
+
+		dueDate := ""
+		if task.DueDate != nil {
+			dueDate = task.DueDate.Format(time.RFC3339)
+		}
+		record := []string{
+			task.ID,
+			task.Title,
+			task.Description,
+			string(task.Status),
+			string(task.Priority),
+			dueDate,
+			joinLabels(names),
+			joinLabels(colors),
+			strconv.FormatBool(task.Completed),
+			strconv.FormatBool(task.Archived),
+		}
+		for i, value := range record {
+			record[i] = escapeCell(value)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// readCSV lê o CSV usando a primeira linha como cabeçalho
+func readCSV(r io.Reader) ([]models.ImportTaskRow, []models.ImportRowResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Planilhas costumam salvar o CSV com BOM UTF-8 antes da primeira coluna
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, nil, errors.New("csv header must include a title column")
+	}
+
+	var rows []models.ImportTaskRow
+	var failures []models.ImportRowResult
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				failures = append(failures, rowFailure(line, err))
+				continue
+			}
+			return nil, nil, err
+		}
+
+		// raw preserva a descrição como foi escrita; as demais colunas
+		// ignoram espaços nas pontas
+		raw := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return unescapeCell(record[i])
+			}
+			return ""
+		}
+		get := func(name string) string {
+			return strings.TrimSpace(raw(name))
+		}
+
+		row := models.ImportTaskRow{
+			Row:         line,
+			ID:          get("id"),
+			Title:       get("title"),
+			Description: raw("description"),
+			Status:      models.Status(get("status")),
+			Priority:    models.Priority(get("priority")),
+		}
+		if row.Completed, err = optionalBool(get("completed")); err != nil {
+			failures = append(failures, rowFailure(line, fmt.Errorf("completed: %w", err)))
+			continue
+		}
+		if row.Archived, err = optionalBool(get("archived")); err != nil {
+			failures = append(failures, rowFailure(line, fmt.Errorf("archived: %w", err)))
+			continue
+		}
+		if due := get("dueDate"); due != "" {
+			parsed, err := time.Parse(time.RFC3339, due)
+			if err != nil {
+				failures = append(failures, rowFailure(line, fmt.Errorf("dueDate: %w", err)))
+				continue
+			}
+			row.DueDate = &parsed
+		}
+		colors := splitLabels(get("labelColors"))
+		for i, name := range splitLabels(get("labels")) {
+			label := models.CreateLabelRequest{Name: name}
+			if i < len(colors) {
+				label.Color = colors[i]
+			}
+			row.LabelsByName = append(row.LabelsByName, label)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, failures, nil
+}
+
+// joinLabels junta os valores com labelSeparator, escapando-o
+func joinLabels(values []string) string {
+	var b strings.Builder
+	for i, value := range values {
+		if i > 0 {
+			b.WriteRune(labelSeparator)
+		}
+		for _, r := range value {
+			if r == labelSeparator || r == '\\' {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// splitLabels desfaz joinLabels, ignorando espaços nas pontas de cada valor.
+// Valores vazios são mantidos para que nomes e cores continuem alinhados.
+func splitLabels(cell string) []string {
+	if cell == "" {
+		return nil
+	}
+	var values []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range cell {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == labelSeparator:
+			values = append(values, strings.TrimSpace(b.String()))
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(values, strings.TrimSpace(b.String()))
+}
+
+// optionalBool interpreta uma coluna booleana; vazia retorna nil
+func optionalBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,98 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// Formatos aceitos na exportação e importação do quadro
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Formatos de outros sistemas aceitos apenas na importação
+const (
+	FormatTrello = "trello"
+	FormatGitHub = "github"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// Palavras que identificam, no nome de uma lista ou etiqueta, a coluna correspondente
+var (
+	doneKeywords       = []string{"done", "complete", "finished", "closed", "concluíd", "feito", "pronto"}
+	inProgressKeywords = []string{"doing", "progress", "wip", "review", "andamento", "fazendo"}
+)
+
+// ContentType retorna o tipo MIME do formato
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json"
+}
+
+// Export escreve as tarefas no formato pedido, linha a linha, sem montar o
+// arquivo inteiro em memória. As etiquetas saem pelo nome e cor, e não pelo
+// ID, que só vale neste quadro.
+func Export(w io.Writer, format string, tasks []models.ExportTask) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, tasks)
+	case FormatJSON:
+		return writeJSON(w, tasks)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// Import lê as linhas de um arquivo exportado por este quadro, pelo Trello ou
+// pelo GitHub. Linhas malformadas não interrompem a leitura: são devolvidas em
+// failures com o número da linha. O erro só é retornado quando o arquivo
+// inteiro é ilegível.
+func Import(r io.Reader, format string) (rows []models.ImportTaskRow, failures []models.ImportRowResult, err error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSON:
+		return readJSON(r)
+	case FormatTrello:
+		return readTrello(r)
+	case FormatGitHub:
+		return readGitHub(r)
+	default:
+		return nil, nil, ErrUnsupportedFormat
+	}
+}
+
+// rowFailure monta o resultado de uma linha que não pôde ser lida
+func rowFailure(row int, err error) models.ImportRowResult {
+	return models.ImportRowResult{Row: row, Error: fmt.Sprintf("invalid row: %v", err)}
+}
+
+// statusFromName deduz o status pelo nome de uma lista ou etiqueta,
+// usando todo quando nenhuma palavra conhecida aparece
+func statusFromName(name string) models.Status {
+	name = strings.ToLower(name)
+	contains := func(keyword string) bool { return strings.Contains(name, keyword) }
+	switch {
+	case slices.ContainsFunc(doneKeywords, contains):
+		return models.StatusDone
+	case slices.ContainsFunc(inProgressKeywords, contains):
+		return models.StatusInProgress
+	default:
+		return models.StatusTodo
+	}
+}
+
+// trimColorVariant remove os sufixos _dark e _light das cores do Trello
+func trimColorVariant(color string) string {
+	color = strings.TrimSuffix(color, "_dark")
+	return strings.TrimSuffix(color, "_light")
+}
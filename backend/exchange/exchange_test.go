@@ -0,0 +1,229 @@
+package exchange
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+func sampleTasks() []models.ExportTask {
+	due := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+	return []models.ExportTask{
+		{
+			Task:   &models.Task{ID: "1", Title: "Fix login", Description: "Crash, with \"quotes\"\nand newline", Status: models.StatusInProgress, Priority: models.PriorityHigh, DueDate: &due, Labels: []string{"l1", "l2"}},
+			Labels: []models.CreateLabelRequest{{Name: "Bug", Color: "#d73a4a"}, {Name: "a;b\\c", Color: "#0e8a16"}},
+		},
+		{Task: &models.Task{ID: "2", Title: "Write docs", Status: models.StatusTodo, Priority: models.PriorityLow}},
+	}
+}
+
+// exportTasks envolve tarefas sem etiquetas para Export
+func exportTasks(tasks ...*models.Task) []models.ExportTask {
+	exported := make([]models.ExportTask, len(tasks))
+	for i, task := range tasks {
+		exported[i].Task = task
+	}
+	return exported
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		var buf bytes.Buffer
+		if err := Export(&buf, format, sampleTasks()); err != nil {
+			t.Fatalf("%s: "+msgExpectedNoError, format, err)
+		}
+
+		rows, failures, err := Import(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: "+msgExpectedNoError, format, err)
+		}
+		if len(failures) != 0 || len(rows) != 2 {
+			t.Fatalf("%s: expected 2 rows and no failures, got %v and %v", format, rows, failures)
+		}
+
+		first := rows[0]
+		if first.ID != "1" || first.Description != "Crash, with \"quotes\"\nand newline" || first.Status != models.StatusInProgress ||
+			first.Priority != models.PriorityHigh || first.DueDate == nil {
+			t.Errorf("%s: unexpected first row %+v", format, first)
+		}
+		if len(first.Labels) != 0 || !slices.Equal(first.LabelsByName, sampleTasks()[0].Labels) {
+			t.Errorf("%s: expected labels by name and color, got IDs %v and %+v", format, first.Labels, first.LabelsByName)
+		}
+		if len(rows[1].LabelsByName) != 0 {
+			t.Errorf("%s: expected no labels on the second row, got %+v", format, rows[1].LabelsByName)
+		}
+	}
+}
+
+func TestImportJSONAcceptsLabelIDs(t *testing.T) {
+	input := `[{"title":"Old export","labels":["1","2"]},{"title":"Bad","labels":[3]}]`
+
+	rows, failures, err := Import(strings.NewReader(input), FormatJSON)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(rows) != 1 || !slices.Equal(rows[0].Labels, []string{"1", "2"}) {
+		t.Errorf("expected label IDs from an older export, got %+v", rows)
+	}
+	if len(failures) != 1 || failures[0].Row != 2 {
+		t.Errorf("expected element 2 to fail, got %+v", failures)
+	}
+}
+
+func TestCSVRoundTripKeepsCellsAndFlags(t *testing.T) {
+	tasks := []*models.Task{
+		{ID: "1", Title: "=HYPERLINK(\"http://evil\")", Description: "  - indented list\n", Status: models.StatusDone, Completed: true, Archived: true},
+		{ID: "2", Title: "'@quoted", Description: "+1 vote", Status: models.StatusTodo},
+		{ID: "3", Title: "'tis plain", Description: "-", Status: models.StatusTodo},
+	}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, FormatCSV, exportTasks(tasks...)); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	for _, dangerous := range []string{",=HYPERLINK", ",@", ",+1", ",-\n"} {
+		if strings.Contains(buf.String(), dangerous) {
+			t.Errorf("expected cells starting with a formula character to be escaped, found %q in %s", dangerous, buf.String())
+		}
+	}
+
+	rows, failures, err := Import(&buf, FormatCSV)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(failures) != 0 || len(rows) != len(tasks) {
+		t.Fatalf("expected %d rows and no failures, got %v and %v", len(tasks), rows, failures)
+	}
+	for i, task := range tasks {
+		row := rows[i]
+		if row.Title != task.Title || row.Description != task.Description {
+			t.Errorf("row %d: expected title %q and description %q, got %q and %q", i, task.Title, task.Description, row.Title, row.Description)
+		}
+		if row.Completed == nil || *row.Completed != task.Completed || row.Archived == nil || *row.Archived != task.Archived {
+			t.Errorf("row %d: expected completed %v and archived %v, got %v and %v", i, task.Completed, task.Archived, row.Completed, row.Archived)
+		}
+	}
+}
+
+func TestImportCSVReportsBadRows(t *testing.T) {
+	input := "title,dueDate,archived\nGood,,\nBad date,tomorrow,\nAlso good,2025-01-01T00:00:00Z,false\nBad flag,,maybe\n"
+
+	rows, failures, err := Import(strings.NewReader(input), FormatCSV)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(rows) != 2 || rows[1].Row != 4 {
+		t.Errorf("expected rows from lines 2 and 4, got %+v", rows)
+	}
+	if len(failures) != 2 || failures[0].Row != 3 || failures[1].Row != 5 {
+		t.Errorf("expected failures on lines 3 and 5, got %+v", failures)
+	}
+}
+
+func TestImportJSONReportsBadElements(t *testing.T) {
+	input := `[{"title":"Good"},{"title":42},{"title":"Also good"}]`
+
+	rows, failures, err := Import(strings.NewReader(input), FormatJSON)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(rows) != 2 || len(failures) != 1 || failures[0].Row != 2 {
+		t.Errorf("expected element 2 to fail, got rows %+v and failures %+v", rows, failures)
+	}
+}
+
+func TestImportInvalidInput(t *testing.T) {
+	if _, _, err := Import(strings.NewReader(`{"title":"x"}`), FormatJSON); err == nil {
+		t.Error("expected error for non-array JSON")
+	}
+	if _, _, err := Import(strings.NewReader("name\nx\n"), FormatCSV); err == nil {
+		t.Error("expected error for CSV without title column")
+	}
+	if _, _, err := Import(strings.NewReader(""), "xml"); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestImportTrelloBoard(t *testing.T) {
+	input := `{
+		"lists": [
+			{"id": "l1", "name": "To Do"},
+			{"id": "l2", "name": "Doing"},
+			{"id": "l3", "name": "Done 🎉"},
+			{"id": "l4", "name": "Old", "closed": true}
+		],
+		"cards": [
+			{"id": "c1", "name": "Card A", "desc": "desc", "idList": "l2", "due": "2025-03-01T18:00:00.000Z",
+			 "labels": [{"name": "Bug", "color": "red"}, {"name": "", "color": "green_dark"}]},
+			{"id": "c2", "name": "Card B", "idList": "l3"},
+			{"id": "c3", "name": "Archived card", "idList": "l1", "closed": true},
+			{"id": "c4", "name": "Card in archived list", "idList": "l4"}
+		],
+		"checklists": [
+			{"idCard": "c1", "pos": 2, "checkItems": [{"name": "second list", "state": "incomplete", "pos": 1}]},
+			{"idCard": "c1", "pos": 1, "checkItems": [
+				{"name": "b", "state": "incomplete", "pos": 20},
+				{"name": "a", "state": "complete", "pos": 10}
+			]}
+		]
+	}`
+
+	rows, _, err := Import(strings.NewReader(input), FormatTrello)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected closed cards and lists to be skipped, got %+v", rows)
+	}
+
+	card := rows[0]
+	if card.ID != "trello-c1" || card.Status != models.StatusInProgress || card.DueDate == nil {
+		t.Errorf("unexpected card %+v", card)
+	}
+	if len(card.LabelsByName) != 2 || card.LabelsByName[0].Color != "#eb5a46" ||
+		card.LabelsByName[1].Name != "green_dark" || card.LabelsByName[1].Color != "#61bd4f" {
+		t.Errorf("unexpected labels %+v", card.LabelsByName)
+	}
+	if len(card.Checklist) != 3 || card.Checklist[0].Text != "a" || !card.Checklist[0].Checked || card.Checklist[2].Text != "second list" {
+		t.Errorf("unexpected checklist %+v", card.Checklist)
+	}
+	if rows[1].Status != models.StatusDone {
+		t.Errorf("expected card in Done list to be done, got %s", rows[1].Status)
+	}
+}
+
+func TestImportGitHubIssues(t *testing.T) {
+	input := `[
+		{"number": 1, "title": "Open", "body": "b", "state": "open",
+		 "labels": [{"name": "bug", "color": "d73a4a"}],
+		 "milestone": {"due_on": "2025-03-01T08:00:00Z"}},
+		{"number": 2, "title": "Started", "state": "OPEN", "labels": [{"name": "In Progress"}]},
+		{"number": 3, "title": "Closed", "state": "CLOSED", "milestone": {"dueOn": "2025-04-01T08:00:00Z"}},
+		{"number": 4, "title": "A pull request", "state": "open", "pull_request": {"url": "x"}}
+	]`
+
+	rows, _, err := Import(strings.NewReader(input), FormatGitHub)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected pull requests to be skipped, got %+v", rows)
+	}
+
+	if rows[0].ID != "github-1" || rows[0].Status != models.StatusTodo || rows[0].DueDate == nil ||
+		len(rows[0].LabelsByName) != 1 || rows[0].LabelsByName[0].Color != "#d73a4a" {
+		t.Errorf("unexpected open issue %+v", rows[0])
+	}
+	if rows[1].Status != models.StatusInProgress {
+		t.Errorf("expected in_progress label to set the status, got %s", rows[1].Status)
+	}
+	if rows[2].Status != models.StatusDone || rows[2].DueDate == nil {
+		t.Errorf("unexpected closed issue %+v", rows[2])
+	}
+}
@@ -0,0 +1,76 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// githubIssue aceita tanto o formato da API REST (/repos/{owner}/{repo}/issues)
+// quanto o de `gh issue list --json number,title,body,state,labels,milestone`
+type githubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	Labels []struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Milestone *struct {
+		DueOn    *time.Time `json:"due_on"`
+		DueOnCLI *time.Time `json:"dueOn"`
+	} `json:"milestone"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+// readGitHub converte um dump JSON de issues do GitHub. Issues fechadas viram
+// done e abertas com etiqueta de andamento (ex: "in progress") viram in_progress;
+// pull requests presentes no dump da API são ignorados.
+func readGitHub(r io.Reader) ([]models.ImportTaskRow, []models.ImportRowResult, error) {
+	var issues []githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, nil, fmt.Errorf("read github issues: %w", err)
+	}
+
+	var rows []models.ImportTaskRow
+	for i, issue := range issues {
+		if len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null" {
+			continue
+		}
+
+		row := models.ImportTaskRow{
+			Row:         i + 1,
+			ID:          "github-" + strconv.Itoa(issue.Number),
+			Title:       issue.Title,
+			Description: issue.Body,
+			Status:      models.StatusTodo,
+		}
+		if issue.Milestone != nil {
+			row.DueDate = issue.Milestone.DueOn
+			if row.DueDate == nil {
+				row.DueDate = issue.Milestone.DueOnCLI
+			}
+		}
+		for _, label := range issue.Labels {
+			if statusFromName(label.Name) == models.StatusInProgress {
+				row.Status = models.StatusInProgress
+			}
+			row.LabelsByName = append(row.LabelsByName, models.CreateLabelRequest{
+				Name:  label.Name,
+				Color: "#" + label.Color,
+			})
+		}
+		if strings.EqualFold(issue.State, "closed") {
+			row.Status = models.StatusDone
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil, nil
+}
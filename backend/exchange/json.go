@@ -0,0 +1,102 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// exportedTask é o elemento do array exportado; taskFields não herda o
+// MarshalJSON de models.Task, para que labels traga os nomes no lugar dos IDs
+type exportedTask struct {
+	*taskFields
+	Labels []models.CreateLabelRequest `json:"labels,omitempty"`
+}
+
+type taskFields models.Task
+
+// writeJSON escreve um array JSON codificando uma tarefa por vez
+func writeJSON(w io.Writer, tasks []models.ExportTask) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	for i, task := range tasks {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(exportedTask{(*taskFields)(task.Task), task.Labels}); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "]\n")
+	return err
+}
+
+// readJSON lê um array de tarefas elemento a elemento; elementos com tipos
+// inválidos viram falhas da linha correspondente (índice a partir de 1)
+func readJSON(r io.Reader) ([]models.ImportTaskRow, []models.ImportRowResult, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read json: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, nil, fmt.Errorf("read json: expected an array of tasks")
+	}
+
+	var rows []models.ImportTaskRow
+	var failures []models.ImportRowResult
+	for i := 1; dec.More(); i++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, fmt.Errorf("read json element %d: %w", i, err)
+		}
+
+		var row models.ImportTaskRow
+		element := struct {
+			*models.ImportTaskRow
+			Labels []json.RawMessage `json:"labels"`
+		}{ImportTaskRow: &row}
+		if err := json.Unmarshal(raw, &element); err != nil {
+			failures = append(failures, rowFailure(i, err))
+			continue
+		}
+		if err := readLabels(&row, element.Labels); err != nil {
+			failures = append(failures, rowFailure(i, fmt.Errorf("labels: %w", err)))
+			continue
+		}
+		row.Row = i
+		rows = append(rows, row)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, fmt.Errorf("read json: %w", err)
+	}
+	return rows, failures, nil
+}
+
+// readLabels aceita etiquetas pelo nome e cor, como na exportação, ou pelo ID
+// deste quadro, como nos arquivos exportados por versões anteriores
+func readLabels(row *models.ImportTaskRow, labels []json.RawMessage) error {
+	for _, raw := range labels {
+		var id string
+		if err := json.Unmarshal(raw, &id); err == nil {
+			row.Labels = append(row.Labels, id)
+			continue
+		}
+		var label models.CreateLabelRequest
+		if err := json.Unmarshal(raw, &label); err != nil {
+			return err
+		}
+		row.LabelsByName = append(row.LabelsByName, label)
+	}
+	return nil
+}
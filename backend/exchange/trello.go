@@ -0,0 +1,116 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// trelloColors traduz as cores nomeadas das etiquetas do Trello
+var trelloColors = map[string]string{
+	"green":  "#61bd4f",
+	"yellow": "#f2d600",
+	"orange": "#ff9f1a",
+	"red":    "#eb5a46",
+	"purple": "#c377e0",
+	"blue":   "#0079bf",
+	"sky":    "#00c2e0",
+	"lime":   "#51e898",
+	"pink":   "#ff78cb",
+	"black":  "#344563",
+}
+
+// trelloBoard contém apenas os campos usados da exportação JSON de um quadro do Trello
+type trelloBoard struct {
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID     string     `json:"id"`
+		Name   string     `json:"name"`
+		Desc   string     `json:"desc"`
+		IDList string     `json:"idList"`
+		Closed bool       `json:"closed"`
+		Due    *time.Time `json:"due"`
+		Labels []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Checklists []struct {
+		IDCard     string  `json:"idCard"`
+		Pos        float64 `json:"pos"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"`
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+}
+
+// readTrello converte os cartões de uma exportação do Trello. O status vem do
+// nome da lista do cartão; cartões e listas arquivados são ignorados.
+func readTrello(r io.Reader) ([]models.ImportTaskRow, []models.ImportRowResult, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, nil, fmt.Errorf("read trello board: %w", err)
+	}
+
+	statuses := make(map[string]models.Status, len(board.Lists))
+	for _, list := range board.Lists {
+		if !list.Closed {
+			statuses[list.ID] = statusFromName(list.Name)
+		}
+	}
+
+	sort.SliceStable(board.Checklists, func(i, j int) bool { return board.Checklists[i].Pos < board.Checklists[j].Pos })
+	checklists := make(map[string][]models.ChecklistItem)
+	for _, checklist := range board.Checklists {
+		items := checklist.CheckItems
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+		for _, item := range items {
+			checklists[checklist.IDCard] = append(checklists[checklist.IDCard], models.ChecklistItem{
+				Text:    item.Name,
+				Checked: item.State == "complete",
+			})
+		}
+	}
+
+	var rows []models.ImportTaskRow
+	for i, card := range board.Cards {
+		status, ok := statuses[card.IDList]
+		if card.Closed || !ok {
+			continue
+		}
+
+		row := models.ImportTaskRow{
+			Row:         i + 1,
+			ID:          "trello-" + card.ID,
+			Title:       card.Name,
+			Description: card.Desc,
+			Status:      status,
+			DueDate:     card.Due,
+			Checklist:   checklists[card.ID],
+		}
+		for _, label := range card.Labels {
+			// Etiquetas do Trello podem ter só a cor
+			name := label.Name
+			if name == "" {
+				name = label.Color
+			}
+			row.LabelsByName = append(row.LabelsByName, models.CreateLabelRequest{
+				Name:  name,
+				Color: trelloColors[trimColorVariant(label.Color)],
+			})
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil, nil
+}
@@ -0,0 +1,147 @@
+package graphql
+
+// OperationType é o tipo de uma operação: query, mutation ou subscription
+type OperationType string
+
+const (
+	OperationQuery        OperationType = "query"
+	OperationMutation     OperationType = "mutation"
+	OperationSubscription OperationType = "subscription"
+)
+
+// Document é um documento GraphQL já interpretado
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*FragmentDefinition
+}
+
+// Operation é uma operação do documento, com suas variáveis e seleção
+type Operation struct {
+	Type         OperationType
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// VariableDefinition declara uma variável da operação, ex: $id: ID!
+type VariableDefinition struct {
+	Name    string
+	Type    *TypeRef
+	Default *Value
+	Loc     Location
+}
+
+// TypeRef é uma referência a um tipo no documento, ex: [String!]!
+type TypeRef struct {
+	Name    string
+	Elem    *TypeRef
+	NonNull bool
+}
+
+// String formata a referência como no documento
+func (t *TypeRef) String() string {
+	s := t.Name
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// Selection é um campo, um fragmento nomeado ou um fragmento inline
+type Selection interface {
+	location() Location
+}
+
+// Field seleciona um campo, opcionalmente com apelido, argumentos e subseleção
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// ResponseKey é o nome do campo na resposta: o apelido, se houver
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread inclui um fragmento nomeado, ex: ...TaskFields
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+// InlineFragment é uma seleção condicionada ao tipo, ex: ... on Task { id }
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Loc           Location
+}
+
+// FragmentDefinition declara um fragmento nomeado reutilizável
+type FragmentDefinition struct {
+	Name          string
+	TypeCondition string
+	SelectionSet  []Selection
+	Loc           Location
+}
+
+func (f *Field) location() Location          { return f.Loc }
+func (f *FragmentSpread) location() Location { return f.Loc }
+func (f *InlineFragment) location() Location { return f.Loc }
+
+// Argument é um argumento de campo ou diretiva
+type Argument struct {
+	Name  string
+	Value *Value
+	Loc   Location
+}
+
+// Directive é uma diretiva como @include(if: $flag)
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+	Loc       Location
+}
+
+// ValueKind identifica o tipo de um valor literal
+type ValueKind int
+
+const (
+	ValueVariable ValueKind = iota
+	ValueInt
+	ValueFloat
+	ValueString
+	ValueBoolean
+	ValueNull
+	ValueEnum
+	ValueList
+	ValueObject
+)
+
+// Value é um valor literal ou uma variável; Raw guarda o texto (ou o nome da
+// variável), List os itens de listas e Fields os campos de objetos
+type Value struct {
+	Kind   ValueKind
+	Raw    string
+	List   []*Value
+	Fields []*ObjectField
+	Loc    Location
+}
+
+// ObjectField é um campo de um objeto literal, ex: {title: "x"}
+type ObjectField struct {
+	Name  string
+	Value *Value
+}
@@ -0,0 +1,41 @@
+package graphql
+
+// Códigos em extensions.code dos erros gerados pelo próprio executor
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeQueryTooDeep     = "QUERY_TOO_DEEP"
+	CodeQueryTooComplex  = "QUERY_TOO_COMPLEX"
+)
+
+// Error é um erro no formato da resposta GraphQL
+type Error struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Error retorna a mensagem
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// withCode define extensions.code se ainda não houver um
+func (e *Error) withCode(code string) *Error {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]any)
+	}
+	if _, ok := e.Extensions["code"]; !ok {
+		e.Extensions["code"] = code
+	}
+	return e
+}
+
+// CodedError é implementado por erros de resolvers que informam o próprio
+// extensions.code (ex: NOT_FOUND)
+type CodedError interface {
+	error
+	Code() string
+}
@@ -0,0 +1,442 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// errNull sinaliza que um campo não nulo ficou nulo e o null deve subir até o
+// pai anulável mais próximo; o erro em si já foi registrado
+var errNull = errors.New("null in non-null position")
+
+// Response é a resposta de uma operação. Data fica fora do JSON quando a
+// operação nem chegou a ser executada (erros de sintaxe ou validação).
+type Response struct {
+	Data     any
+	Errors   []*Error
+	executed bool
+}
+
+// MarshalJSON escreve {"data": ..., "errors": [...]}
+func (r *Response) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any)
+	if r.executed {
+		out["data"] = r.Data
+	}
+	if len(r.Errors) > 0 {
+		out["errors"] = r.Errors
+	}
+	return json.Marshal(out)
+}
+
+// ErrorResponse monta a resposta de erros que impediram a execução
+func ErrorResponse(errs ...*Error) *Response {
+	return &Response{Errors: errs}
+}
+
+// orderedMap mantém os campos na ordem da seleção, como pede a especificação
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]any)}
+}
+
+func (m *orderedMap) set(key string, value any) {
+	if _, exists := m.values[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON escreve o objeto com as chaves na ordem de inserção
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		b.Write(name)
+		b.WriteByte(':')
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// collectedField agrupa as seleções de um mesmo nome na resposta
+type collectedField struct {
+	key    string
+	fields []*Field
+}
+
+// collectFields achata fragmentos e aplica @include/@skip, agrupando os campos
+// pela chave da resposta
+func (p *Prepared) collectFields(parent *Object, selections []Selection) []*collectedField {
+	var collected []*collectedField
+	index := make(map[string]*collectedField)
+	visited := make(map[string]bool)
+
+	var collect func([]Selection)
+	collect = func(selections []Selection) {
+		for _, selection := range selections {
+			switch selection := selection.(type) {
+			case *Field:
+				if !p.included(selection.Directives) {
+					continue
+				}
+				key := selection.ResponseKey()
+				if group, ok := index[key]; ok {
+					group.fields = append(group.fields, selection)
+					continue
+				}
+				group := &collectedField{key: key, fields: []*Field{selection}}
+				index[key] = group
+				collected = append(collected, group)
+			case *FragmentSpread:
+				if !p.included(selection.Directives) || visited[selection.Name] {
+					continue
+				}
+				visited[selection.Name] = true
+				if fragment, ok := p.doc.Fragments[selection.Name]; ok && fragment.TypeCondition == parent.Name {
+					collect(fragment.SelectionSet)
+				}
+			case *InlineFragment:
+				if p.included(selection.Directives) && (selection.TypeCondition == "" || selection.TypeCondition == parent.Name) {
+					collect(selection.SelectionSet)
+				}
+			}
+		}
+	}
+	collect(selections)
+	return collected
+}
+
+// included avalia @include(if:) e @skip(if:)
+func (p *Prepared) included(directives []*Directive) bool {
+	for _, directive := range directives {
+		value, _, _ := coerceLiteral(directiveArgs[0].Type, directive.Arguments[0].Value, p.vars)
+		flag, _ := value.(bool)
+		if (directive.Name == "skip" && flag) || (directive.Name == "include" && !flag) {
+			return false
+		}
+	}
+	return true
+}
+
+// executor guarda os erros de campo de uma execução
+type executor struct {
+	*Prepared
+	errs []*Error
+}
+
+// Execute roda uma query ou mutation; os campos de uma mutation são
+// resolvidos em sequência, na ordem do documento
+func (p *Prepared) Execute(ctx context.Context) *Response {
+	return p.execute(ctx, p.schema.root(p.op.Type), nil)
+}
+
+// Subscribe abre o fluxo da subscription e executa a seleção a cada evento.
+// O canal é fechado quando o contexto é cancelado ou o fluxo termina.
+func (p *Prepared) Subscribe(ctx context.Context) (<-chan *Response, error) {
+	root := p.schema.Subscription
+	if p.op.Type != OperationSubscription || root == nil {
+		return nil, fmt.Errorf("graphql: operation is not a subscription")
+	}
+	field := p.collectFields(root, p.op.SelectionSet)[0].fields[0]
+	def := root.Field(field.Name)
+	if def == nil || def.Subscribe == nil {
+		return nil, fmt.Errorf("graphql: field %q cannot be subscribed", field.Name)
+	}
+
+	args, err := p.arguments(def.Args, field.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	events, err := def.Subscribe(ResolveParams{Context: ctx, Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(chan *Response)
+	go func() {
+		defer close(responses)
+		for event := range events {
+			select {
+			case responses <- p.execute(ctx, root, event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return responses, nil
+}
+
+// execute resolve a seleção da operação sobre o tipo raiz
+func (p *Prepared) execute(ctx context.Context, root *Object, source any) *Response {
+	e := &executor{Prepared: p}
+	data, err := e.selectionSet(ctx, root, source, p.op.SelectionSet, nil)
+	response := &Response{Errors: e.errs, executed: true}
+	if err == nil {
+		response.Data = data
+	}
+	return response
+}
+
+// selectionSet resolve os campos selecionados de um objeto
+func (e *executor) selectionSet(ctx context.Context, parent *Object, source any, selections []Selection, path []any) (*orderedMap, error) {
+	result := newOrderedMap()
+	for _, group := range e.collectFields(parent, selections) {
+		value, err := e.field(ctx, parent, source, group, append(path[:len(path):len(path)], group.key))
+		if err != nil {
+			return nil, err
+		}
+		result.set(group.key, value)
+	}
+	return result, nil
+}
+
+// field resolve e completa um campo
+func (e *executor) field(ctx context.Context, parent *Object, source any, group *collectedField, path []any) (any, error) {
+	field := group.fields[0]
+	if field.Name == "__typename" {
+		return parent.Name, nil
+	}
+	def := parent.Field(field.Name)
+
+	args, err := e.arguments(def.Args, field.Arguments)
+	if err != nil {
+		return e.fieldError(def.Type, field, path, err)
+	}
+
+	var value any
+	switch {
+	case def.Resolve != nil:
+		value, err = e.resolve(def.Resolve, ResolveParams{Context: ctx, Source: source, Args: args})
+	case def.Subscribe != nil:
+		// Na subscription o evento é o próprio valor do campo raiz
+		value = source
+	default:
+		value, err = defaultResolve(source, def.Name)
+	}
+	if err != nil {
+		return e.fieldError(def.Type, field, path, err)
+	}
+
+	return e.complete(ctx, def.Type, group, value, path)
+}
+
+// resolve chama o resolver convertendo panics em erro de campo
+func (e *executor) resolve(resolve ResolveFunc, params ResolveParams) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	return resolve(params)
+}
+
+// arguments converte os argumentos do campo já validados
+func (p *Prepared) arguments(defs []*ArgumentDef, args []*Argument) (map[string]any, error) {
+	values := make(map[string]any)
+	for _, def := range defs {
+		var arg *Argument
+		for _, candidate := range args {
+			if candidate.Name == def.Name {
+				arg = candidate
+			}
+		}
+		if arg != nil {
+			value, present, err := coerceLiteral(def.Type, arg.Value, p.vars)
+			if err != nil {
+				return nil, err
+			}
+			if present {
+				values[def.Name] = value
+				continue
+			}
+		}
+		if def.Default != nil {
+			values[def.Name] = def.Default
+		}
+	}
+	return values, nil
+}
+
+// fieldError registra o erro do campo e devolve null, propagando se o tipo for não nulo
+func (e *executor) fieldError(t Type, field *Field, path []any, err error) (any, error) {
+	gqlErr := &Error{Message: err.Error(), Locations: []Location{field.Loc}, Path: path}
+	var coded CodedError
+	if errors.As(err, &coded) {
+		gqlErr.withCode(coded.Code())
+	}
+	e.errs = append(e.errs, gqlErr)
+	if _, nonNull := t.(*NonNull); nonNull {
+		return nil, errNull
+	}
+	return nil, nil
+}
+
+// complete converte o valor do resolver conforme o tipo do campo. Erros
+// abaixo de uma posição anulável viram null nessa posição.
+func (e *executor) complete(ctx context.Context, t Type, group *collectedField, value any, path []any) (any, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		completed, err := e.completeValue(ctx, nonNull.Of, group, value, path)
+		if err != nil {
+			return nil, err
+		}
+		if completed == nil {
+			field := group.fields[0]
+			e.errs = append(e.errs, &Error{
+				Message:   fmt.Sprintf("Cannot return null for non-nullable field %s.", field.Name),
+				Locations: []Location{field.Loc},
+				Path:      path,
+			})
+			return nil, errNull
+		}
+		return completed, nil
+	}
+
+	completed, err := e.completeValue(ctx, t, group, value, path)
+	if err != nil {
+		return nil, nil
+	}
+	return completed, nil
+}
+
+// completeValue converte o valor sem tratar NonNull no nível atual
+func (e *executor) completeValue(ctx context.Context, t Type, group *collectedField, value any, path []any) (any, error) {
+	if _, isList := t.(*List); isList && reflect.ValueOf(value).Kind() == reflect.Slice {
+		// Slices nulos do Go (ex: campos omitempty) são listas vazias
+	} else if isNil(value) {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+			e.fieldError(t, group.fields[0], path, fmt.Errorf("expected a list for field %q", group.fields[0].Name))
+			return nil, errNull
+		}
+		list := make([]any, items.Len())
+		for i := range items.Len() {
+			item, err := e.complete(ctx, t.Of, group, items.Index(i).Interface(), append(path[:len(path):len(path)], i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = item
+		}
+		return list, nil
+	case *Scalar:
+		serialized, err := t.Serialize(value)
+		if err != nil {
+			e.fieldError(t, group.fields[0], path, err)
+			return nil, errNull
+		}
+		return serialized, nil
+	case *Enum:
+		text, err := serializeString(value)
+		if err == nil && !slices.Contains(t.Values, text.(string)) {
+			err = fmt.Errorf("Enum %q cannot represent value: %s", t.Name, describe(value))
+		}
+		if err != nil {
+			e.fieldError(t, group.fields[0], path, err)
+			return nil, errNull
+		}
+		return text, nil
+	case *Object:
+		var selections []Selection
+		for _, field := range group.fields {
+			selections = append(selections, field.SelectionSet...)
+		}
+		return e.selectionSet(ctx, t, value, selections, path)
+	default:
+		return nil, fmt.Errorf("graphql: cannot complete type %s", t)
+	}
+}
+
+// defaultResolve lê o campo de mesmo nome em maps e structs (pela tag json ou pelo nome)
+func defaultResolve(source any, name string) (any, error) {
+	v := reflect.ValueOf(source)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		value := v.MapIndex(reflect.ValueOf(name))
+		if !value.IsValid() {
+			return nil, nil
+		}
+		return value.Interface(), nil
+	case reflect.Struct:
+		if value, ok := structField(v, name); ok {
+			return value.Interface(), nil
+		}
+	}
+	return nil, fmt.Errorf("no value for field %q", name)
+}
+
+// structField busca o campo pela tag json, pelo nome ou nos campos embutidos
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == name || (tag == "" && strings.EqualFold(field.Name, name)) {
+			return v.Field(i), true
+		}
+	}
+	for i := range t.NumField() {
+		if field := t.Field(i); field.Anonymous {
+			embedded := v.Field(i)
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if value, ok := structField(embedded, name); ok {
+					return value, true
+				}
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// isNil trata ponteiros, slices e maps nulos como null
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
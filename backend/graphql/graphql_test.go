@@ -0,0 +1,419 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+// codedError simula um erro de resolver com extensions.code
+type codedError struct{ code string }
+
+func (e codedError) Error() string { return "not found" }
+func (e codedError) Code() string  { return e.code }
+
+type testItem struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Done     bool     `json:"done"`
+	Tags     []string `json:"tags,omitempty"`
+	Children []*testItem
+}
+
+// newTestSchema monta um schema pequeno de itens aninhados para os testes
+func newTestSchema(t *testing.T, events chan any) *Schema {
+	t.Helper()
+	state := &Enum{Name: "State", Values: []string{"open", "done"}}
+	item := &Object{Name: "Item"}
+	item.Fields = []*FieldDef{
+		{Name: "id", Type: NewNonNull(ID)},
+		{Name: "name", Type: NewNonNull(String)},
+		{Name: "done", Type: NewNonNull(Boolean)},
+		{Name: "tags", Type: NewNonNull(NewList(NewNonNull(String)))},
+		{Name: "state", Type: NewNonNull(state), Resolve: func(p ResolveParams) (any, error) {
+			if p.Source.(*testItem).Done {
+				return "done", nil
+			}
+			return "open", nil
+		}},
+		{Name: "children", Type: NewNonNull(NewList(NewNonNull(item)))},
+		{Name: "broken", Type: NewNonNull(String), Resolve: func(p ResolveParams) (any, error) {
+			return nil, errors.New("boom")
+		}},
+		{Name: "expensive", Type: Int, Cost: 50, Resolve: func(p ResolveParams) (any, error) { return 1, nil }},
+	}
+	input := &InputObject{
+		Name: "ItemInput",
+		Fields: []*ArgumentDef{
+			{Name: "name", Type: NewNonNull(String)},
+			{Name: "tags", Type: NewList(NewNonNull(String))},
+			{Name: "state", Type: state, Default: "open"},
+		},
+	}
+
+	items := []*testItem{
+		{ID: "1", Name: "first", Children: []*testItem{{ID: "3", Name: "child"}}},
+		{ID: "2", Name: "second", Done: true, Tags: []string{"a"}},
+	}
+	query := &Object{
+		Name: "Query",
+		Fields: []*FieldDef{
+			{Name: "items", Type: NewNonNull(NewList(NewNonNull(item))),
+				Args: []*ArgumentDef{{Name: "first", Type: Int, Default: 10}},
+				Resolve: func(p ResolveParams) (any, error) {
+					return items[:min(p.Args["first"].(int), len(items))], nil
+				}},
+			{Name: "item", Type: item,
+				Args: []*ArgumentDef{{Name: "id", Type: NewNonNull(ID)}},
+				Resolve: func(p ResolveParams) (any, error) {
+					for _, it := range items {
+						if it.ID == p.Args["id"] {
+							return it, nil
+						}
+					}
+					return nil, codedError{code: "NOT_FOUND"}
+				}},
+			{Name: "echo", Type: String,
+				Args: []*ArgumentDef{{Name: "text", Type: String}},
+				Resolve: func(p ResolveParams) (any, error) {
+					if text, ok := p.Args["text"]; ok {
+						return text, nil
+					}
+					return "absent", nil
+				}},
+			{Name: "panic", Type: String, Resolve: func(p ResolveParams) (any, error) { panic("oops") }},
+		},
+	}
+	mutation := &Object{
+		Name: "Mutation",
+		Fields: []*FieldDef{
+			{Name: "addItem", Type: NewNonNull(item),
+				Args: []*ArgumentDef{{Name: "input", Type: NewNonNull(input)}},
+				Resolve: func(p ResolveParams) (any, error) {
+					in := p.Args["input"].(map[string]any)
+					created := &testItem{ID: "9", Name: in["name"].(string), Done: in["state"] == "done"}
+					for _, tag := range in["tags"].([]any) {
+						created.Tags = append(created.Tags, tag.(string))
+					}
+					return created, nil
+				}},
+		},
+	}
+	subscription := &Object{
+		Name: "Subscription",
+		Fields: []*FieldDef{
+			{Name: "itemChanged", Type: NewNonNull(item),
+				Subscribe: func(p ResolveParams) (<-chan any, error) { return events, nil }},
+		},
+	}
+
+	schema, err := NewSchema(query, mutation, subscription)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return schema
+}
+
+// run prepara e executa a operação, devolvendo a resposta em JSON
+func run(t *testing.T, schema *Schema, req Request) string {
+	t.Helper()
+	prepared, errs := schema.Prepare(req, Limits{})
+	if errs != nil {
+		t.Fatalf("expected no validation errors, got %v", errs)
+	}
+	body, err := json.Marshal(prepared.Execute(context.Background()))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return string(body)
+}
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# comentário
+		query List($first: Int = 2, $skip: Boolean!) {
+			all: items(first: $first) { ...fields @skip(if: $skip) }
+		}
+		fragment fields on Item { id ... on Item { name } }
+		mutation { addItem(input: {name: """
+			bloco
+		""", tags: ["a", "b"]}) { id } }
+	`)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(doc.Operations) != 2 || len(doc.Fragments) != 1 {
+		t.Fatalf("expected 2 operations and 1 fragment, got %d and %d", len(doc.Operations), len(doc.Fragments))
+	}
+
+	op := doc.Operations[0]
+	if op.Name != "List" || len(op.Variables) != 2 || op.Variables[0].Default == nil {
+		t.Errorf("expected operation List with 2 variables and a default, got %+v", op)
+	}
+	field := op.SelectionSet[0].(*Field)
+	if field.ResponseKey() != "all" || field.Name != "items" {
+		t.Errorf("expected alias all for items, got %s: %s", field.ResponseKey(), field.Name)
+	}
+
+	arg := doc.Operations[1].SelectionSet[0].(*Field).Arguments[0]
+	if name := arg.Value.Fields[0].Value.Raw; name != "bloco" {
+		t.Errorf("expected block string to be dedented, got %q", name)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+		line  int
+	}{
+		{"{ items { id }", "unexpected <EOF>", 1},
+		{"{\n  items(first: ) { id } }", `unexpected ")"`, 2},
+		{`{ echo(text: "open) }`, "unterminated string", 1},
+		{"{ items ? }", "unexpected character", 1},
+		{"# só comentário", "no operations", 0},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query)
+		var gqlErr *Error
+		if !errors.As(err, &gqlErr) {
+			t.Fatalf("expected *Error for %q, got %v", tt.query, err)
+		}
+		if !strings.Contains(gqlErr.Message, tt.want) {
+			t.Errorf("expected error containing %q for %q, got %q", tt.want, tt.query, gqlErr.Message)
+		}
+		if tt.line > 0 && (len(gqlErr.Locations) == 0 || gqlErr.Locations[0].Line != tt.line) {
+			t.Errorf("expected error at line %d for %q, got %v", tt.line, tt.query, gqlErr.Locations)
+		}
+	}
+}
+
+func TestPrepareRejectsInvalidOperations(t *testing.T) {
+	schema := newTestSchema(t, nil)
+	tests := []struct {
+		name string
+		req  Request
+		code string
+		want string
+	}{
+		{"syntax", Request{Query: "{"}, CodeParseFailed, "Syntax Error"},
+		{"unknown field", Request{Query: "{ items { nope } }"}, CodeValidationFailed, `Cannot query field "nope"`},
+		{"missing selection", Request{Query: "{ items }"}, CodeValidationFailed, "must have a selection"},
+		{"selection on leaf", Request{Query: "{ echo { id } }"}, CodeValidationFailed, "must not have a selection"},
+		{"unknown argument", Request{Query: "{ items(last: 1) { id } }"}, CodeValidationFailed, `Unknown argument "last"`},
+		{"missing argument", Request{Query: "{ item { id } }"}, CodeValidationFailed, `Argument "id" of type "ID!" is required`},
+		{"wrong argument type", Request{Query: `{ items(first: "2") { id } }`}, CodeValidationFailed, `Argument "first" has invalid value`},
+		{"string as enum", Request{Query: `mutation { addItem(input: {name: "x", state: "done"}) { id } }`}, CodeValidationFailed, "does not exist"},
+		{"unknown fragment", Request{Query: "{ items { ...nope } }"}, CodeValidationFailed, `Unknown fragment "nope"`},
+		{"fragment cycle", Request{Query: "{ items { ...a } } fragment a on Item { children { ...a } }"}, CodeValidationFailed, "within itself"},
+		{"undefined variable", Request{Query: "{ echo(text: $text) }"}, CodeValidationFailed, `"$text" is not defined`},
+		{"incompatible variable", Request{Query: "query($n: String) { items(first: $n) { id } }"}, CodeValidationFailed, "used in position expecting"},
+		{"missing variable", Request{Query: "query($id: ID!) { item(id: $id) { id } }"}, CodeBadUserInput, "was not provided"},
+		{"invalid variable", Request{Query: "query($n: Int) { items(first: $n) { id } }", Variables: map[string]any{"n": "x"}}, CodeBadUserInput, "got invalid value"},
+		{"ambiguous operation", Request{Query: "query A { echo } query B { echo }"}, CodeValidationFailed, "Must provide operation name"},
+		{"two subscription fields", Request{Query: "subscription { itemChanged { id } again: itemChanged { id } }"}, CodeValidationFailed, "only one top level field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := schema.Prepare(tt.req, Limits{})
+			if len(errs) == 0 {
+				t.Fatal("expected validation errors, got none")
+			}
+			if code := errs[0].Extensions["code"]; code != tt.code {
+				t.Errorf("expected code %s, got %v", tt.code, code)
+			}
+			if !strings.Contains(errs[0].Message, tt.want) {
+				t.Errorf("expected error containing %q, got %q", tt.want, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestPrepareEnforcesLimits(t *testing.T) {
+	schema := newTestSchema(t, nil)
+	query := "{ items { children { children { id } } } }"
+
+	prepared, errs := schema.Prepare(Request{Query: query}, Limits{})
+	if errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	// items (1) + 10 * (children (1) + 10 * (children (1) + 10 * id (1)))
+	if prepared.Depth != 4 || prepared.Complexity != 1111 {
+		t.Errorf("expected depth 4 and complexity 1111, got %d and %d", prepared.Depth, prepared.Complexity)
+	}
+
+	_, errs = schema.Prepare(Request{Query: query}, Limits{MaxDepth: 3})
+	if len(errs) != 1 || errs[0].Extensions["code"] != CodeQueryTooDeep {
+		t.Errorf("expected %s, got %v", CodeQueryTooDeep, errs)
+	}
+	_, errs = schema.Prepare(Request{Query: query}, Limits{MaxComplexity: 1000})
+	if len(errs) != 1 || errs[0].Extensions["code"] != CodeQueryTooComplex {
+		t.Errorf("expected %s, got %v", CodeQueryTooComplex, errs)
+	}
+
+	// Fragmentos contam na profundidade e Cost substitui o custo padrão
+	prepared, errs = schema.Prepare(Request{Query: "{ item(id: 1) { ...deep } } fragment deep on Item { children { expensive } }"}, Limits{MaxDepth: 3, ListCost: 2})
+	if errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	if prepared.Depth != 3 || prepared.Complexity != 102 {
+		t.Errorf("expected depth 3 and complexity 102, got %d and %d", prepared.Depth, prepared.Complexity)
+	}
+}
+
+func TestPrepareCountsEachFragmentOnce(t *testing.T) {
+	schema := newTestSchema(t, nil)
+
+	// Cada fragmento espalha o seguinte duas vezes: sem memorizar o custo de
+	// cada fragmento, a validação percorre 2^40 seleções
+	const fragments = 40
+	var query strings.Builder
+	query.WriteString("{ item(id: 1) { ...f0 } }")
+	for i := range fragments {
+		fmt.Fprintf(&query, " fragment f%d on Item { id ...f%d ...f%d }", i, i+1, i+1)
+	}
+	fmt.Fprintf(&query, " fragment f%d on Item { id }", fragments)
+
+	_, errs := schema.Prepare(Request{Query: query.String()}, Limits{MaxComplexity: 1000})
+	if len(errs) != 1 || errs[0].Extensions["code"] != CodeQueryTooComplex {
+		t.Fatalf("expected %s, got %v", CodeQueryTooComplex, errs)
+	}
+
+	prepared, errs := schema.Prepare(Request{Query: query.String()}, Limits{})
+	if errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	if prepared.Complexity != maxCost {
+		t.Errorf("expected the complexity to saturate at %d, got %d", maxCost, prepared.Complexity)
+	}
+}
+
+func TestExecuteQuery(t *testing.T) {
+	schema := newTestSchema(t, nil)
+	got := run(t, schema, Request{
+		Query: `query List($first: Int, $withTags: Boolean!) {
+			items(first: $first) {
+				__typename
+				key: id
+				...names
+				state
+				tags @include(if: $withTags)
+				children { id }
+			}
+			echo
+			quoted: echo(text: "hi")
+		}
+		fragment names on Item { name ... on Item { done } }`,
+		Variables: map[string]any{"first": 1, "withTags": false},
+	})
+
+	want := `{"data":{"items":[{"__typename":"Item","key":"1","name":"first","done":false,"state":"open","children":[{"id":"3"}]}],"echo":"absent","quoted":"hi"}}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestExecuteMutationWithInputObject(t *testing.T) {
+	schema := newTestSchema(t, nil)
+	got := run(t, schema, Request{
+		Query:     `mutation($input: ItemInput!) { addItem(input: $input) { name done tags } }`,
+		Variables: map[string]any{"input": map[string]any{"name": "new", "tags": "solo", "state": "done"}},
+	})
+
+	want := `{"data":{"addItem":{"name":"new","done":true,"tags":["solo"]}}}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestExecutePropagatesNulls(t *testing.T) {
+	schema := newTestSchema(t, nil)
+
+	// broken é String! e anula o item, que é anulável
+	got := run(t, schema, Request{Query: `{ item(id: 2) { id broken } echo }`})
+	want := `{"data":{"item":null,"echo":"absent"},"errors":[{"message":"boom","locations":[{"line":1,"column":20}],"path":["item","broken"]}]}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	// Dentro de [Item!]! o null sobe até a raiz
+	got = run(t, schema, Request{Query: `{ items { broken } }`})
+	if !strings.HasPrefix(got, `{"data":null,"errors":[{"message":"boom"`) {
+		t.Errorf("expected data to be null, got %s", got)
+	}
+}
+
+func TestExecuteReportsResolverErrors(t *testing.T) {
+	schema := newTestSchema(t, nil)
+
+	got := run(t, schema, Request{Query: `{ item(id: 7) { id } }`})
+	want := `{"data":{"item":null},"errors":[{"message":"not found","locations":[{"line":1,"column":3}],"path":["item"],"extensions":{"code":"NOT_FOUND"}}]}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	got = run(t, schema, Request{Query: `{ panic }`})
+	if !strings.Contains(got, `"data":{"panic":null}`) || !strings.Contains(got, "internal error: oops") {
+		t.Errorf("expected panic to become a field error, got %s", got)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	events := make(chan any, 2)
+	schema := newTestSchema(t, events)
+	prepared, errs := schema.Prepare(Request{Query: `subscription { itemChanged { id state } }`}, Limits{})
+	if errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	responses, err := prepared.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	events <- &testItem{ID: "5", Done: true}
+	select {
+	case response := <-responses:
+		body, _ := json.Marshal(response)
+		if want := `{"data":{"itemChanged":{"id":"5","state":"done"}}}`; string(body) != want {
+			t.Errorf("expected %s, got %s", want, body)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a response for the event")
+	}
+
+	close(events)
+	select {
+	case _, open := <-responses:
+		if open {
+			t.Error("expected responses to be closed when the stream ends")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected responses to be closed")
+	}
+}
+
+func TestSchemaSDL(t *testing.T) {
+	sdl := newTestSchema(t, nil).SDL()
+	for _, want := range []string{
+		"type Query {",
+		"items(first: Int = 10): [Item!]!",
+		"type Subscription {",
+		"enum State {",
+		"input ItemInput {",
+		`state: State = open`,
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("expected SDL to contain %q, got:\n%s", want, sdl)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+package graphql
+
+import "fmt"
+
+// parser monta o Document a partir dos tokens, com um token de antecipação
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+// Parse interpreta um documento executável (operações e fragmentos)
+func Parse(src string) (*Document, error) {
+	p := &parser{lexer: newLexer(src)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: make(map[string]*FragmentDefinition)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunct, "{"):
+			loc := p.tok.loc
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: OperationQuery, SelectionSet: selections, Loc: loc})
+		case p.peek(tokenName, "query"), p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.peek(tokenName, "fragment"):
+			fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.Fragments[fragment.Name]; exists {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", fragment.Name), Locations: []Location{fragment.Loc}}
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, &Error{Message: "Syntax Error: the document has no operations"}
+	}
+	return doc, nil
+}
+
+// advance lê o próximo token
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// peek indica se o token atual é do tipo e valor dados
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+// skip consome o token se for a pontuação dada
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.peek(tokenPunct, punct) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+// expect consome a pontuação dada ou falha
+func (p *parser) expect(punct string) error {
+	if !p.peek(tokenPunct, punct) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+// name consome um nome
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+// unexpected monta o erro de sintaxe do token atual
+func (p *parser) unexpected() error {
+	found := fmt.Sprintf("%q", p.tok.value)
+	if p.tok.kind == tokenEOF {
+		found = "<EOF>"
+	}
+	return &Error{Message: "Syntax Error: unexpected " + found, Locations: []Location{p.tok.loc}}
+}
+
+// operation lê query/mutation/subscription com nome, variáveis e seleção
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{Type: OperationType(p.tok.value), Loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.Name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(tokenPunct, ")") {
+			def, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = selections
+	return op, nil
+}
+
+// variableDefinition lê $nome: Tipo = padrão
+func (p *parser) variableDefinition() (*VariableDefinition, error) {
+	def := &VariableDefinition{Loc: p.tok.loc}
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	def.Name = name
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	if def.Type, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip("="); err != nil {
+		return nil, err
+	} else if ok {
+		if def.Default, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// typeRef lê Nome, [Tipo] e o marcador ! de não nulo
+func (p *parser) typeRef() (*TypeRef, error) {
+	ref := &TypeRef{}
+	if ok, err := p.skip("["); err != nil {
+		return nil, err
+	} else if ok {
+		if ref.Elem, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		ref.Name = name
+	}
+	nonNull, err := p.skip("!")
+	if err != nil {
+		return nil, err
+	}
+	ref.NonNull = nonNull
+	return ref, nil
+}
+
+// fragment lê fragment Nome on Tipo { ... }
+func (p *parser) fragment() (*FragmentDefinition, error) {
+	fragment := &FragmentDefinition{Loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, &Error{Message: `Syntax Error: unexpected "on"`, Locations: []Location{fragment.Loc}}
+	}
+	fragment.Name = name
+	if !p.peek(tokenName, "on") {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if fragment.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if _, err := p.directives(); err != nil {
+		return nil, err
+	}
+	if fragment.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return fragment, nil
+}
+
+// selectionSet lê { seleção... }
+func (p *parser) selectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []Selection
+	for !p.peek(tokenPunct, "}") {
+		selection, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, p.unexpected()
+	}
+	return selections, p.advance()
+}
+
+// selection lê um campo ou um fragmento
+func (p *parser) selection() (Selection, error) {
+	loc := p.tok.loc
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.fragmentSelection(loc)
+	}
+
+	field := &Field{Loc: loc}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	field.Name = name
+
+	if field.Arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunct, "{") {
+		if field.SelectionSet, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+// fragmentSelection lê o que vem depois de "...": um nome ou um fragmento inline
+func (p *parser) fragmentSelection(loc Location) (Selection, error) {
+	if p.tok.kind == tokenName && p.tok.value != "on" {
+		spread := &FragmentSpread{Name: p.tok.value, Loc: loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		spread.Directives, err = p.directives()
+		return spread, err
+	}
+
+	inline := &InlineFragment{Loc: loc}
+	if p.peek(tokenName, "on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		inline.TypeCondition = name
+	}
+	var err error
+	if inline.Directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if inline.SelectionSet, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+// arguments lê (nome: valor, ...) se presente
+func (p *parser) arguments(constant bool) ([]*Argument, error) {
+	ok, err := p.skip("(")
+	if err != nil || !ok {
+		return nil, err
+	}
+	var args []*Argument
+	for !p.peek(tokenPunct, ")") {
+		arg := &Argument{Loc: p.tok.loc}
+		if arg.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.Value, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) == 0 {
+		return nil, p.unexpected()
+	}
+	return args, p.advance()
+}
+
+// directives lê @nome(argumentos) em sequência
+func (p *parser) directives() ([]*Directive, error) {
+	var directives []*Directive
+	for p.peek(tokenPunct, "@") {
+		directive := &Directive{Loc: p.tok.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		if directive.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if directive.Arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive)
+	}
+	return directives, nil
+}
+
+// value lê um valor; constant proíbe variáveis (valores padrão)
+func (p *parser) value(constant bool) (*Value, error) {
+	value := &Value{Raw: p.tok.value, Loc: p.tok.loc}
+	switch p.tok.kind {
+	case tokenInt:
+		value.Kind = ValueInt
+	case tokenFloat:
+		value.Kind = ValueFloat
+	case tokenString:
+		value.Kind = ValueString
+	case tokenName:
+		switch p.tok.value {
+		case "true", "false":
+			value.Kind = ValueBoolean
+		case "null":
+			value.Kind = ValueNull
+		default:
+			value.Kind = ValueEnum
+		}
+	case tokenPunct:
+		switch p.tok.value {
+		case "$":
+			if constant {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			value.Kind, value.Raw = ValueVariable, name
+			return value, nil
+		case "[":
+			return p.listValue(value, constant)
+		case "{":
+			return p.objectValue(value, constant)
+		default:
+			return nil, p.unexpected()
+		}
+	default:
+		return nil, p.unexpected()
+	}
+	return value, p.advance()
+}
+
+// listValue lê [valor, ...]
+func (p *parser) listValue(value *Value, constant bool) (*Value, error) {
+	value.Kind = ValueList
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	for !p.peek(tokenPunct, "]") {
+		item, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		value.List = append(value.List, item)
+	}
+	return value, p.advance()
+}
+
+// objectValue lê {campo: valor, ...}
+func (p *parser) objectValue(value *Value, constant bool) (*Value, error) {
+	value.Kind = ValueObject
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	for !p.peek(tokenPunct, "}") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		item, err := p.value(constant)
+		if err != nil {
+			return nil, err
+		}
+		value.Fields = append(value.Fields, &ObjectField{Name: name, Value: item})
+	}
+	return value, p.advance()
+}
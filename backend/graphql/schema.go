@@ -0,0 +1,476 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type é um tipo do schema: Scalar, Enum, Object, InputObject, List ou NonNull
+type Type interface {
+	String() string
+}
+
+// ResolveParams é o que um resolver recebe: o objeto pai e os argumentos já convertidos
+type ResolveParams struct {
+	Context context.Context
+	Source  any
+	Args    map[string]any
+}
+
+// ResolveFunc calcula o valor de um campo
+type ResolveFunc func(p ResolveParams) (any, error)
+
+// SubscribeFunc abre o fluxo de eventos de um campo de subscription; o canal
+// deve ser fechado quando o contexto for cancelado
+type SubscribeFunc func(p ResolveParams) (<-chan any, error)
+
+// Scalar é um tipo folha. Serialize converte o valor do resolver para JSON e
+// ParseValue converte a entrada (variável ou literal) para o valor Go.
+type Scalar struct {
+	Name        string
+	Description string
+	Serialize   func(value any) (any, error)
+	ParseValue  func(value any) (any, error)
+}
+
+// Enum é um tipo folha com valores fixos, recebidos e devolvidos como string
+type Enum struct {
+	Name        string
+	Description string
+	Values      []string
+}
+
+// Object é um tipo de saída com campos
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*FieldDef
+}
+
+// InputObject é um tipo de entrada com campos, recebido como map[string]any
+type InputObject struct {
+	Name        string
+	Description string
+	Fields      []*ArgumentDef
+}
+
+// List é uma lista de outro tipo
+type List struct {
+	Of Type
+}
+
+// NonNull marca o tipo como obrigatório
+type NonNull struct {
+	Of Type
+}
+
+// FieldDef é um campo de um Object. Sem Resolve o valor é lido do campo de mesmo
+// nome (ou tag json) do objeto pai. Cost soma ao custo da consulta (padrão 1).
+type FieldDef struct {
+	Name              string
+	Description       string
+	Type              Type
+	Args              []*ArgumentDef
+	Resolve           ResolveFunc
+	Subscribe         SubscribeFunc
+	Cost              int
+	DeprecationReason string
+}
+
+// ArgumentDef é um argumento de campo ou um campo de InputObject
+type ArgumentDef struct {
+	Name        string
+	Description string
+	Type        Type
+	Default     any
+}
+
+func (t *Scalar) String() string      { return t.Name }
+func (t *Enum) String() string        { return t.Name }
+func (t *Object) String() string      { return t.Name }
+func (t *InputObject) String() string { return t.Name }
+func (t *List) String() string        { return "[" + t.Of.String() + "]" }
+func (t *NonNull) String() string     { return t.Of.String() + "!" }
+
+// NewList cria o tipo [of]
+func NewList(of Type) *List {
+	return &List{Of: of}
+}
+
+// NewNonNull cria o tipo of!
+func NewNonNull(of Type) *NonNull {
+	return &NonNull{Of: of}
+}
+
+// Field busca um campo pelo nome
+func (t *Object) Field(name string) *FieldDef {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// Field busca um campo de entrada pelo nome
+func (t *InputObject) Field(name string) *ArgumentDef {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// Schema reúne os tipos raiz de consulta, mutação e subscription
+type Schema struct {
+	Query        *Object
+	Mutation     *Object
+	Subscription *Object
+
+	types map[string]Type
+}
+
+// NewSchema valida e indexa os tipos alcançáveis a partir das raízes
+func NewSchema(query, mutation, subscription *Object) (*Schema, error) {
+	if query == nil {
+		return nil, fmt.Errorf("graphql: the query type is required")
+	}
+	s := &Schema{Query: query, Mutation: mutation, Subscription: subscription, types: make(map[string]Type)}
+	for _, builtin := range []Type{String, Int, Float, Boolean, ID} {
+		s.types[builtin.String()] = builtin
+	}
+	for _, root := range []*Object{query, mutation, subscription} {
+		if root != nil {
+			if err := s.addType(root); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s, nil
+}
+
+// addType registra o tipo nomeado e os tipos usados por ele
+func (s *Schema) addType(t Type) error {
+	named := namedType(t)
+	name := named.String()
+	if existing, ok := s.types[name]; ok {
+		if existing != named {
+			return fmt.Errorf("graphql: two different types named %q", name)
+		}
+		return nil
+	}
+	s.types[name] = named
+
+	switch named := named.(type) {
+	case *Object:
+		for _, field := range named.Fields {
+			if err := s.addType(field.Type); err != nil {
+				return err
+			}
+			for _, arg := range field.Args {
+				if !isInputType(arg.Type) {
+					return fmt.Errorf("graphql: argument %s.%s(%s) must be an input type", name, field.Name, arg.Name)
+				}
+				if err := s.addType(arg.Type); err != nil {
+					return err
+				}
+			}
+		}
+	case *InputObject:
+		for _, field := range named.Fields {
+			if !isInputType(field.Type) {
+				return fmt.Errorf("graphql: input field %s.%s must be an input type", name, field.Name)
+			}
+			if err := s.addType(field.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Type busca um tipo nomeado
+func (s *Schema) Type(name string) Type {
+	return s.types[name]
+}
+
+// typeFromRef resolve a referência de tipo de uma variável
+func (s *Schema) typeFromRef(ref *TypeRef) Type {
+	var t Type
+	if ref.Elem != nil {
+		elem := s.typeFromRef(ref.Elem)
+		if elem == nil {
+			return nil
+		}
+		t = NewList(elem)
+	} else if t = s.types[ref.Name]; t == nil {
+		return nil
+	}
+	if ref.NonNull {
+		t = NewNonNull(t)
+	}
+	return t
+}
+
+// namedType remove List e NonNull do tipo
+func namedType(t Type) Type {
+	for {
+		switch wrapper := t.(type) {
+		case *List:
+			t = wrapper.Of
+		case *NonNull:
+			t = wrapper.Of
+		default:
+			return t
+		}
+	}
+}
+
+// isInputType indica tipos aceitos em argumentos e variáveis
+func isInputType(t Type) bool {
+	switch namedType(t).(type) {
+	case *Scalar, *Enum, *InputObject:
+		return true
+	default:
+		return false
+	}
+}
+
+// SDL imprime o schema na linguagem de definição do GraphQL, útil para
+// ferramentas de geração de código
+func (s *Schema) SDL() string {
+	var b strings.Builder
+	printed := make(map[string]bool)
+	printType := func(t Type) {
+		if printed[t.String()] {
+			return
+		}
+		printed[t.String()] = true
+		switch t := t.(type) {
+		case *Scalar:
+			if !slices.Contains([]string{"String", "Int", "Float", "Boolean", "ID"}, t.Name) {
+				writeDescription(&b, "", t.Description)
+				fmt.Fprintf(&b, "scalar %s\n\n", t.Name)
+			}
+		case *Enum:
+			writeDescription(&b, "", t.Description)
+			fmt.Fprintf(&b, "enum %s {\n", t.Name)
+			for _, value := range t.Values {
+				fmt.Fprintf(&b, "  %s\n", value)
+			}
+			b.WriteString("}\n\n")
+		case *Object:
+			writeDescription(&b, "", t.Description)
+			fmt.Fprintf(&b, "type %s {\n", t.Name)
+			for _, field := range t.Fields {
+				writeDescription(&b, "  ", field.Description)
+				fmt.Fprintf(&b, "  %s%s: %s", field.Name, formatArgs(field.Args), field.Type)
+				if field.DeprecationReason != "" {
+					fmt.Fprintf(&b, " @deprecated(reason: %s)", strconv.Quote(field.DeprecationReason))
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("}\n\n")
+		case *InputObject:
+			writeDescription(&b, "", t.Description)
+			fmt.Fprintf(&b, "input %s {\n", t.Name)
+			for _, field := range t.Fields {
+				writeDescription(&b, "  ", field.Description)
+				fmt.Fprintf(&b, "  %s\n", formatArg(field))
+			}
+			b.WriteString("}\n\n")
+		}
+	}
+
+	for _, root := range []*Object{s.Query, s.Mutation, s.Subscription} {
+		if root != nil {
+			printType(root)
+		}
+	}
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		printType(s.types[name])
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// writeDescription imprime a descrição como string GraphQL antes da definição
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description == "" {
+		return
+	}
+	if strings.Contains(description, "\n") {
+		fmt.Fprintf(b, "%s\"\"\"\n%s%s\n%s\"\"\"\n", indent, indent,
+			strings.ReplaceAll(description, "\n", "\n"+indent), indent)
+		return
+	}
+	fmt.Fprintf(b, "%s%s\n", indent, strconv.Quote(description))
+}
+
+// formatArgs imprime (a: T, b: T = padrão)
+func formatArgs(args []*ArgumentDef) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = formatArg(arg)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// formatArg imprime nome: Tipo = padrão
+func formatArg(arg *ArgumentDef) string {
+	s := arg.Name + ": " + arg.Type.String()
+	if arg.Default != nil {
+		if _, isEnum := namedType(arg.Type).(*Enum); isEnum {
+			s += fmt.Sprintf(" = %v", arg.Default)
+		} else if text, ok := arg.Default.(string); ok {
+			s += " = " + strconv.Quote(text)
+		} else {
+			s += fmt.Sprintf(" = %v", arg.Default)
+		}
+	}
+	return s
+}
+
+// Escalares padrão do GraphQL
+var (
+	String = &Scalar{
+		Name:      "String",
+		Serialize: serializeString,
+		ParseValue: func(value any) (any, error) {
+			if text, ok := value.(string); ok {
+				return text, nil
+			}
+			return nil, fmt.Errorf("String cannot represent a non string value: %s", describe(value))
+		},
+	}
+	Int = &Scalar{
+		Name: "Int",
+		Serialize: func(value any) (any, error) {
+			number, ok := toInt(reflect.ValueOf(value))
+			if !ok || number < math.MinInt32 || number > math.MaxInt32 {
+				return nil, fmt.Errorf("Int cannot represent value: %s", describe(value))
+			}
+			return number, nil
+		},
+		ParseValue: func(value any) (any, error) {
+			number, ok := toInt(reflect.ValueOf(value))
+			if !ok || number < math.MinInt32 || number > math.MaxInt32 {
+				return nil, fmt.Errorf("Int cannot represent non 32-bit signed integer value: %s", describe(value))
+			}
+			return int(number), nil
+		},
+	}
+	Float = &Scalar{
+		Name: "Float",
+		Serialize: func(value any) (any, error) {
+			if number, ok := toFloat(value); ok {
+				return number, nil
+			}
+			return nil, fmt.Errorf("Float cannot represent value: %s", describe(value))
+		},
+		ParseValue: func(value any) (any, error) {
+			if number, ok := toFloat(value); ok {
+				return number, nil
+			}
+			return nil, fmt.Errorf("Float cannot represent non numeric value: %s", describe(value))
+		},
+	}
+	Boolean = &Scalar{
+		Name: "Boolean",
+		Serialize: func(value any) (any, error) {
+			if b, ok := value.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent value: %s", describe(value))
+		},
+		ParseValue: func(value any) (any, error) {
+			if b, ok := value.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent a non boolean value: %s", describe(value))
+		},
+	}
+	ID = &Scalar{
+		Name:      "ID",
+		Serialize: serializeString,
+		ParseValue: func(value any) (any, error) {
+			if text, ok := value.(string); ok {
+				return text, nil
+			}
+			if number, ok := toInt(reflect.ValueOf(value)); ok {
+				return strconv.FormatInt(number, 10), nil
+			}
+			return nil, fmt.Errorf("ID cannot represent value: %s", describe(value))
+		},
+	}
+)
+
+// serializeString aceita strings e tipos baseados em string (ex: models.Status)
+func serializeString(value any) (any, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("String cannot represent value: %s", describe(value))
+}
+
+// toInt converte inteiros e floats sem parte fracionária
+func toInt(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || math.IsInf(f, 0) {
+			return 0, false
+		}
+		return int64(f), true
+	default:
+		return 0, false
+	}
+}
+
+// toFloat converte qualquer número
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		number, ok := toInt(v)
+		return float64(number), ok
+	}
+}
+
+// describe formata o valor recebido nas mensagens de erro
+func describe(value any) string {
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value)
+	case enumLiteral:
+		return string(value)
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
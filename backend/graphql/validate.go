@@ -0,0 +1,381 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Limits restringe o tamanho das consultas aceitas. Zero desativa o limite.
+type Limits struct {
+	// MaxDepth é o maior aninhamento de campos, ex: tasks { blockedBy { id } } tem profundidade 3
+	MaxDepth int
+	// MaxComplexity é o custo máximo: cada campo custa 1 (ou FieldDef.Cost) e a
+	// subseleção de um campo de lista conta ListCost vezes
+	MaxComplexity int
+	// ListCost estima quantos itens uma lista retorna (padrão: 10)
+	ListCost int
+}
+
+const defaultListCost = 10
+
+// maxCost satura o custo calculado, que cresce exponencialmente com listas e
+// fragmentos aninhados, para que a soma não estoure
+const maxCost = math.MaxInt32
+
+// Request é o corpo de uma requisição GraphQL
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Prepared é uma operação já interpretada, validada e com as variáveis
+// convertidas, pronta para Execute ou Subscribe
+type Prepared struct {
+	schema     *Schema
+	doc        *Document
+	op         *Operation
+	vars       map[string]any
+	Depth      int
+	Complexity int
+}
+
+// Type retorna o tipo da operação escolhida
+func (p *Prepared) Type() OperationType {
+	return p.op.Type
+}
+
+// directiveArgs são os argumentos de @include e @skip
+var directiveArgs = []*ArgumentDef{{Name: "if", Type: NewNonNull(Boolean)}}
+
+// Prepare interpreta e valida a requisição. Os erros retornados já vêm com
+// extensions.code e devem ser respondidos sem executar nada.
+func (s *Schema) Prepare(req Request, limits Limits) (*Prepared, []*Error) {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return nil, []*Error{toError(err).withCode(CodeParseFailed)}
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return nil, []*Error{toError(err).withCode(CodeValidationFailed)}
+	}
+	root := s.root(op.Type)
+	if root == nil {
+		return nil, []*Error{(&Error{Message: fmt.Sprintf("Schema is not configured for %ss.", op.Type), Locations: []Location{op.Loc}}).withCode(CodeValidationFailed)}
+	}
+
+	vars, errs := s.coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		for _, err := range errs {
+			err.withCode(CodeBadUserInput)
+		}
+		return nil, errs
+	}
+
+	v := &validator{schema: s, doc: doc, vars: vars, varDefs: make(map[string]*VariableDefinition), varTypes: make(map[string]Type),
+		listCost: min(limits.ListCost, maxCost), fragmentCosts: make(map[string]fragmentCost)}
+	if v.listCost <= 0 {
+		v.listCost = defaultListCost
+	}
+	for _, def := range op.Variables {
+		if _, exists := v.varDefs[def.Name]; exists {
+			v.fail(def.Loc, "There can be only one variable named \"$%s\".", def.Name)
+		}
+		v.varDefs[def.Name] = def
+		v.varTypes[def.Name] = s.typeFromRef(def.Type)
+	}
+	depth, complexity := v.selectionSet(root, op.SelectionSet)
+
+	prepared := &Prepared{schema: s, doc: doc, op: op, vars: vars, Depth: depth, Complexity: complexity}
+	if op.Type == OperationSubscription && len(v.errs) == 0 {
+		if fields := prepared.collectFields(root, op.SelectionSet); len(fields) != 1 {
+			v.fail(op.Loc, "Subscription must select only one top level field.")
+		}
+	}
+	if len(v.errs) > 0 {
+		for _, err := range v.errs {
+			err.withCode(CodeValidationFailed)
+		}
+		return nil, v.errs
+	}
+
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return nil, []*Error{(&Error{Message: fmt.Sprintf("Query depth %d exceeds the maximum of %d.", depth, limits.MaxDepth)}).withCode(CodeQueryTooDeep)}
+	}
+	if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+		return nil, []*Error{(&Error{Message: fmt.Sprintf("Query complexity %d exceeds the maximum of %d.", complexity, limits.MaxComplexity)}).withCode(CodeQueryTooComplex)}
+	}
+	return prepared, nil
+}
+
+// root retorna o tipo raiz da operação
+func (s *Schema) root(t OperationType) *Object {
+	switch t {
+	case OperationQuery:
+		return s.Query
+	case OperationMutation:
+		return s.Mutation
+	case OperationSubscription:
+		return s.Subscription
+	default:
+		return nil
+	}
+}
+
+// selectOperation escolhe a operação pelo nome ou a única do documento
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q.", name)}
+}
+
+// toError converte para *Error mantendo posição e extensões, se houver
+func toError(err error) *Error {
+	if gqlErr, ok := err.(*Error); ok {
+		return gqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+// validator confere a seleção contra o schema e calcula profundidade e custo
+type validator struct {
+	schema    *Schema
+	doc       *Document
+	vars      map[string]any
+	varDefs   map[string]*VariableDefinition
+	varTypes  map[string]Type
+	listCost  int
+	fragments []string
+	errs      []*Error
+
+	// fragmentCosts guarda profundidade e custo de cada fragmento já
+	// validado; sem ela, fragmentos que espalham outros várias vezes são
+	// percorridos um número exponencial de vezes
+	fragmentCosts map[string]fragmentCost
+}
+
+// fragmentCost é a profundidade e o custo da seleção de um fragmento
+type fragmentCost struct {
+	depth, cost int
+}
+
+// fail registra um erro de validação
+func (v *validator) fail(loc Location, format string, args ...any) {
+	v.errs = append(v.errs, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}})
+}
+
+// selectionSet valida a seleção sobre parent e retorna sua profundidade e custo
+func (v *validator) selectionSet(parent *Object, selections []Selection) (depth, cost int) {
+	for _, selection := range selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *Field:
+			d, c = v.field(parent, selection)
+		case *FragmentSpread:
+			v.directives(selection.Directives)
+			fragment, ok := v.doc.Fragments[selection.Name]
+			if !ok {
+				v.fail(selection.Loc, "Unknown fragment %q.", selection.Name)
+				continue
+			}
+			if fragment.TypeCondition != parent.Name {
+				v.fail(selection.Loc, "Fragment %q cannot be spread here as objects of type %q can never be of type %q.",
+					selection.Name, parent.Name, fragment.TypeCondition)
+				continue
+			}
+			if slices.Contains(v.fragments, selection.Name) {
+				v.fail(selection.Loc, "Cannot spread fragment %q within itself.", selection.Name)
+				continue
+			}
+			if known, ok := v.fragmentCosts[selection.Name]; ok {
+				d, c = known.depth, known.cost
+				break
+			}
+			v.fragments = append(v.fragments, selection.Name)
+			d, c = v.selectionSet(parent, fragment.SelectionSet)
+			v.fragments = v.fragments[:len(v.fragments)-1]
+			v.fragmentCosts[selection.Name] = fragmentCost{d, c}
+		case *InlineFragment:
+			v.directives(selection.Directives)
+			if selection.TypeCondition != "" && selection.TypeCondition != parent.Name {
+				v.fail(selection.Loc, "Fragment cannot be spread here as objects of type %q can never be of type %q.",
+					parent.Name, selection.TypeCondition)
+				continue
+			}
+			d, c = v.selectionSet(parent, selection.SelectionSet)
+		}
+		depth = max(depth, d)
+		cost = min(cost+c, maxCost)
+	}
+	return depth, cost
+}
+
+// field valida um campo, seus argumentos e sua subseleção
+func (v *validator) field(parent *Object, field *Field) (depth, cost int) {
+	v.directives(field.Directives)
+	if field.Name == "__typename" {
+		if field.SelectionSet != nil {
+			v.fail(field.Loc, "Field \"__typename\" must not have a selection since type \"String!\" has no subfields.")
+		}
+		return 1, 1
+	}
+
+	def := parent.Field(field.Name)
+	if def == nil {
+		v.fail(field.Loc, "Cannot query field %q on type %q.", field.Name, parent.Name)
+		return 0, 0
+	}
+	v.arguments(fmt.Sprintf("%s.%s", parent.Name, def.Name), def.Args, field.Arguments, field.Loc)
+
+	cost = max(def.Cost, 1)
+	object, isObject := namedType(def.Type).(*Object)
+	switch {
+	case isObject && field.SelectionSet == nil:
+		v.fail(field.Loc, "Field %q of type %q must have a selection of subfields.", field.Name, def.Type)
+		return 1, cost
+	case !isObject && field.SelectionSet != nil:
+		v.fail(field.Loc, "Field %q must not have a selection since type %q has no subfields.", field.Name, def.Type)
+		return 1, cost
+	case !isObject:
+		return 1, cost
+	}
+
+	childDepth, childCost := v.selectionSet(object, field.SelectionSet)
+	if isList(def.Type) {
+		childCost = min(childCost*v.listCost, maxCost)
+	}
+	return childDepth + 1, min(cost+childCost, maxCost)
+}
+
+// directives aceita apenas @include(if:) e @skip(if:)
+func (v *validator) directives(directives []*Directive) {
+	for _, directive := range directives {
+		if directive.Name != "include" && directive.Name != "skip" {
+			v.fail(directive.Loc, "Unknown directive \"@%s\".", directive.Name)
+			continue
+		}
+		v.arguments("@"+directive.Name, directiveArgs, directive.Arguments, directive.Loc)
+	}
+}
+
+// arguments confere nomes, obrigatoriedade, valores e uso de variáveis
+func (v *validator) arguments(owner string, defs []*ArgumentDef, args []*Argument, loc Location) {
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		if seen[arg.Name] {
+			v.fail(arg.Loc, "There can be only one argument named %q.", arg.Name)
+			continue
+		}
+		seen[arg.Name] = true
+
+		def := findArgument(defs, arg.Name)
+		if def == nil {
+			v.fail(arg.Loc, "Unknown argument %q on %s.", arg.Name, owner)
+			continue
+		}
+		v.variableUsages(arg.Value, def.Type, def.Default != nil)
+		if _, _, err := coerceLiteral(def.Type, arg.Value, v.vars); err != nil {
+			v.fail(arg.Loc, "Argument %q has invalid value: %v.", arg.Name, err)
+		}
+	}
+	for _, def := range defs {
+		if _, nonNull := def.Type.(*NonNull); nonNull && def.Default == nil && !seen[def.Name] {
+			v.fail(loc, "Argument %q of type %q is required on %s, but it was not provided.", def.Name, def.Type, owner)
+		}
+	}
+}
+
+// variableUsages confere se cada variável usada está definida com tipo compatível
+func (v *validator) variableUsages(value *Value, location Type, locationDefault bool) {
+	switch value.Kind {
+	case ValueVariable:
+		def, ok := v.varDefs[value.Raw]
+		if !ok {
+			v.fail(value.Loc, "Variable \"$%s\" is not defined.", value.Raw)
+			return
+		}
+		varType := v.varTypes[value.Raw]
+		if varType == nil {
+			return
+		}
+		target := location
+		if nonNull, ok := location.(*NonNull); ok && (def.Default != nil || locationDefault) {
+			if _, varNonNull := varType.(*NonNull); !varNonNull {
+				target = nonNull.Of
+			}
+		}
+		if !compatible(varType, target) {
+			v.fail(value.Loc, "Variable \"$%s\" of type %q used in position expecting type %q.", value.Raw, varType, location)
+		}
+	case ValueList:
+		item := location
+		if nonNull, ok := item.(*NonNull); ok {
+			item = nonNull.Of
+		}
+		if list, ok := item.(*List); ok {
+			item = list.Of
+		}
+		for _, element := range value.List {
+			v.variableUsages(element, item, false)
+		}
+	case ValueObject:
+		input, ok := namedType(location).(*InputObject)
+		if !ok {
+			return
+		}
+		for _, field := range value.Fields {
+			if def := input.Field(field.Name); def != nil {
+				v.variableUsages(field.Value, def.Type, def.Default != nil)
+			}
+		}
+	}
+}
+
+// compatible indica se uma variável do tipo varType pode ser usada onde se espera location
+func compatible(varType, location Type) bool {
+	if nonNull, ok := location.(*NonNull); ok {
+		varNonNull, ok := varType.(*NonNull)
+		return ok && compatible(varNonNull.Of, nonNull.Of)
+	}
+	if varNonNull, ok := varType.(*NonNull); ok {
+		return compatible(varNonNull.Of, location)
+	}
+	if list, ok := location.(*List); ok {
+		varList, ok := varType.(*List)
+		return ok && compatible(varList.Of, list.Of)
+	}
+	if _, ok := varType.(*List); ok {
+		return false
+	}
+	return varType == location
+}
+
+// findArgument busca a definição de um argumento pelo nome
+func findArgument(defs []*ArgumentDef, name string) *ArgumentDef {
+	for _, def := range defs {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// isList indica se o tipo (sem NonNull) é uma lista
+func isList(t Type) bool {
+	if nonNull, ok := t.(*NonNull); ok {
+		t = nonNull.Of
+	}
+	_, ok := t.(*List)
+	return ok
+}
@@ -0,0 +1,263 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// enumLiteral distingue um valor de enum escrito no documento (ex: done) de
+// uma string ("done"), que só é aceita em enums quando vem de variáveis
+type enumLiteral string
+
+// coerceVariables converte as variáveis recebidas conforme as definições da operação
+func (s *Schema) coerceVariables(op *Operation, input map[string]any) (map[string]any, []*Error) {
+	values := make(map[string]any)
+	var errs []*Error
+	for _, def := range op.Variables {
+		fail := func(format string, args ...any) {
+			errs = append(errs, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{def.Loc}})
+		}
+
+		t := s.typeFromRef(def.Type)
+		if t == nil {
+			fail("Unknown type %q.", def.Type)
+			continue
+		}
+		if !isInputType(t) {
+			fail("Variable \"$%s\" cannot be non-input type %q.", def.Name, def.Type)
+			continue
+		}
+
+		value, provided := input[def.Name]
+		switch {
+		case !provided && def.Default != nil:
+			coerced, _, err := coerceLiteral(t, def.Default, nil)
+			if err != nil {
+				fail("Variable \"$%s\" has invalid default value: %v", def.Name, err)
+				continue
+			}
+			values[def.Name] = coerced
+		case !provided:
+			if _, nonNull := t.(*NonNull); nonNull {
+				fail("Variable \"$%s\" of required type %q was not provided.", def.Name, def.Type)
+			}
+		default:
+			coerced, err := coerceInput(t, value)
+			if err != nil {
+				fail("Variable \"$%s\" got invalid value %s; %v", def.Name, describe(value), err)
+				continue
+			}
+			values[def.Name] = coerced
+		}
+	}
+	return values, errs
+}
+
+// coerceInput converte um valor vindo do JSON das variáveis para o tipo de entrada
+func coerceInput(t Type, value any) (any, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected non-nullable type %q not to be null", t)
+		}
+		return coerceInput(nonNull.Of, value)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items, ok := value.([]any)
+		if !ok {
+			// Um valor único vale como lista de um item
+			item, err := coerceInput(t.Of, value)
+			if err != nil {
+				return nil, err
+			}
+			return []any{item}, nil
+		}
+		list := make([]any, len(items))
+		for i, item := range items {
+			coerced, err := coerceInput(t.Of, item)
+			if err != nil {
+				return nil, fmt.Errorf("at index %d: %w", i, err)
+			}
+			list[i] = coerced
+		}
+		return list, nil
+	case *Scalar:
+		return t.ParseValue(value)
+	case *Enum:
+		text, ok := value.(string)
+		if !ok || !slices.Contains(t.Values, text) {
+			return nil, fmt.Errorf("value %s does not exist in %q enum", describe(value), t.Name)
+		}
+		return text, nil
+	case *InputObject:
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected type %q to be an object", t.Name)
+		}
+		for name := range fields {
+			if t.Field(name) == nil {
+				return nil, fmt.Errorf("field %q is not defined by type %q", name, t.Name)
+			}
+		}
+		object := make(map[string]any)
+		for _, field := range t.Fields {
+			raw, present := fields[field.Name]
+			if !present {
+				if err := applyDefault(object, field, t.Name); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			coerced, err := coerceInput(field.Type, raw)
+			if err != nil {
+				return nil, fmt.Errorf("at %q: %w", field.Name, err)
+			}
+			object[field.Name] = coerced
+		}
+		return object, nil
+	default:
+		return nil, fmt.Errorf("type %q is not an input type", t)
+	}
+}
+
+// coerceLiteral converte um valor escrito no documento. present é falso
+// quando o valor é uma variável não informada, para que o argumento fique ausente.
+func coerceLiteral(t Type, value *Value, vars map[string]any) (any, bool, error) {
+	if value.Kind == ValueVariable {
+		coerced, ok := vars[value.Raw]
+		if !ok {
+			if _, nonNull := t.(*NonNull); nonNull {
+				return nil, false, fmt.Errorf("variable \"$%s\" of required type %q was not provided", value.Raw, t)
+			}
+			return nil, false, nil
+		}
+		// As variáveis já foram convertidas e a compatibilidade dos tipos é
+		// conferida na validação
+		return coerced, true, nil
+	}
+
+	if nonNull, ok := t.(*NonNull); ok {
+		if value.Kind == ValueNull {
+			return nil, false, fmt.Errorf("expected value of type %q, found null", t)
+		}
+		return coerceLiteral(nonNull.Of, value, vars)
+	}
+	if value.Kind == ValueNull {
+		return nil, true, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		if value.Kind != ValueList {
+			item, present, err := coerceLiteral(t.Of, value, vars)
+			if err != nil || !present {
+				return nil, present, err
+			}
+			return []any{item}, true, nil
+		}
+		list := make([]any, 0, len(value.List))
+		for _, item := range value.List {
+			coerced, _, err := coerceLiteral(t.Of, item, vars)
+			if err != nil {
+				return nil, false, err
+			}
+			list = append(list, coerced)
+		}
+		return list, true, nil
+	case *InputObject:
+		if value.Kind != ValueObject {
+			return nil, false, fmt.Errorf("expected value of type %q, found %s", t.Name, value.Raw)
+		}
+		object := make(map[string]any)
+		seen := make(map[string]bool)
+		for _, field := range value.Fields {
+			def := t.Field(field.Name)
+			if def == nil {
+				return nil, false, fmt.Errorf("field %q is not defined by type %q", field.Name, t.Name)
+			}
+			seen[field.Name] = true
+			coerced, present, err := coerceLiteral(def.Type, field.Value, vars)
+			if err != nil {
+				return nil, false, fmt.Errorf("at %q: %w", field.Name, err)
+			}
+			if present {
+				object[field.Name] = coerced
+			}
+		}
+		for _, def := range t.Fields {
+			if !seen[def.Name] {
+				if err := applyDefault(object, def, t.Name); err != nil {
+					return nil, false, err
+				}
+			}
+		}
+		return object, true, nil
+	case *Enum:
+		if value.Kind != ValueEnum || !slices.Contains(t.Values, value.Raw) {
+			return nil, false, fmt.Errorf("value %s does not exist in %q enum", literalText(value), t.Name)
+		}
+		return value.Raw, true, nil
+	case *Scalar:
+		raw, err := literal(value)
+		if err != nil {
+			return nil, false, err
+		}
+		coerced, err := t.ParseValue(raw)
+		return coerced, err == nil, err
+	default:
+		return nil, false, fmt.Errorf("type %q is not an input type", t)
+	}
+}
+
+// applyDefault preenche o valor padrão do campo ausente ou falha se for obrigatório
+func applyDefault(object map[string]any, field *ArgumentDef, typeName string) error {
+	if field.Default != nil {
+		object[field.Name] = field.Default
+		return nil
+	}
+	if _, nonNull := field.Type.(*NonNull); nonNull {
+		return fmt.Errorf("field %s.%s of required type %q was not provided", typeName, field.Name, field.Type)
+	}
+	return nil
+}
+
+// literal converte um literal escalar para o valor Go equivalente
+func literal(value *Value) (any, error) {
+	switch value.Kind {
+	case ValueInt:
+		number, err := strconv.ParseInt(value.Raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s", value.Raw)
+		}
+		return number, nil
+	case ValueFloat:
+		return strconv.ParseFloat(value.Raw, 64)
+	case ValueString:
+		return value.Raw, nil
+	case ValueBoolean:
+		return value.Raw == "true", nil
+	case ValueEnum:
+		return enumLiteral(value.Raw), nil
+	default:
+		return nil, fmt.Errorf("expected a scalar value, found %s", literalText(value))
+	}
+}
+
+// literalText formata o literal nas mensagens de erro
+func literalText(value *Value) string {
+	switch value.Kind {
+	case ValueString:
+		return strconv.Quote(value.Raw)
+	case ValueList:
+		return "list"
+	case ValueObject:
+		return "object"
+	default:
+		return value.Raw
+	}
+}
@@ -0,0 +1,66 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Client chama as RPCs de TaskService por HTTP/2 sem TLS, para serviços Go
+// que não usam código gerado pelo protoc
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient cria um cliente para o servidor em addr (ex: localhost:9090)
+func NewClient(addr string) *Client {
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	return &Client{
+		baseURL: "http://" + strings.TrimPrefix(addr, "http://"),
+		http:    &http.Client{Transport: &http.Transport{Protocols: &protocols}},
+	}
+}
+
+// Invoke chama o método (ex: "CreateTask") e decodifica a resposta em resp.
+// Erros do servidor são devolvidos como *StatusError.
+func (c *Client) Invoke(ctx context.Context, method string, req, resp any) error {
+	url := c.baseURL + "/" + ServiceName + "/" + method
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(frame(marshal(req))))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	httpReq.Header.Set("TE", "trailers")
+
+	httpResp, err := c.http.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("grpc: unexpected HTTP status %s", httpResp.Status)
+	}
+
+	// Resposta "trailers-only": o status já vem nos headers
+	if code := httpResp.Header.Get("Grpc-Status"); code != "" {
+		if status := parseStatus(code, httpResp.Header.Get("Grpc-Message")); status != nil {
+			return status
+		}
+	}
+
+	body, readErr := readMessage(httpResp.Body)
+	// Os trailers só ficam disponíveis depois de o corpo ser lido até o fim
+	io.Copy(io.Discard, httpResp.Body)
+	if status := parseStatus(httpResp.Trailer.Get("Grpc-Status"), httpResp.Trailer.Get("Grpc-Message")); status != nil {
+		return status
+	}
+	if readErr != nil {
+		return readErr
+	}
+	return unmarshal(body, resp)
+}
@@ -0,0 +1,322 @@
+package grpcapi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tipos de fio do protobuf usados pelas mensagens
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var (
+	errTruncated = errors.New("proto: message is truncated")
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// protoField liga um campo da struct ao número do campo no .proto
+type protoField struct {
+	index  int
+	number int
+}
+
+var fieldCache sync.Map // reflect.Type -> []protoField
+
+// protoFields lê as tags pb:"N" da struct
+func protoFields(t reflect.Type) []protoField {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]protoField)
+	}
+	var fields []protoField
+	for i := range t.NumField() {
+		tag := t.Field(i).Tag.Get("pb")
+		if tag == "" {
+			continue
+		}
+		number, err := strconv.Atoi(tag)
+		if err != nil || number <= 0 {
+			panic(fmt.Sprintf("grpcapi: invalid pb tag %q on %s.%s", tag, t.Name(), t.Field(i).Name))
+		}
+		fields = append(fields, protoField{index: i, number: number})
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// marshal codifica a struct no formato binário do protobuf. Os campos são
+// mapeados pela tag pb; escalares com valor zero são omitidos (proto3),
+// ponteiros representam campos optional e mensagens, *time.Time vira
+// google.protobuf.Timestamp e map[string]string vira map<string, string>.
+func marshal(msg any) []byte {
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return appendMessage(nil, v)
+}
+
+func appendMessage(b []byte, v reflect.Value) []byte {
+	for _, field := range protoFields(v.Type()) {
+		b = appendField(b, field.number, v.Field(field.index))
+	}
+	return b
+}
+
+// appendField codifica um campo; ponteiros nulos e valores zero ficam de fora
+func appendField(b []byte, number int, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return b
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Struct {
+			return appendTag(b, number, wireBytes, embedded(elem))
+		}
+		return appendScalar(b, number, elem)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Len() == 0 {
+				return b
+			}
+			return appendTag(b, number, wireBytes, v.Bytes())
+		}
+		for i := range v.Len() {
+			if item := v.Index(i); item.Kind() == reflect.Pointer {
+				b = appendField(b, number, item)
+			} else {
+				// Itens de listas são enviados mesmo com valor zero
+				b = appendScalar(b, number, item)
+			}
+		}
+		return b
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, c reflect.Value) int { return strings.Compare(a.String(), c.String()) })
+		for _, key := range keys {
+			var entry []byte
+			entry = appendScalar(entry, 1, key)
+			entry = appendScalar(entry, 2, v.MapIndex(key))
+			b = appendTag(b, number, wireBytes, entry)
+		}
+		return b
+	default:
+		if v.IsZero() {
+			return b
+		}
+		return appendScalar(b, number, v)
+	}
+}
+
+// embedded codifica uma mensagem aninhada ou um Timestamp
+func embedded(v reflect.Value) []byte {
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		var b []byte
+		if seconds := t.Unix(); seconds != 0 {
+			b = binary.AppendUvarint(appendKey(b, 1, wireVarint), uint64(seconds))
+		}
+		if nanos := t.Nanosecond(); nanos != 0 {
+			b = binary.AppendUvarint(appendKey(b, 2, wireVarint), uint64(nanos))
+		}
+		return b
+	}
+	return appendMessage(nil, v)
+}
+
+// appendScalar codifica string, bool e inteiros mesmo quando têm valor zero
+func appendScalar(b []byte, number int, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.String:
+		return appendTag(b, number, wireBytes, []byte(v.String()))
+	case reflect.Bool:
+		value := uint64(0)
+		if v.Bool() {
+			value = 1
+		}
+		return binary.AppendUvarint(appendKey(b, number, wireVarint), value)
+	case reflect.Int32, reflect.Int64, reflect.Int:
+		// Negativos são estendidos para 64 bits, como no protobuf
+		return binary.AppendUvarint(appendKey(b, number, wireVarint), uint64(v.Int()))
+	case reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(appendKey(b, number, wireVarint), v.Uint())
+	case reflect.Struct:
+		return appendTag(b, number, wireBytes, embedded(v))
+	default:
+		panic(fmt.Sprintf("grpcapi: unsupported field type %s", v.Type()))
+	}
+}
+
+func appendKey(b []byte, number, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(number)<<3|uint64(wireType))
+}
+
+func appendTag(b []byte, number, wireType int, data []byte) []byte {
+	b = appendKey(b, number, wireType)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// unmarshal decodifica a mensagem na struct apontada por msg, ignorando
+// campos desconhecidos
+func unmarshal(data []byte, msg any) error {
+	return decodeMessage(data, reflect.ValueOf(msg).Elem())
+}
+
+func decodeMessage(data []byte, v reflect.Value) error {
+	fields := make(map[int]reflect.Value)
+	for _, field := range protoFields(v.Type()) {
+		fields[field.number] = v.Field(field.index)
+	}
+
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		number, wireType := int(key>>3), int(key&7)
+
+		var varint uint64
+		var bytes []byte
+		switch wireType {
+		case wireVarint:
+			varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return errTruncated
+			}
+			bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wireType == wireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return errTruncated
+			}
+			data = data[size:]
+			continue
+		default:
+			return fmt.Errorf("proto: unsupported wire type %d", wireType)
+		}
+
+		field, ok := fields[number]
+		if !ok {
+			continue
+		}
+		if err := decodeField(field, wireType, varint, bytes); err != nil {
+			return fmt.Errorf("proto: field %d: %w", number, err)
+		}
+	}
+	return nil
+}
+
+// decodeField grava o valor lido no campo, acumulando repeated e maps
+func decodeField(field reflect.Value, wireType int, varint uint64, data []byte) error {
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if field.IsNil() || elem.Elem().Kind() != reflect.Struct {
+			field.Set(elem)
+		}
+		return decodeField(field.Elem(), wireType, varint, data)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			if wireType != wireBytes {
+				return errors.New("expected bytes")
+			}
+			field.SetBytes(append([]byte(nil), data...))
+			return nil
+		}
+		item := reflect.New(field.Type().Elem()).Elem()
+		if err := decodeField(item, wireType, varint, data); err != nil {
+			return err
+		}
+		field.Set(reflect.Append(field, item))
+		return nil
+	case reflect.Map:
+		if wireType != wireBytes {
+			return errors.New("expected map entry")
+		}
+		var entry struct {
+			Key   string `pb:"1"`
+			Value string `pb:"2"`
+		}
+		if err := decodeMessage(data, reflect.ValueOf(&entry).Elem()); err != nil {
+			return err
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+		field.SetMapIndex(reflect.ValueOf(entry.Key), reflect.ValueOf(entry.Value))
+		return nil
+	case reflect.Struct:
+		if wireType != wireBytes {
+			return errors.New("expected message")
+		}
+		if field.Type() == timeType {
+			var ts struct {
+				Seconds int64 `pb:"1"`
+				Nanos   int32 `pb:"2"`
+			}
+			if err := decodeMessage(data, reflect.ValueOf(&ts).Elem()); err != nil {
+				return err
+			}
+			field.Set(reflect.ValueOf(time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()))
+			return nil
+		}
+		return decodeMessage(data, field)
+	case reflect.String:
+		if wireType != wireBytes {
+			return errors.New("expected string")
+		}
+		field.SetString(string(data))
+		return nil
+	case reflect.Bool:
+		if wireType != wireVarint {
+			return errors.New("expected bool")
+		}
+		field.SetBool(varint != 0)
+		return nil
+	case reflect.Int32, reflect.Int64, reflect.Int:
+		if wireType != wireVarint {
+			return errors.New("expected integer")
+		}
+		value := int64(varint)
+		if field.Kind() == reflect.Int32 && (value < math.MinInt32 || value > math.MaxInt32) {
+			value = int64(int32(value))
+		}
+		field.SetInt(value)
+		return nil
+	case reflect.Uint32, reflect.Uint64:
+		if wireType != wireVarint {
+			return errors.New("expected integer")
+		}
+		field.SetUint(varint)
+		return nil
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+}
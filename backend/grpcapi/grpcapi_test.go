@@ -0,0 +1,431 @@
+package grpcapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/service"
+	"github.com/acauhi/kanban-backend/storage"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+// newTestClient sobe o servidor gRPC com HTTP/2 sem TLS e retorna um cliente para ele
+func newTestClient(t *testing.T) (*Client, *service.TaskService) {
+	t.Helper()
+	svc := service.NewTaskService(repository.NewInMemoryTaskRepository(),
+		service.WithAttachmentStorage(repository.NewInMemoryAttachmentRepository(), storage.NewMemoryBlobStore()),
+	)
+
+	server := httptest.NewUnstartedServer(NewServer(svc))
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	server.Config.Protocols = &protocols
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return NewClient(server.Listener.Addr().String()), svc
+}
+
+// expectCode confere o código do StatusError retornado
+func expectCode(t *testing.T, err error, code Code) {
+	t.Helper()
+	var status *StatusError
+	if !errors.As(err, &status) {
+		t.Fatalf("expected *StatusError with code %d, got %v", code, err)
+	}
+	if status.Code != code {
+		t.Errorf("expected code %d, got %d (%s)", code, status.Code, status.Message)
+	}
+}
+
+func TestMarshalMatchesProtobufWireFormat(t *testing.T) {
+	// Bytes definidos pela especificação do formato binário do protobuf
+	tests := []struct {
+		name string
+		msg  any
+		want []byte
+	}{
+		{"empty", &Empty{}, nil},
+		{"scalars", &Task{ID: "1", Status: StatusDone, Completed: true, Progress: 50},
+			[]byte{0x0a, 0x01, '1', 0x20, 0x03, 0x28, 0x01, 0x70, 0x32}},
+		{"zero values are omitted", &Task{ID: "", Status: StatusUnspecified}, nil},
+		{"repeated strings", &ReorderChecklistRequest{ItemIDs: []string{"a", ""}},
+			[]byte{0x12, 0x01, 'a', 0x12, 0x00}},
+		{"optional presence", &UpdateChecklistItemRequest{Checked: new(bool)},
+			[]byte{0x20, 0x00}},
+		{"timestamp", &Occurrence{ScheduledAt: ptr(time.Unix(1700000000, 5))},
+			[]byte{0x12, 0x08, 0x08, 0x80, 0xe2, 0xcf, 0xaa, 0x06, 0x10, 0x05}},
+		{"map", &CreateTaskFromTemplateRequest{Variables: map[string]string{"b": "2", "a": "1"}},
+			[]byte{0x12, 0x06, 0x0a, 0x01, 'a', 0x12, 0x01, '1', 0x12, 0x06, 0x0a, 0x01, 'b', 0x12, 0x01, '2'}},
+		{"negative int", &ChecklistItem{Order: -1},
+			[]byte{0x20, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := marshal(tt.msg); !bytes.Equal(got, tt.want) {
+				t.Errorf("expected % x, got % x", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRoundTrip(t *testing.T) {
+	due := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+	title := "New"
+	status := StatusInProgress
+	tests := []any{
+		&Task{
+			ID: "1", Title: "Task", Status: StatusDone, Completed: true, CompletedAt: &due,
+			Checklist: []*ChecklistItem{{ID: "a", Text: "one", Checked: true, Order: 0}, {ID: "b", Text: "two", Order: 1}},
+			Labels:    []string{"x", "y"}, Priority: PriorityUrgent, DueDate: &due,
+			Occurrence: &Occurrence{RecurringTaskID: "r", ScheduledAt: &due}, Progress: 50,
+		},
+		&UpdateTaskRequest{ID: "1", Title: &title, Status: &status, Labels: &LabelIDs{}},
+		&CreateTaskFromTemplateRequest{TemplateID: "t", Variables: map[string]string{"name": "value"}},
+		&AttachmentContent{Attachment: &Attachment{ID: "a", Size: 3}, Content: []byte{0, 1, 2}},
+		&ChecklistItem{Order: -7},
+	}
+
+	for _, msg := range tests {
+		decoded := reflect.New(reflect.TypeOf(msg).Elem()).Interface()
+		if err := unmarshal(marshal(msg), decoded); err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+		if !reflect.DeepEqual(msg, decoded) {
+			t.Errorf("expected %+v, got %+v", msg, decoded)
+		}
+	}
+}
+
+func TestUnmarshalSkipsUnknownFieldsAndRejectsTruncated(t *testing.T) {
+	// Campo 99 (varint), campo 98 (fixed64) e campo 97 (bytes) antes do id
+	data := []byte{0x98, 0x06, 0x01, 0x91, 0x06, 1, 2, 3, 4, 5, 6, 7, 8, 0x8a, 0x06, 0x01, 'z', 0x0a, 0x01, '7'}
+	var req GetTaskRequest
+	if err := unmarshal(data, &req); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if req.ID != "7" {
+		t.Errorf("expected id 7, got %q", req.ID)
+	}
+
+	if err := unmarshal([]byte{0x0a, 0x05, 'a'}, &req); err == nil {
+		t.Error("expected error for truncated message")
+	}
+}
+
+func TestTaskLifecycle(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	var task Task
+	err := client.Invoke(ctx, "CreateTask", &CreateTaskRequest{Title: "Write docs", Priority: PriorityHigh, Checklist: []string{"draft", "review"}}, &task)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if task.ID == "" || task.Status != StatusTodo || task.Priority != PriorityHigh || len(task.Checklist) != 2 {
+		t.Fatalf("unexpected task %+v", task)
+	}
+
+	var checked Task
+	err = client.Invoke(ctx, "UpdateChecklistItem", &UpdateChecklistItemRequest{TaskID: task.ID, ItemID: task.Checklist[0].ID, Checked: ptr(true)}, &checked)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if checked.Progress != 50 {
+		t.Errorf("expected progress 50, got %d", checked.Progress)
+	}
+
+	done := StatusDone
+	var updated Task
+	if err := client.Invoke(ctx, "UpdateTask", &UpdateTaskRequest{ID: task.ID, Status: &done}, &updated); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !updated.Completed || updated.CompletedAt == nil || updated.Title != "Write docs" {
+		t.Errorf("expected completed task with title kept, got %+v", updated)
+	}
+
+	var list ListTasksResponse
+	if err := client.Invoke(ctx, "ListTasks", &ListTasksRequest{Sort: SortFieldTitle}, &list); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(list.Tasks) != 1 || list.Tasks[0].ID != task.ID {
+		t.Errorf("expected task %s in list, got %+v", task.ID, list.Tasks)
+	}
+
+	if err := client.Invoke(ctx, "DeleteTask", &DeleteTaskRequest{ID: task.ID}, &Empty{}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	err = client.Invoke(ctx, "GetTask", &GetTaskRequest{ID: task.ID}, &Task{})
+	expectCode(t, err, CodeNotFound)
+}
+
+func TestErrorsMapToStatusCodes(t *testing.T) {
+	client, svc := newTestClient(t)
+	ctx := context.Background()
+
+	blocker, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Blocker"})
+	blocked, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Blocked"})
+	if err := client.Invoke(ctx, "AddDependency", &AddDependencyRequest{TaskID: blocked.ID, BlockerID: blocker.ID}, &TaskDependencies{}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	invalid := Status(42)
+	done := StatusDone
+	tests := []struct {
+		name   string
+		method string
+		req    any
+		code   Code
+	}{
+		{"task not found", "GetTask", &GetTaskRequest{ID: "missing"}, CodeNotFound},
+		{"update missing task", "UpdateTask", &UpdateTaskRequest{ID: "missing"}, CodeNotFound},
+		{"empty title", "CreateTask", &CreateTaskRequest{}, CodeInvalidArgument},
+		{"invalid status", "UpdateTask", &UpdateTaskRequest{ID: blocker.ID, Status: &invalid}, CodeInvalidArgument},
+		{"invalid priority", "CreateTask", &CreateTaskRequest{Title: "x", Priority: Priority(9)}, CodeInvalidArgument},
+		{"invalid sort", "ListTasks", &ListTasksRequest{Sort: SortField(9)}, CodeInvalidArgument},
+		{"task blocked", "UpdateTask", &UpdateTaskRequest{ID: blocked.ID, Status: &done}, CodeFailedPrecondition},
+		{"dependency cycle", "AddDependency", &AddDependencyRequest{TaskID: blocker.ID, BlockerID: blocked.ID}, CodeFailedPrecondition},
+		{"unknown method", "Archive", &Empty{}, CodeUnimplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectCode(t, client.Invoke(ctx, tt.method, tt.req, &Task{}), tt.code)
+		})
+	}
+
+	var status *StatusError
+	errors.As(client.Invoke(ctx, "CreateTask", &CreateTaskRequest{}, &Task{}), &status)
+	if status.Message != service.ErrInvalidTitle.Error() {
+		t.Errorf("expected message %q, got %q", service.ErrInvalidTitle, status.Message)
+	}
+}
+
+func TestToStatusUsesServiceErrorClasses(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code Code
+	}{
+		{"duplicate checklist item", fmt.Errorf("checklist[1].id: %w", service.ErrDuplicateChecklistItem), CodeInvalidArgument},
+		{"invalid template name", service.ErrInvalidTemplateName, CodeInvalidArgument},
+		{"invalid schedule", service.ErrInvalidSchedule, CodeInvalidArgument},
+		{"invalid timezone", service.ErrInvalidTimezone, CodeInvalidArgument},
+		{"duplicate label", service.ErrDuplicateLabel, CodeFailedPrecondition},
+		{"recurring task not found", repository.ErrRecurringTaskNotFound, CodeNotFound},
+		{"label cited by a field", &service.FieldError{Field: "labels", Err: repository.ErrLabelNotFound}, CodeInvalidArgument},
+		{"invalid feed token", service.ErrInvalidFeedToken, CodeUnauthenticated},
+		{"unexpected", errors.New("disk on fire"), CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toStatus(tt.err).Code; got != tt.code {
+				t.Errorf("expected code %d, got %d", tt.code, got)
+			}
+		})
+	}
+}
+
+func TestAttachmentsAndComments(t *testing.T) {
+	client, svc := newTestClient(t)
+	ctx := context.Background()
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+
+	var attachment Attachment
+	content := []byte("hello, gRPC")
+	if err := client.Invoke(ctx, "AddAttachment", &AddAttachmentRequest{TaskID: task.ID, FileName: "notes.txt", Content: content}, &attachment); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	var downloaded AttachmentContent
+	if err := client.Invoke(ctx, "GetAttachment", &GetAttachmentRequest{TaskID: task.ID, AttachmentID: attachment.ID}, &downloaded); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !bytes.Equal(downloaded.Content, content) || downloaded.Attachment.FileName != "notes.txt" || downloaded.Attachment.Size != int64(len(content)) {
+		t.Errorf("unexpected attachment %+v", downloaded)
+	}
+
+	var comment Comment
+	if err := client.Invoke(ctx, "AddComment", &AddCommentRequest{TaskID: task.ID, Author: "ana", Body: "Olá, ção"}, &comment); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	var comments ListCommentsResponse
+	if err := client.Invoke(ctx, "ListComments", &ListCommentsRequest{TaskID: task.ID}, &comments); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(comments.Comments) != 1 || comments.Comments[0].Body != "Olá, ção" || comments.Comments[0].CreatedAt == nil {
+		t.Errorf("unexpected comments %+v", comments.Comments)
+	}
+
+	err := client.Invoke(ctx, "AddComment", &AddCommentRequest{TaskID: task.ID, Author: "ana", Body: strings.Repeat("x", service.MaxCommentBodyLength+1)}, &comment)
+	expectCode(t, err, CodeInvalidArgument)
+}
+
+func TestServerRejectsNonGRPCRequests(t *testing.T) {
+	server := NewServer(service.NewTaskService(repository.NewInMemoryTaskRepository()))
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+ServiceName+"/GetTask", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/"+ServiceName+"/GetTask", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	server.ServeHTTP(w, req)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status 415, got %d", w.Code)
+	}
+}
+
+func TestGrpcMessageEncoding(t *testing.T) {
+	message := "100% inválido\n"
+	encoded := encodeGrpcMessage(message)
+	if encoded != "100%25 inv%C3%A1lido%0A" {
+		t.Errorf("unexpected encoding %q", encoded)
+	}
+	if decoded := decodeGrpcMessage(encoded); decoded != message {
+		t.Errorf("expected %q, got %q", message, decoded)
+	}
+}
+
+// protoMessages lê tasks.proto linha a linha e retorna os campos de cada
+// mensagem como nome -> número
+func protoMessages(t *testing.T) map[string]map[string]int {
+	t.Helper()
+	file, err := os.Open("tasks.proto")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	defer file.Close()
+
+	messagePattern := regexp.MustCompile(`^message (\w+) \{(\})?$`)
+	fieldPattern := regexp.MustCompile(`^(?:optional |repeated )?(?:map<[^>]+>|[\w.]+) (\w+) = (\d+);$`)
+	messages := make(map[string]map[string]int)
+	var current map[string]int
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "//")
+		text = strings.TrimSpace(text)
+		switch {
+		case text == "":
+		case current == nil:
+			if m := messagePattern.FindStringSubmatch(text); m != nil {
+				messages[m[1]] = make(map[string]int)
+				if m[2] == "" {
+					current = messages[m[1]]
+				}
+			}
+		case text == "}":
+			current = nil
+		default:
+			m := fieldPattern.FindStringSubmatch(text)
+			if m == nil {
+				t.Fatalf("tasks.proto:%d: unexpected line %q", line, text)
+			}
+			current[m[1]], _ = strconv.Atoi(m[2])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return messages
+}
+
+// goMessages lê as structs de messages.go e retorna os campos de cada uma
+// como nome -> número da tag pb
+func goMessages(t *testing.T) map[string]map[string]int {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "messages.go", nil, 0)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	messages := make(map[string]map[string]int)
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		fields, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		messages[spec.Name.Name] = make(map[string]int)
+		for _, field := range fields.Fields.List {
+			if field.Tag == nil || len(field.Names) != 1 {
+				t.Fatalf("%s: expected one named field per pb tag", spec.Name.Name)
+			}
+			tag, _ := strconv.Unquote(field.Tag.Value)
+			number, err := strconv.Atoi(reflect.StructTag(tag).Get("pb"))
+			if err != nil {
+				t.Fatalf("%s.%s: invalid pb tag %s", spec.Name.Name, field.Names[0].Name, field.Tag.Value)
+			}
+			messages[spec.Name.Name][field.Names[0].Name] = number
+		}
+		return false
+	})
+	return messages
+}
+
+// As structs de messages.go devem ter os mesmos campos e números de tasks.proto;
+// task_id em snake_case corresponde a TaskID
+func TestMessagesMatchProto(t *testing.T) {
+	proto, structs := protoMessages(t), goMessages(t)
+	if len(proto) == 0 {
+		t.Fatal("expected messages in tasks.proto")
+	}
+
+	for name, fields := range proto {
+		goFields, ok := structs[name]
+		if !ok {
+			t.Errorf("message %s has no struct in messages.go", name)
+			continue
+		}
+		for field, number := range fields {
+			goName := ""
+			for candidate := range goFields {
+				if strings.EqualFold(candidate, strings.ReplaceAll(field, "_", "")) {
+					goName = candidate
+				}
+			}
+			if goName == "" {
+				t.Errorf("%s.%s has no struct field", name, field)
+			} else if goFields[goName] != number {
+				t.Errorf("%s.%s is field %d in tasks.proto but %s has pb:\"%d\"", name, field, number, goName, goFields[goName])
+			}
+		}
+		if len(goFields) != len(fields) {
+			t.Errorf("%s has %d fields in tasks.proto and %d in messages.go", name, len(fields), len(goFields))
+		}
+	}
+	for name := range structs {
+		if _, ok := proto[name]; !ok {
+			t.Errorf("struct %s has no message in tasks.proto", name)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
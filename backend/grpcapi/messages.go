@@ -0,0 +1,362 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// As structs abaixo espelham as mensagens de tasks.proto; a tag pb é o número
+// do campo. Ponteiros para escalares são os campos optional.
+
+type Status int32
+
+const (
+	StatusUnspecified Status = iota
+	StatusTodo
+	StatusInProgress
+	StatusDone
+)
+
+type Priority int32
+
+const (
+	PriorityUnspecified Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+type SortField int32
+
+const (
+	SortFieldUnspecified SortField = iota
+	SortFieldDueDate
+	SortFieldPriority
+	SortFieldTitle
+)
+
+type Empty struct{}
+
+type Task struct {
+	ID          string           `pb:"1"`
+	Title       string           `pb:"2"`
+	Description string           `pb:"3"`
+	Status      Status           `pb:"4"`
+	Completed   bool             `pb:"5"`
+	CompletedAt *time.Time       `pb:"6"`
+	Archived    bool             `pb:"7"`
+	ArchivedAt  *time.Time       `pb:"8"`
+	Checklist   []*ChecklistItem `pb:"9"`
+	Labels      []string         `pb:"10"`
+	Priority    Priority         `pb:"11"`
+	DueDate     *time.Time       `pb:"12"`
+	Occurrence  *Occurrence      `pb:"13"`
+	Progress    int32            `pb:"14"`
+}
+
+type ChecklistItem struct {
+	ID      string `pb:"1"`
+	Text    string `pb:"2"`
+	Checked bool   `pb:"3"`
+	Order   int32  `pb:"4"`
+}
+
+type Occurrence struct {
+	RecurringTaskID string     `pb:"1"`
+	ScheduledAt     *time.Time `pb:"2"`
+}
+
+type CreateTaskRequest struct {
+	Title       string     `pb:"1"`
+	Description string     `pb:"2"`
+	Labels      []string   `pb:"3"`
+	Priority    Priority   `pb:"4"`
+	DueDate     *time.Time `pb:"5"`
+	Checklist   []string   `pb:"6"`
+}
+
+type CreateTaskFromTemplateRequest struct {
+	TemplateID string            `pb:"1"`
+	Variables  map[string]string `pb:"2"`
+}
+
+type GetTaskRequest struct {
+	ID string `pb:"1"`
+}
+
+type ListTasksRequest struct {
+	Archived bool      `pb:"1"`
+	Label    string    `pb:"2"`
+	Sort     SortField `pb:"3"`
+	Desc     bool      `pb:"4"`
+}
+
+type ListOverdueTasksRequest struct {
+	Sort SortField `pb:"1"`
+	Desc bool      `pb:"2"`
+}
+
+type ListTasksResponse struct {
+	Tasks []*Task `pb:"1"`
+}
+
+type LabelIDs struct {
+	IDs []string `pb:"1"`
+}
+
+type UpdateTaskRequest struct {
+	ID          string     `pb:"1"`
+	Title       *string    `pb:"2"`
+	Description *string    `pb:"3"`
+	Status      *Status    `pb:"4"`
+	Labels      *LabelIDs  `pb:"5"`
+	Priority    *Priority  `pb:"6"`
+	DueDate     *time.Time `pb:"7"`
+}
+
+type DeleteTaskRequest struct {
+	ID string `pb:"1"`
+}
+
+type AddChecklistItemRequest struct {
+	TaskID string `pb:"1"`
+	Text   string `pb:"2"`
+}
+
+type UpdateChecklistItemRequest struct {
+	TaskID  string  `pb:"1"`
+	ItemID  string  `pb:"2"`
+	Text    *string `pb:"3"`
+	Checked *bool   `pb:"4"`
+}
+
+type ReorderChecklistRequest struct {
+	TaskID  string   `pb:"1"`
+	ItemIDs []string `pb:"2"`
+}
+
+type RemoveChecklistItemRequest struct {
+	TaskID string `pb:"1"`
+	ItemID string `pb:"2"`
+}
+
+type TaskDependencies struct {
+	TaskID    string  `pb:"1"`
+	BlockedBy []*Task `pb:"2"`
+	Blocks    []*Task `pb:"3"`
+}
+
+type GetDependenciesRequest struct {
+	TaskID string `pb:"1"`
+}
+
+type AddDependencyRequest struct {
+	TaskID    string `pb:"1"`
+	BlockerID string `pb:"2"`
+}
+
+type RemoveDependencyRequest struct {
+	TaskID    string `pb:"1"`
+	BlockerID string `pb:"2"`
+}
+
+type Comment struct {
+	ID        string     `pb:"1"`
+	TaskID    string     `pb:"2"`
+	Author    string     `pb:"3"`
+	Body      string     `pb:"4"`
+	CreatedAt *time.Time `pb:"5"`
+	UpdatedAt *time.Time `pb:"6"`
+}
+
+type ListCommentsRequest struct {
+	TaskID string `pb:"1"`
+}
+
+type ListCommentsResponse struct {
+	Comments []*Comment `pb:"1"`
+}
+
+type AddCommentRequest struct {
+	TaskID string `pb:"1"`
+	Author string `pb:"2"`
+	Body   string `pb:"3"`
+}
+
+type UpdateCommentRequest struct {
+	TaskID    string `pb:"1"`
+	CommentID string `pb:"2"`
+	Body      string `pb:"3"`
+}
+
+type DeleteCommentRequest struct {
+	TaskID    string `pb:"1"`
+	CommentID string `pb:"2"`
+}
+
+type Attachment struct {
+	ID          string     `pb:"1"`
+	TaskID      string     `pb:"2"`
+	FileName    string     `pb:"3"`
+	ContentType string     `pb:"4"`
+	Size        int64      `pb:"5"`
+	CreatedAt   *time.Time `pb:"6"`
+}
+
+type ListAttachmentsRequest struct {
+	TaskID string `pb:"1"`
+}
+
+type ListAttachmentsResponse struct {
+	Attachments []*Attachment `pb:"1"`
+}
+
+type AddAttachmentRequest struct {
+	TaskID   string `pb:"1"`
+	FileName string `pb:"2"`
+	Content  []byte `pb:"3"`
+}
+
+type GetAttachmentRequest struct {
+	TaskID       string `pb:"1"`
+	AttachmentID string `pb:"2"`
+}
+
+type AttachmentContent struct {
+	Attachment *Attachment `pb:"1"`
+	Content    []byte      `pb:"2"`
+}
+
+type DeleteAttachmentRequest struct {
+	TaskID       string `pb:"1"`
+	AttachmentID string `pb:"2"`
+}
+
+var (
+	statusValues = map[Status]models.Status{
+		StatusTodo:       models.StatusTodo,
+		StatusInProgress: models.StatusInProgress,
+		StatusDone:       models.StatusDone,
+	}
+	priorityValues = map[Priority]models.Priority{
+		PriorityLow:    models.PriorityLow,
+		PriorityMedium: models.PriorityMedium,
+		PriorityHigh:   models.PriorityHigh,
+		PriorityUrgent: models.PriorityUrgent,
+	}
+	sortFields = map[SortField]string{
+		SortFieldDueDate:  service.SortByDueDate,
+		SortFieldPriority: service.SortByPriority,
+		SortFieldTitle:    service.SortByTitle,
+	}
+)
+
+// modelStatus converte o enum; STATUS_UNSPECIFIED e valores fora do enum são status inválidos
+func modelStatus(status Status) (models.Status, error) {
+	value, ok := statusValues[status]
+	if !ok {
+		return "", service.ErrInvalidStatus
+	}
+	return value, nil
+}
+
+// modelPriority converte o enum; PRIORITY_UNSPECIFIED vira "", que o serviço
+// troca pela prioridade padrão na criação
+func modelPriority(priority Priority) (models.Priority, error) {
+	if priority == PriorityUnspecified {
+		return "", nil
+	}
+	value, ok := priorityValues[priority]
+	if !ok {
+		return "", service.ErrInvalidPriority
+	}
+	return value, nil
+}
+
+// sortField converte o campo de ordenação; SORT_FIELD_UNSPECIFIED não ordena
+func sortField(field SortField) (string, error) {
+	if field == SortFieldUnspecified {
+		return "", nil
+	}
+	value, ok := sortFields[field]
+	if !ok {
+		return "", service.ErrInvalidSort
+	}
+	return value, nil
+}
+
+func protoStatus(status models.Status) Status {
+	for key, value := range statusValues {
+		if value == status {
+			return key
+		}
+	}
+	return StatusUnspecified
+}
+
+func protoPriority(priority models.Priority) Priority {
+	for key, value := range priorityValues {
+		if value == priority {
+			return key
+		}
+	}
+	return PriorityUnspecified
+}
+
+// toTask converte a tarefa do domínio para a mensagem
+func toTask(task *models.Task) *Task {
+	msg := &Task{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      protoStatus(task.Status),
+		Completed:   task.Completed,
+		CompletedAt: task.CompletedAt,
+		Archived:    task.Archived,
+		ArchivedAt:  task.ArchivedAt,
+		Labels:      task.Labels,
+		Priority:    protoPriority(task.Priority),
+		DueDate:     task.DueDate,
+		Progress:    int32(task.Progress()),
+	}
+	for _, item := range task.Checklist {
+		msg.Checklist = append(msg.Checklist, &ChecklistItem{ID: item.ID, Text: item.Text, Checked: item.Checked, Order: int32(item.Order)})
+	}
+	if task.Occurrence != nil {
+		msg.Occurrence = &Occurrence{RecurringTaskID: task.Occurrence.RecurringTaskID, ScheduledAt: &task.Occurrence.ScheduledAt}
+	}
+	return msg
+}
+
+func toTasks(tasks []*models.Task) []*Task {
+	msgs := make([]*Task, len(tasks))
+	for i, task := range tasks {
+		msgs[i] = toTask(task)
+	}
+	return msgs
+}
+
+func toComment(comment *models.Comment) *Comment {
+	return &Comment{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: &comment.CreatedAt,
+		UpdatedAt: &comment.UpdatedAt,
+	}
+}
+
+func toAttachment(attachment *models.Attachment) *Attachment {
+	return &Attachment{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   &attachment.CreatedAt,
+	}
+}
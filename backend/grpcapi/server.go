@@ -0,0 +1,418 @@
+package grpcapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// ServiceName é o nome completo do serviço em tasks.proto
+const ServiceName = "kanban.v1.TaskService"
+
+// maxMessageSize limita o tamanho de cada mensagem recebida; cabe um anexo
+// do tamanho máximo mais os metadados
+const maxMessageSize = service.MaxAttachmentSize + 1<<20
+
+// method decodifica a requisição, chama o serviço e retorna a resposta
+type method func(s *Server, body []byte) (any, error)
+
+// unary adapta uma função tipada para a tabela de métodos
+func unary[Req any](fn func(s *Server, req *Req) (any, error)) method {
+	return func(s *Server, body []byte) (any, error) {
+		req := new(Req)
+		if err := unmarshal(body, req); err != nil {
+			return nil, statusErrorf(CodeInternal, "grpc: failed to unmarshal the request: %v", err)
+		}
+		return fn(s, req)
+	}
+}
+
+// methods lista as RPCs de TaskService pelo nome
+var methods = map[string]method{
+	"CreateTask":             unary((*Server).createTask),
+	"CreateTaskFromTemplate": unary((*Server).createTaskFromTemplate),
+	"GetTask":                unary((*Server).getTask),
+	"ListTasks":              unary((*Server).listTasks),
+	"ListOverdueTasks":       unary((*Server).listOverdueTasks),
+	"UpdateTask":             unary((*Server).updateTask),
+	"DeleteTask":             unary((*Server).deleteTask),
+	"AddChecklistItem":       unary((*Server).addChecklistItem),
+	"UpdateChecklistItem":    unary((*Server).updateChecklistItem),
+	"ReorderChecklist":       unary((*Server).reorderChecklist),
+	"RemoveChecklistItem":    unary((*Server).removeChecklistItem),
+	"GetDependencies":        unary((*Server).getDependencies),
+	"AddDependency":          unary((*Server).addDependency),
+	"RemoveDependency":       unary((*Server).removeDependency),
+	"ListComments":           unary((*Server).listComments),
+	"AddComment":             unary((*Server).addComment),
+	"UpdateComment":          unary((*Server).updateComment),
+	"DeleteComment":          unary((*Server).deleteComment),
+	"ListAttachments":        unary((*Server).listAttachments),
+	"AddAttachment":          unary((*Server).addAttachment),
+	"GetAttachment":          unary((*Server).getAttachment),
+	"DeleteAttachment":       unary((*Server).deleteAttachment),
+}
+
+// Server implementa kanban.v1.TaskService sobre o TaskService, falando o
+// protocolo gRPC (HTTP/2, mensagens protobuf com prefixo de tamanho e status
+// nos trailers) apenas com a biblioteca padrão
+type Server struct {
+	tasks *service.TaskService
+}
+
+// NewServer cria o servidor gRPC de tarefas
+func NewServer(tasks *service.TaskService) *Server {
+	return &Server{tasks: tasks}
+}
+
+// ListenAndServe atende o gRPC em addr usando HTTP/2 sem TLS (h2c), como os
+// clientes gRPC esperam com credenciais "insecure"
+func (s *Server) ListenAndServe(addr string) error {
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{Addr: addr, Handler: s, Protocols: &protocols}
+	return server.ListenAndServe()
+}
+
+// ServeHTTP atende POST /kanban.v1.TaskService/{Método}
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "gRPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "application/grpc" && contentType != "application/grpc+proto" {
+		http.Error(w, "unsupported content type "+contentType, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Grpc-Accept-Encoding", "identity")
+
+	serviceName, methodName, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	handle, ok := methods[methodName]
+	if serviceName != ServiceName || !ok {
+		writeStatus(w, statusErrorf(CodeUnimplemented, "unknown method %s", r.URL.Path), false)
+		return
+	}
+	body, err := readMessage(r.Body)
+	if err != nil {
+		writeStatus(w, toStatus(err), false)
+		return
+	}
+
+	resp, err := handle(s, body)
+	if err != nil {
+		writeStatus(w, toStatus(err), false)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(frame(marshal(resp)))
+	writeStatus(w, nil, true)
+}
+
+// readMessage lê uma mensagem: 1 byte de compressão,
+// 4 bytes de tamanho (big endian) e o conteúdo
+func readMessage(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, statusErrorf(CodeInternal, "grpc: failed to read the message header: %v", err)
+	}
+	if header[0] != 0 {
+		return nil, statusErrorf(CodeUnimplemented, "grpc: compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxMessageSize {
+		return nil, statusErrorf(CodeResourceExhausted, "grpc: received message larger than max (%d vs. %d)", size, maxMessageSize)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, statusErrorf(CodeInternal, "grpc: failed to read the message: %v", err)
+	}
+	return body, nil
+}
+
+// frame prefixa a mensagem com a flag de compressão e o tamanho
+func frame(message []byte) []byte {
+	b := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(b[1:], uint32(len(message)))
+	return append(b, message...)
+}
+
+// writeStatus envia grpc-status e grpc-message. Depois do corpo eles vão nos
+// trailers; sem corpo a resposta é "trailers-only" e vão nos headers.
+func writeStatus(w http.ResponseWriter, status *StatusError, afterBody bool) {
+	if status == nil {
+		status = &StatusError{Code: CodeOK}
+	}
+	prefix := ""
+	if afterBody {
+		prefix = http.TrailerPrefix
+	}
+	w.Header().Set(prefix+"Grpc-Status", strconv.Itoa(int(status.Code)))
+	if status.Message != "" {
+		w.Header().Set(prefix+"Grpc-Message", encodeGrpcMessage(status.Message))
+	}
+	if !afterBody {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *Server) createTask(req *CreateTaskRequest) (any, error) {
+	priority, err := modelPriority(req.Priority)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.tasks.CreateTask(models.CreateTaskRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		Labels:      req.Labels,
+		Priority:    priority,
+		DueDate:     req.DueDate,
+		Checklist:   req.Checklist,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) createTaskFromTemplate(req *CreateTaskFromTemplateRequest) (any, error) {
+	task, err := s.tasks.CreateTaskFromTemplate(req.TemplateID, models.InstantiateTemplateRequest{Variables: req.Variables})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) getTask(req *GetTaskRequest) (any, error) {
+	task, err := s.tasks.GetTaskByID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+// listTasks segue GET /tasks: arquivadas, por etiqueta ou todas as ativas
+func (s *Server) listTasks(req *ListTasksRequest) (any, error) {
+	var tasks []*models.Task
+	var err error
+	switch {
+	case req.Archived:
+		tasks, err = s.tasks.GetArchivedTasks()
+	case req.Label != "":
+		tasks, err = s.tasks.GetTasksByLabel(req.Label)
+	default:
+		tasks, err = s.tasks.GetAllTasks()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sortedTasks(tasks, req.Sort, req.Desc)
+}
+
+func (s *Server) listOverdueTasks(req *ListOverdueTasksRequest) (any, error) {
+	tasks, err := s.tasks.GetOverdueTasks()
+	if err != nil {
+		return nil, err
+	}
+	return sortedTasks(tasks, req.Sort, req.Desc)
+}
+
+// sortedTasks ordena a lista como ?sort= e ?order= da API REST
+func sortedTasks(tasks []*models.Task, sort SortField, desc bool) (*ListTasksResponse, error) {
+	field, err := sortField(sort)
+	if err != nil {
+		return nil, err
+	}
+	if field != "" {
+		if err := service.SortTasks(tasks, field, desc); err != nil {
+			return nil, err
+		}
+	}
+	return &ListTasksResponse{Tasks: toTasks(tasks)}, nil
+}
+
+func (s *Server) updateTask(req *UpdateTaskRequest) (any, error) {
+	update := models.UpdateTaskRequest{
+		Title:       req.Title,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+	}
+	if req.Status != nil {
+		status, err := modelStatus(*req.Status)
+		if err != nil {
+			return nil, err
+		}
+		update.Status = &status
+	}
+	if req.Priority != nil {
+		priority, err := modelPriority(*req.Priority)
+		if err != nil {
+			return nil, err
+		}
+		update.Priority = &priority
+	}
+	if req.Labels != nil {
+		labels := req.Labels.IDs
+		if labels == nil {
+			labels = []string{}
+		}
+		update.Labels = &labels
+	}
+
+	task, err := s.tasks.UpdateTask(req.ID, update)
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) deleteTask(req *DeleteTaskRequest) (any, error) {
+	if err := s.tasks.DeleteTask(req.ID); err != nil {
+		return nil, err
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) addChecklistItem(req *AddChecklistItemRequest) (any, error) {
+	task, err := s.tasks.AddChecklistItem(req.TaskID, models.AddChecklistItemRequest{Text: req.Text})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) updateChecklistItem(req *UpdateChecklistItemRequest) (any, error) {
+	task, err := s.tasks.UpdateChecklistItem(req.TaskID, req.ItemID, models.UpdateChecklistItemRequest{Text: req.Text, Checked: req.Checked})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) reorderChecklist(req *ReorderChecklistRequest) (any, error) {
+	task, err := s.tasks.ReorderChecklist(req.TaskID, models.ReorderChecklistRequest{ItemIDs: req.ItemIDs})
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) removeChecklistItem(req *RemoveChecklistItemRequest) (any, error) {
+	task, err := s.tasks.RemoveChecklistItem(req.TaskID, req.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *Server) getDependencies(req *GetDependenciesRequest) (any, error) {
+	deps, err := s.tasks.GetDependencies(req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	return toDependencies(deps), nil
+}
+
+func (s *Server) addDependency(req *AddDependencyRequest) (any, error) {
+	deps, err := s.tasks.AddDependency(req.TaskID, models.AddDependencyRequest{BlockerID: req.BlockerID})
+	if err != nil {
+		return nil, err
+	}
+	return toDependencies(deps), nil
+}
+
+func (s *Server) removeDependency(req *RemoveDependencyRequest) (any, error) {
+	if err := s.tasks.RemoveDependency(req.TaskID, req.BlockerID); err != nil {
+		return nil, err
+	}
+	return &Empty{}, nil
+}
+
+func toDependencies(deps *models.TaskDependencies) *TaskDependencies {
+	return &TaskDependencies{TaskID: deps.TaskID, BlockedBy: toTasks(deps.BlockedBy), Blocks: toTasks(deps.Blocks)}
+}
+
+func (s *Server) listComments(req *ListCommentsRequest) (any, error) {
+	comments, err := s.tasks.GetComments(req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListCommentsResponse{}
+	for _, comment := range comments {
+		resp.Comments = append(resp.Comments, toComment(comment))
+	}
+	return resp, nil
+}
+
+func (s *Server) addComment(req *AddCommentRequest) (any, error) {
+	comment, err := s.tasks.AddComment(req.TaskID, models.CreateCommentRequest{Author: req.Author, Body: req.Body})
+	if err != nil {
+		return nil, err
+	}
+	return toComment(comment), nil
+}
+
+func (s *Server) updateComment(req *UpdateCommentRequest) (any, error) {
+	comment, err := s.tasks.UpdateComment(req.TaskID, req.CommentID, models.UpdateCommentRequest{Body: req.Body})
+	if err != nil {
+		return nil, err
+	}
+	return toComment(comment), nil
+}
+
+func (s *Server) deleteComment(req *DeleteCommentRequest) (any, error) {
+	if err := s.tasks.DeleteComment(req.TaskID, req.CommentID); err != nil {
+		return nil, err
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) listAttachments(req *ListAttachmentsRequest) (any, error) {
+	attachments, err := s.tasks.GetAttachments(req.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ListAttachmentsResponse{}
+	for _, attachment := range attachments {
+		resp.Attachments = append(resp.Attachments, toAttachment(attachment))
+	}
+	return resp, nil
+}
+
+func (s *Server) addAttachment(req *AddAttachmentRequest) (any, error) {
+	attachment, err := s.tasks.AddAttachment(req.TaskID, req.FileName, bytes.NewReader(req.Content))
+	if err != nil {
+		return nil, err
+	}
+	return toAttachment(attachment), nil
+}
+
+func (s *Server) getAttachment(req *GetAttachmentRequest) (any, error) {
+	attachment, blob, err := s.tasks.OpenAttachment(req.TaskID, req.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	content, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("read attachment %s: %w", attachment.ID, err)
+	}
+	return &AttachmentContent{Attachment: toAttachment(attachment), Content: content}, nil
+}
+
+func (s *Server) deleteAttachment(req *DeleteAttachmentRequest) (any, error) {
+	if err := s.tasks.DeleteAttachment(req.TaskID, req.AttachmentID); err != nil {
+		return nil, err
+	}
+	return &Empty{}, nil
+}
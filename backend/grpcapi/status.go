@@ -0,0 +1,113 @@
+package grpcapi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// Code é um código de status do gRPC (google.rpc.Code)
+type Code int
+
+const (
+	CodeOK                 Code = 0
+	CodeInvalidArgument    Code = 3
+	CodeNotFound           Code = 5
+	CodeResourceExhausted  Code = 8
+	CodeFailedPrecondition Code = 9
+	CodeUnimplemented      Code = 12
+	CodeInternal           Code = 13
+	CodeUnauthenticated    Code = 16
+)
+
+// StatusError é o erro devolvido ao cliente no trailer grpc-status/grpc-message
+type StatusError struct {
+	Code    Code
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("grpc: code = %d desc = %s", e.Code, e.Message)
+}
+
+// statusErrorf cria um StatusError com a mensagem formatada
+func statusErrorf(code Code, format string, args ...any) *StatusError {
+	return &StatusError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// kindCodes é o código do gRPC de cada classe de erro do domínio, a mesma
+// classificação que define o status dos handlers REST
+var kindCodes = map[service.ErrorKind]Code{
+	service.KindInvalid:       CodeInvalidArgument,
+	service.KindNotFound:      CodeNotFound,
+	service.KindConflict:      CodeFailedPrecondition,
+	service.KindTooLarge:      CodeResourceExhausted,
+	service.KindUnprocessable: CodeFailedPrecondition,
+	service.KindUnauthorized:  CodeUnauthenticated,
+}
+
+// toStatus converte o erro do serviço; erros de vários campos são sempre
+// INVALID_ARGUMENT, assim como um recurso inexistente citado num campo, ex:
+// uma etiqueta. Erros inesperados são registrados no log e respondidos como
+// INTERNAL sem detalhes.
+func toStatus(err error) *StatusError {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr
+	}
+	var fieldErrs service.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		return &StatusError{Code: CodeInvalidArgument, Message: err.Error()}
+	}
+	kind, _ := service.ClassifyError(err)
+	code, ok := kindCodes[kind]
+	if !ok {
+		log.Printf("grpc: %v", err)
+		return &StatusError{Code: CodeInternal, Message: "internal server error"}
+	}
+	var fieldErr *service.FieldError
+	if code == CodeNotFound && errors.As(err, &fieldErr) {
+		code = CodeInvalidArgument
+	}
+	return &StatusError{Code: code, Message: err.Error()}
+}
+
+// encodeGrpcMessage aplica o percent-encoding exigido em grpc-message
+func encodeGrpcMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// decodeGrpcMessage desfaz o percent-encoding de grpc-message
+func decodeGrpcMessage(message string) string {
+	decoded, err := url.PathUnescape(message)
+	if err != nil {
+		return message
+	}
+	return decoded
+}
+
+// parseStatus lê os trailers grpc-status e grpc-message
+func parseStatus(code, message string) *StatusError {
+	number, err := strconv.Atoi(code)
+	if err != nil {
+		return statusErrorf(CodeInternal, "invalid grpc-status %q", code)
+	}
+	if Code(number) == CodeOK {
+		return nil
+	}
+	return &StatusError{Code: Code(number), Message: decodeGrpcMessage(message)}
+}
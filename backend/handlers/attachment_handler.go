@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// multipartOverhead cobre boundaries e headers das partes no limite do corpo
+const multipartOverhead = 1 << 20
+
+// handleUploadAttachment processa uploads multipart (campo "file") sem
+// carregar o arquivo inteiro em memória
+func (h *TaskHandler) handleUploadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxAttachmentSize+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeError(w, errInvalidRequestBody)
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			writeError(w, errFileFieldRequired)
+			return
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.service.AddAttachment(taskID, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(attachment)
+		return
+	}
+}
+
+// handleGetAttachments processa requisições GET para listar os anexos da tarefa
+func (h *TaskHandler) handleGetAttachments(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	attachments, err := h.service.GetAttachments(taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(attachments)
+}
+
+// handleDownloadAttachment envia o conteúdo do anexo em streaming, com
+// suporte a requisições parciais (Range) e condicionais via http.ServeContent
+func (h *TaskHandler) handleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	attachmentID := r.PathValue("attachmentId")
+
+	attachment, blob, err := h.service.OpenAttachment(taskID, attachmentID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, attachment.FileName, attachment.CreatedAt, blob)
+}
+
+// handleDeleteAttachment processa requisições DELETE para remover um anexo
+func (h *TaskHandler) handleDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	attachmentID := r.PathValue("attachmentId")
+
+	if err := h.service.DeleteAttachment(taskID, attachmentID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/calendar"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+const (
+	pathCalendarFeed   = "/calendar.ics"
+	pathCalendarTokens = "/calendar/tokens"
+)
+
+type CalendarHandler struct {
+	service *service.CalendarService
+	router  *router
+}
+
+// NewCalendarHandler cria uma nova instância do handler do feed de calendário
+func NewCalendarHandler(service *service.CalendarService) *CalendarHandler {
+	h := &CalendarHandler{
+		service: service,
+	}
+	h.router = newRouter([]route{
+		{"GET " + pathCalendarFeed, h.handleFeed},
+		{"GET " + pathCalendarTokens, h.handleGetTokens},
+		{"POST " + pathCalendarTokens, h.handleCreateToken},
+		{"DELETE " + pathCalendarTokens + "/{id}", h.handleRevokeToken},
+	})
+	return h
+}
+
+// ServeHTTP atende /calendar.ics e /calendar/tokens
+func (h *CalendarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleFeed processa GET /calendar.ics?token=...&component=event|todo. O token
+// também pode vir no header Authorization: Bearer, para clientes que o suportam.
+func (h *CalendarHandler) handleFeed(w http.ResponseWriter, r *http.Request) {
+	value := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && value == "" {
+		value = bearer
+	}
+	token, err := h.service.Authenticate(value)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	component := calendar.ComponentEvent
+	switch r.URL.Query().Get("component") {
+	case "", "event":
+	case "todo":
+		component = calendar.ComponentTodo
+	default:
+		writeError(w, calendar.ErrUnsupportedComponent)
+		return
+	}
+
+	tasks, err := h.service.GetCalendarTasks()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf, calendar.Feed{
+		Name:        "Kanban (" + token.User + ")",
+		Component:   component,
+		GeneratedAt: time.Now(),
+		Tasks:       tasks,
+	}); err != nil {
+		log.Printf("calendar: %v", err)
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", calendar.ContentType)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	buf.WriteTo(w)
+}
+
+// handleCreateToken processa POST /calendar/tokens para emitir um token de feed
+func (h *CalendarHandler) handleCreateToken(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateFeedTokenRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	token, err := h.service.CreateFeedToken(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(token)
+}
+
+// handleGetTokens processa GET /calendar/tokens
+func (h *CalendarHandler) handleGetTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.service.GetFeedTokens()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// handleRevokeToken processa DELETE /calendar/tokens/{id}
+func (h *CalendarHandler) handleRevokeToken(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.service.RevokeFeedToken(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
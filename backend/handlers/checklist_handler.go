@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// handleAddChecklistItem processa requisições POST para adicionar um item ao checklist
+func (h *TaskHandler) handleAddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	var req models.AddChecklistItemRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	task, err := h.service.AddChecklistItem(taskID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeTask(w, http.StatusCreated, task)
+}
+
+// handleUpdateChecklistItem processa requisições PUT para editar ou marcar um item
+func (h *TaskHandler) handleUpdateChecklistItem(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	itemID := r.PathValue("itemId")
+
+	var req models.UpdateChecklistItemRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	task, err := h.service.UpdateChecklistItem(taskID, itemID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeTask(w, http.StatusOK, task)
+}
+
+// handleReorderChecklist processa requisições PUT para reordenar o checklist
+func (h *TaskHandler) handleReorderChecklist(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	var req models.ReorderChecklistRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	task, err := h.service.ReorderChecklist(taskID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeTask(w, http.StatusOK, task)
+}
+
+// handleRemoveChecklistItem processa requisições DELETE para remover um item
+func (h *TaskHandler) handleRemoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	itemID := r.PathValue("itemId")
+
+	task, err := h.service.RemoveChecklistItem(taskID, itemID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeTask(w, http.StatusOK, task)
+}
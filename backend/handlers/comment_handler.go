@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// handleGetComments processa requisições GET para listar os comentários da tarefa
+func (h *TaskHandler) handleGetComments(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	comments, err := h.service.GetComments(taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(comments)
+}
+
+// handleAddComment processa requisições POST para comentar em uma tarefa
+func (h *TaskHandler) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	var req models.CreateCommentRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	comment, err := h.service.AddComment(taskID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// handleUpdateComment processa requisições PUT para editar um comentário
+func (h *TaskHandler) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	commentID := r.PathValue("commentId")
+
+	var req models.UpdateCommentRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	comment, err := h.service.UpdateComment(taskID, commentID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(comment)
+}
+
+// handleDeleteComment processa requisições DELETE para remover um comentário
+func (h *TaskHandler) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	commentID := r.PathValue("commentId")
+
+	if err := h.service.DeleteComment(taskID, commentID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
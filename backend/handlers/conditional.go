@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+// revisionETag formata a revisão do quadro como ETag fraco, válido para
+// qualquer content-coding da mesma resposta
+func revisionETag(rev repository.Revision) string {
+	return `W/"` + strconv.FormatInt(rev.Epoch, 36) + "-" + strconv.FormatUint(rev.Number, 10) + `"`
+}
+
+// checkNotModified escreve ETag, Last-Modified e Cache-Control da revisão e,
+// se a cópia do cliente ainda vale, responde 304 e retorna true. Como em
+// RFC 9110, If-None-Match tem precedência e If-Modified-Since só é
+// considerado na sua ausência.
+//
+// A revisão deve ser lida antes dos dados: se uma escrita acontecer no meio,
+// a resposta leva dados novos com o ETag antigo e a próxima requisição
+// baixa tudo de novo, em vez de guardar dados antigos sob o ETag novo.
+func checkNotModified(w http.ResponseWriter, r *http.Request, rev repository.Revision) bool {
+	return checkNotModifiedAt(w, r, rev, time.Now())
+}
+
+// checkNotModifiedAt é checkNotModified com o instante atual explícito.
+// Last-Modified tem resolução de segundos: enquanto a última escrita tiver
+// menos de um segundo, outra escrita ainda pode cair no mesmo segundo, então
+// Last-Modified não é enviado e If-Modified-Since não vale; só o ETag
+// distingue as duas versões.
+func checkNotModifiedAt(w http.ResponseWriter, r *http.Request, rev repository.Revision, now time.Time) bool {
+	etag := revisionETag(rev)
+	modified := rev.UpdatedAt.UTC().Truncate(time.Second)
+	settled := now.Sub(rev.UpdatedAt) >= time.Second
+	header := w.Header()
+	header.Set("ETag", etag)
+	if settled {
+		header.Set("Last-Modified", modified.Format(http.TimeFormat))
+	}
+	// O cliente pode guardar a resposta, mas revalida antes de cada uso
+	header.Set("Cache-Control", "no-cache")
+
+	var fresh bool
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		fresh = etagMatches(match, etag)
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && settled {
+		fresh = !modified.After(since)
+	}
+	if !fresh {
+		return false
+	}
+
+	header.Del("Content-Type")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches aplica a comparação fraca entre a lista de If-None-Match e o ETag
+func etagMatches(header, etag string) bool {
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
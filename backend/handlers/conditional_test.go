@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+func TestCheckNotModifiedWaitsForLastModifiedToSettle(t *testing.T) {
+	written := time.Date(2025, 1, 1, 12, 0, 0, 300_000_000, time.UTC)
+	rev := repository.Revision{Epoch: 1, Number: 1, UpdatedAt: written}
+	check := func(now time.Time, since string) (*httptest.ResponseRecorder, bool) {
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		if since != "" {
+			req.Header.Set("If-Modified-Since", since)
+		}
+		rec := httptest.NewRecorder()
+		return rec, checkNotModifiedAt(rec, req, rev, now)
+	}
+
+	// Uma segunda escrita ainda pode cair no mesmo segundo
+	rec, fresh := check(written.Add(500*time.Millisecond), "")
+	if got := rec.Header().Get("Last-Modified"); got != "" || rec.Header().Get("ETag") == "" {
+		t.Errorf("expected only an ETag right after a write, got Last-Modified %q", got)
+	}
+	if fresh {
+		t.Error("expected a request without validators to be answered in full")
+	}
+	sameSecond := written.Truncate(time.Second).Format(http.TimeFormat)
+	if _, fresh := check(written.Add(500*time.Millisecond), sameSecond); fresh {
+		t.Error("expected If-Modified-Since to be ignored within a second of the last write")
+	}
+
+	rec, _ = check(written.Add(time.Second), "")
+	if got := rec.Header().Get("Last-Modified"); got != sameSecond {
+		t.Errorf("expected Last-Modified %q once settled, got %q", sameSecond, got)
+	}
+	if rec, fresh := check(written.Add(time.Second), sameSecond); !fresh || rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for a settled revision, got %d", rec.Code)
+	}
+	if _, fresh := check(written.Add(time.Second), "Mon, 01 Jan 2001 00:00:00 GMT"); fresh {
+		t.Error("expected an older If-Modified-Since to get the full response")
+	}
+}
+
+func TestConditionalGet(t *testing.T) {
+	handler := newTaskHandler()
+	send := func(method, target string, header http.Header, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	created := send(http.MethodPost, "/tasks", nil, `{"title":"Poll"}`)
+	var task models.Task
+	json.Unmarshal(created.Body.Bytes(), &task)
+
+	// Logo após uma escrita, Last-Modified ainda não é confiável e só o ETag vale
+	first := send(http.MethodGet, "/tasks", nil, "")
+	etag, lastModified := first.Header().Get("ETag"), first.Header().Get("Last-Modified")
+	if !strings.HasPrefix(etag, `W/"`) || lastModified != "" {
+		t.Fatalf("expected only a weak ETag, got %q and Last-Modified %q", etag, lastModified)
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := first.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("expected Cache-Control no-cache, got %q", got)
+	}
+	if got := send(http.MethodGet, "/tasks/"+task.ID, nil, "").Header().Get("ETag"); got != etag {
+		t.Errorf("expected the task to share the board ETag %q, got %q", etag, got)
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   int
+	}{
+		{"matching ETag", http.Header{"If-None-Match": {etag}}, http.StatusNotModified},
+		{"ETag in a list", http.Header{"If-None-Match": {`W/"other", ` + etag}}, http.StatusNotModified},
+		{"strong form of the ETag", http.Header{"If-None-Match": {strings.TrimPrefix(etag, "W/")}}, http.StatusNotModified},
+		{"any ETag", http.Header{"If-None-Match": {"*"}}, http.StatusNotModified},
+		{"other ETag", http.Header{"If-None-Match": {`W/"other"`}}, http.StatusOK},
+		{"recent write ignores If-Modified-Since", http.Header{"If-Modified-Since": {future}}, http.StatusOK},
+		{"If-None-Match takes precedence", http.Header{"If-None-Match": {etag}, "If-Modified-Since": {"Mon, 01 Jan 2001 00:00:00 GMT"}}, http.StatusNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := send(http.MethodGet, "/tasks", tt.header, "")
+			if rec.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, rec.Code)
+			}
+			if rec.Code == http.StatusNotModified && (rec.Body.Len() > 0 || rec.Header().Get("Content-Type") != "") {
+				t.Errorf("expected 304 without body or Content-Type, got %q and %q", rec.Body, rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+
+	// Qualquer escrita no quadro invalida o ETag
+	send(http.MethodPut, "/tasks/"+task.ID, nil, `{"title":"Poll","status":"done"}`)
+	if rec := send(http.MethodGet, "/tasks", http.Header{"If-None-Match": {etag}}, ""); rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 after a write, got %d", rec.Code)
+	}
+	send(http.MethodDelete, "/tasks/"+task.ID, nil, "")
+	missing := send(http.MethodGet, "/tasks/"+task.ID, http.Header{"If-None-Match": {"*"}}, "")
+	if missing.Code != http.StatusNotFound || missing.Header().Get("ETag") != "" {
+		t.Errorf("expected 404 without ETag for a deleted task, got %d %q", missing.Code, missing.Header().Get("ETag"))
+	}
+}
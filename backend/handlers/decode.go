@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// maxJSONBodySize limita o corpo JSON das requisições
+const maxJSONBodySize = 1 << 20
+
+var (
+	// errEmptyBody indica uma requisição sem corpo; é um errInvalidRequestBody
+	errEmptyBody        = fmt.Errorf("%w: body is empty", errInvalidRequestBody)
+	errUnknownField     = errors.New("unknown field")
+	errInvalidFieldType = errors.New("invalid field type")
+)
+
+// decodeJSON lê o corpo da requisição em v de forma estrita: no máximo
+// maxJSONBodySize bytes, UTF-8 válido, um único valor JSON e nenhum campo
+// desconhecido. Campos desconhecidos ou com tipo errado viram
+// service.FieldError, respondidos com o campo em "errors".
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONBodySize))
+	if err != nil {
+		// Um *http.MaxBytesError envolvido ainda responde 413
+		return fmt.Errorf("%w: %w", errInvalidRequestBody, err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errEmptyBody
+	}
+	// O decoder troca sequências inválidas por U+FFFD sem reclamar
+	if !utf8.Valid(body) {
+		return fmt.Errorf("%w: %w", errInvalidRequestBody, service.ErrInvalidUTF8)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected data after JSON value", errInvalidRequestBody)
+	}
+	return nil
+}
+
+// decodeError converte os erros de encoding/json
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		field := fieldPath(typeErr.Field)
+		return &service.FieldError{
+			Field: field,
+			Err:   fmt.Errorf("%w: %s cannot be a JSON %s", errInvalidFieldType, field, typeErr.Value),
+		}
+	}
+	// encoding/json não tem um tipo para campos desconhecidos
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		field := strings.Trim(name, `"`)
+		return &service.FieldError{Field: field, Err: fmt.Errorf("%w %q", errUnknownField, field)}
+	}
+	return fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+}
+
+// fieldPath converte o caminho de encoding/json ("checklist.0.text") para o
+// formato dos erros do serviço ("checklist[0].text")
+func fieldPath(path string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(path, ".") {
+		switch {
+		case part != "" && strings.Trim(part, "0123456789") == "":
+			b.WriteString("[" + part + "]")
+		case i > 0:
+			b.WriteString("." + part)
+		default:
+			b.WriteString(part)
+		}
+	}
+	return b.String()
+}
+
+// replaceTaskBody é o corpo do PUT /tasks/{id}. Aceita a tarefa como
+// retornada pelo GET: os campos somente leitura são lidos e descartados, para
+// que apenas campos realmente desconhecidos sejam rejeitados.
+type replaceTaskBody struct {
+	models.ReplaceTaskRequest
+	Checklist []replaceChecklistItemBody `json:"checklist"`
+
+	ID          json.RawMessage `json:"id"`
+	Completed   json.RawMessage `json:"completed"`
+	CompletedAt json.RawMessage `json:"completedAt"`
+	Archived    json.RawMessage `json:"archived"`
+	ArchivedAt  json.RawMessage `json:"archivedAt"`
+	Occurrence  json.RawMessage `json:"occurrence"`
+	Progress    json.RawMessage `json:"progress"`
+}
+
+// replaceChecklistItemBody é um item do checklist do PUT, que pode trazer a
+// posição (order) retornada pelo GET
+type replaceChecklistItemBody struct {
+	models.ChecklistItemInput
+	Order json.RawMessage `json:"order"`
+}
+
+// request descarta os campos somente leitura
+func (b replaceTaskBody) request() models.ReplaceTaskRequest {
+	req := b.ReplaceTaskRequest
+	if b.Checklist != nil {
+		req.Checklist = make([]models.ChecklistItemInput, len(b.Checklist))
+		for i, item := range b.Checklist {
+			req.Checklist[i] = item.ChecklistItemInput
+		}
+	}
+	return req
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// handleGetDependencies processa requisições GET para listar bloqueios da tarefa
+func (h *TaskHandler) handleGetDependencies(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	deps, err := h.service.GetDependencies(taskID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.mapper.Dependencies(deps))
+}
+
+// handleAddDependency processa requisições POST para marcar a tarefa como bloqueada por outra
+func (h *TaskHandler) handleAddDependency(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+
+	var req models.AddDependencyRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	deps, err := h.service.AddDependency(taskID, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(h.mapper.Dependencies(deps))
+}
+
+// handleRemoveDependency processa requisições DELETE para remover um bloqueio
+func (h *TaskHandler) handleRemoveDependency(w http.ResponseWriter, r *http.Request) {
+	taskID := r.PathValue("id")
+	blockerID := r.PathValue("blockerId")
+
+	if err := h.service.RemoveDependency(taskID, blockerID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
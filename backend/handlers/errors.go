@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/problem"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// Erros das próprias requisições HTTP, respondidos pela mesma tabela dos
+// erros do serviço
+var (
+	errInvalidRequestBody = errors.New("invalid request body")
+	errMethodNotAllowed   = errors.New("method not allowed")
+	errResourceNotFound   = errors.New("resource not found")
+	errUnsupportedPatch   = errors.New("unsupported patch format")
+	errFileFieldRequired  = errors.New(`multipart field "file" is required`)
+	errInvalidSince       = errors.New("since must be a date (2006-01-02) or RFC 3339 timestamp")
+	errInvalidImportFile  = errors.New("invalid import file")
+	errRequestTooLarge    = errors.New("request body exceeds the maximum size")
+)
+
+// errorMapping associa um erro ao status HTTP e ao código estável exposto
+// em "code"; erros envolvidos com %w são reconhecidos por errors.Is
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorTable guarda os erros da própria camada HTTP. Os erros do domínio vêm
+// de service.ClassifyError, com o status derivado da classe em kindStatus;
+// ambos valem também para os códigos do GraphQL.
+var errorTable = []errorMapping{
+	{errInvalidRequestBody, http.StatusBadRequest, problem.CodeInvalidRequestBody},
+	{errMethodNotAllowed, http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed},
+	{errResourceNotFound, http.StatusNotFound, problem.CodeResourceNotFound},
+	{errUnsupportedPatch, http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType},
+	{errFileFieldRequired, http.StatusBadRequest, "file_required"},
+	{errInvalidSince, http.StatusBadRequest, "invalid_since"},
+	{errRequestTooLarge, http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge},
+	{errUnknownField, http.StatusBadRequest, problem.CodeUnknownField},
+	{errInvalidFieldType, http.StatusBadRequest, "invalid_field_type"},
+	{errInvalidImportFile, http.StatusBadRequest, "invalid_import_file"},
+}
+
+// kindStatus é o status HTTP de cada classe de erro do domínio
+var kindStatus = map[service.ErrorKind]int{
+	service.KindInvalid:       http.StatusBadRequest,
+	service.KindNotFound:      http.StatusNotFound,
+	service.KindConflict:      http.StatusConflict,
+	service.KindTooLarge:      http.StatusRequestEntityTooLarge,
+	service.KindUnprocessable: http.StatusUnprocessableEntity,
+	service.KindUnauthorized:  http.StatusUnauthorized,
+}
+
+// lookupError procura o erro na tabela e depois entre os do domínio, para que
+// um erro da requisição, ex: errInvalidRequestBody, prevaleça sobre o erro do
+// serviço que ele envolve
+func lookupError(err error) (errorMapping, bool) {
+	for _, mapping := range errorTable {
+		if errors.Is(err, mapping.err) {
+			return mapping, true
+		}
+	}
+	if kind, code := service.ClassifyError(err); kind != service.KindInternal {
+		return errorMapping{status: kindStatus[kind], code: code}, true
+	}
+	return errorMapping{}, false
+}
+
+// toProblem converte o erro segundo a tabela. Erros de campo
+// (service.FieldError) indicam o campo em "errors"; um recurso inexistente
+// citado no corpo, como uma etiqueta, é um erro da requisição (400) e não
+// 404. Vários erros de campo (service.ValidationErrors) respondem 400
+// validation_failed com um item por campo. Corpos acima do limite de
+// http.MaxBytesReader respondem 413 e erros fora da tabela são registrados no
+// log e respondidos como 500 sem detalhes.
+func toProblem(err error) *problem.Problem {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = errRequestTooLarge
+	}
+
+	var fieldErrs service.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		p := problem.New(http.StatusBadRequest, problem.CodeValidationFailed, err.Error())
+		for _, fieldErr := range fieldErrs {
+			code := problem.CodeValidationFailed
+			if mapping, ok := lookupError(fieldErr); ok {
+				code = mapping.code
+			}
+			p.Errors = append(p.Errors, problem.FieldError{Field: fieldErr.Field, Code: code, Message: fieldErr.Error()})
+		}
+		return p
+	}
+
+	mapping, ok := lookupError(err)
+	if !ok {
+		log.Printf("handlers: %v", err)
+		return problem.New(http.StatusInternalServerError, problem.CodeInternalError, "internal server error")
+	}
+
+	var fieldErr *service.FieldError
+	if !errors.As(err, &fieldErr) {
+		return problem.New(mapping.status, mapping.code, err.Error())
+	}
+	status := mapping.status
+	if status == http.StatusNotFound {
+		status = http.StatusBadRequest
+	}
+	p := problem.New(status, mapping.code, err.Error())
+	p.Errors = []problem.FieldError{{Field: fieldErr.Field, Code: mapping.code, Message: err.Error()}}
+	return p
+}
+
+// writeError escreve o erro como application/problem+json. Os validadores
+// de cache já escritos pelo handler valem só para a resposta de sucesso.
+func writeError(w http.ResponseWriter, err error) {
+	for _, name := range []string{"ETag", "Last-Modified", "Cache-Control"} {
+		w.Header().Del(name)
+	}
+	problem.Write(w, toProblem(err))
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/exchange"
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// maxImportSize limita o tamanho do arquivo enviado para importação
+const maxImportSize = 10 << 20
+
+// handleExport processa GET /tasks/export?format=csv|json, enviando todas as
+// tarefas (inclusive arquivadas) em streaming
+func (h *TaskHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	format := exportFormat(r)
+	if format != exchange.FormatCSV && format != exchange.FormatJSON {
+		writeError(w, exchange.ErrUnsupportedFormat)
+		return
+	}
+
+	tasks, err := h.service.ExportTasks()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", exchange.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="tasks.`+format+`"`)
+	w.WriteHeader(http.StatusOK)
+	// Depois que o corpo começou a ser enviado não há como trocar o status
+	if err := exchange.Export(w, format, tasks); err != nil {
+		log.Printf("export: %v", err)
+	}
+}
+
+// handleImport processa POST /tasks/import?format=csv|json|trello|github&dryRun=true&preserveIds=true
+// e responde com o relatório de cada linha. O arquivo pode vir no corpo ou
+// como campo "file" de um formulário multipart.
+func (h *TaskHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	format := exportFormat(r)
+	query := r.URL.Query()
+	opts := models.ImportOptions{
+		DryRun:      query.Get("dryRun") == "true",
+		PreserveIDs: query.Get("preserveIds") == "true",
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	file, err := importFile(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rows, failures, err := exchange.Import(file, format)
+	if err != nil {
+		if !errors.Is(err, exchange.ErrUnsupportedFormat) {
+			err = fmt.Errorf("%w: %w", errInvalidImportFile, err)
+		}
+		writeError(w, err)
+		return
+	}
+
+	report, err := h.service.ImportTasks(rows, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	report.AddFailures(failures)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
+// importFile retorna o conteúdo enviado: o campo "file" em requisições
+// multipart/form-data ou o próprio corpo nas demais
+func importFile(r *http.Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidImportFile, err)
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errFileFieldRequired
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidImportFile, err)
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+// exportFormat lê ?format=, usando JSON como padrão
+func exportFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+	return exchange.FormatJSON
+}
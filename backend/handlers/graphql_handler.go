@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/graphql"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+const (
+	msgInternalServerError  = "Internal server error"
+	msgInvalidRequestBody   = "Invalid request body"
+	msgRequestTooLarge      = "Request body exceeds the maximum size"
+	msgMutationOverGet      = "Mutations must be sent with POST"
+	msgSubscriptionNeedsSSE = "Subscriptions require Accept: text/event-stream"
+	msgStreamingUnsupported = "Streaming unsupported"
+)
+
+type GraphQLHandler struct {
+	schema *graphql.Schema
+	limits graphql.Limits
+	router *router
+}
+
+// NewGraphQLHandler cria o handler de /graphql com os limites de profundidade e complexidade informados
+func NewGraphQLHandler(tasks *service.TaskService, labels *service.LabelService, limits graphql.Limits) (*GraphQLHandler, error) {
+	schema, err := newGraphQLSchema(tasks, labels)
+	if err != nil {
+		return nil, err
+	}
+	h := &GraphQLHandler{
+		schema: schema,
+		limits: limits,
+	}
+	h.router = newRouter([]route{
+		{"GET /graphql", h.handleQuery},
+		{"POST /graphql", h.handleQuery},
+		{"GET /graphql/schema", h.handleSchema},
+	})
+	return h, nil
+}
+
+// ServeHTTP atende /graphql (POST com JSON ou GET com ?query=) e
+// /graphql/schema, que retorna o schema em SDL
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleQuery executa a operação. Subscriptions são enviadas como
+// Server-Sent Events quando o cliente aceita text/event-stream.
+func (h *GraphQLHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeGraphQL(w, http.StatusBadRequest, graphql.ErrorResponse(&graphql.Error{Message: msgInvalidRequestBody}))
+				return
+			}
+		}
+	case http.MethodPost:
+		// O mesmo limite dos corpos JSON da API REST
+		r.Body = http.MaxBytesReader(w, r.Body, maxJSONBodySize)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			status, message := http.StatusBadRequest, msgInvalidRequestBody
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				status, message = http.StatusRequestEntityTooLarge, msgRequestTooLarge
+			}
+			writeGraphQL(w, status, graphql.ErrorResponse(&graphql.Error{Message: message}))
+			return
+		}
+	}
+
+	prepared, errs := h.schema.Prepare(req, h.limits)
+	if errs != nil {
+		writeGraphQL(w, http.StatusBadRequest, graphql.ErrorResponse(errs...))
+		return
+	}
+
+	switch prepared.Type() {
+	case graphql.OperationMutation:
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeGraphQL(w, http.StatusMethodNotAllowed, graphql.ErrorResponse(&graphql.Error{Message: msgMutationOverGet}))
+			return
+		}
+	case graphql.OperationSubscription:
+		h.handleSubscription(w, r, prepared)
+		return
+	}
+
+	writeGraphQL(w, http.StatusOK, prepared.Execute(r.Context()))
+}
+
+// handleSubscription envia cada resultado como um evento "next" e termina
+// com "complete" quando o fluxo acaba; o fluxo acaba quando o cliente desconecta
+func (h *GraphQLHandler) handleSubscription(w http.ResponseWriter, r *http.Request, prepared *graphql.Prepared) {
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		writeGraphQL(w, http.StatusBadRequest, graphql.ErrorResponse(&graphql.Error{Message: msgSubscriptionNeedsSSE}))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeGraphQL(w, http.StatusInternalServerError, graphql.ErrorResponse(&graphql.Error{Message: msgStreamingUnsupported}))
+		return
+	}
+
+	responses, err := prepared.Subscribe(r.Context())
+	if err != nil {
+		writeGraphQL(w, http.StatusBadRequest, graphql.ErrorResponse(&graphql.Error{Message: err.Error()}))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for response := range responses {
+		data, err := json.Marshal(response)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "event: next\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+	fmt.Fprint(w, "event: complete\ndata:\n\n")
+	flusher.Flush()
+}
+
+// handleSchema retorna o schema em SDL
+func (h *GraphQLHandler) handleSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(h.schema.SDL()))
+}
+
+// writeGraphQL escreve a resposta no formato {"data": ..., "errors": [...]}
+func writeGraphQL(w http.ResponseWriter, status int, response *graphql.Response) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
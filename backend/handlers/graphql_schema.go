@@ -0,0 +1,541 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/graphql"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+// Códigos em extensions.code dos erros dos resolvers
+const (
+	codeNotFound       = "NOT_FOUND"
+	codeBadUserInput   = "BAD_USER_INPUT"
+	codeConflict       = "CONFLICT"
+	codeInternalServer = "INTERNAL_SERVER_ERROR"
+)
+
+// resolverError é o erro de um resolver com o código exposto ao cliente
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e *resolverError) Error() string { return e.message }
+func (e *resolverError) Code() string  { return e.code }
+
+// toResolverError traduz os erros do serviço pela mesma tabela dos handlers
+// REST; erros inesperados são registrados no log e não expõem detalhes
+func toResolverError(err error) error {
+	mapping, ok := lookupError(err)
+	var fieldErr *service.FieldError
+	switch {
+	case !ok:
+		log.Printf("graphql: %v", err)
+		return &resolverError{code: codeInternalServer, message: msgInternalServerError}
+	case errors.As(err, &fieldErr):
+		return &resolverError{code: codeBadUserInput, message: err.Error()}
+	case mapping.status == http.StatusNotFound:
+		return &resolverError{code: codeNotFound, message: err.Error()}
+	case mapping.status == http.StatusConflict:
+		return &resolverError{code: codeConflict, message: err.Error()}
+	default:
+		return &resolverError{code: codeBadUserInput, message: err.Error()}
+	}
+}
+
+// dateTimeType é o escalar DateTime, serializado em RFC 3339
+var dateTimeType = &graphql.Scalar{
+	Name:        "DateTime",
+	Description: "Data e hora no formato RFC 3339, ex: 2024-05-01T12:00:00Z",
+	Serialize: func(value any) (any, error) {
+		switch t := value.(type) {
+		case time.Time:
+			return t.Format(time.RFC3339), nil
+		case *time.Time:
+			return t.Format(time.RFC3339), nil
+		}
+		return nil, fmt.Errorf("DateTime cannot represent value: %v", value)
+	},
+	ParseValue: func(value any) (any, error) {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("DateTime cannot represent a non string value: %v", value)
+		}
+		t, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return nil, fmt.Errorf("DateTime must be an RFC 3339 timestamp: %q", text)
+		}
+		return t, nil
+	},
+}
+
+var (
+	statusEnum = &graphql.Enum{
+		Name:   "Status",
+		Values: []string{string(models.StatusTodo), string(models.StatusInProgress), string(models.StatusDone)},
+	}
+	priorityEnum = &graphql.Enum{
+		Name:   "Priority",
+		Values: []string{string(models.PriorityLow), string(models.PriorityMedium), string(models.PriorityHigh), string(models.PriorityUrgent)},
+	}
+	taskSortEnum = &graphql.Enum{
+		Name:   "TaskSort",
+		Values: []string{service.SortByDueDate, service.SortByPriority, service.SortByTitle},
+	}
+	taskEventTypeEnum = &graphql.Enum{
+		Name:   "TaskEventType",
+		Values: []string{string(models.TaskCreated), string(models.TaskUpdated), string(models.TaskDeleted)},
+	}
+)
+
+// graphqlResolvers resolve o schema GraphQL delegando ao TaskService
+type graphqlResolvers struct {
+	tasks  *service.TaskService
+	labels *service.LabelService
+}
+
+// newGraphQLSchema monta o schema de tarefas, quadro e etiquetas
+func newGraphQLSchema(tasks *service.TaskService, labels *service.LabelService) (*graphql.Schema, error) {
+	r := &graphqlResolvers{tasks: tasks, labels: labels}
+	nonNull := graphql.NewNonNull
+	listOf := func(t graphql.Type) graphql.Type { return nonNull(graphql.NewList(nonNull(t))) }
+
+	checklistItemType := &graphql.Object{
+		Name: "ChecklistItem",
+		Fields: []*graphql.FieldDef{
+			{Name: "id", Type: nonNull(graphql.ID)},
+			{Name: "text", Type: nonNull(graphql.String)},
+			{Name: "checked", Type: nonNull(graphql.Boolean)},
+			{Name: "order", Type: nonNull(graphql.Int)},
+		},
+	}
+	labelType := &graphql.Object{
+		Name: "Label",
+		Fields: []*graphql.FieldDef{
+			{Name: "id", Type: nonNull(graphql.ID)},
+			{Name: "name", Type: nonNull(graphql.String)},
+			{Name: "color", Type: nonNull(graphql.String)},
+		},
+	}
+	commentType := &graphql.Object{
+		Name: "Comment",
+		Fields: []*graphql.FieldDef{
+			{Name: "id", Type: nonNull(graphql.ID)},
+			{Name: "author", Type: nonNull(graphql.String)},
+			{Name: "body", Type: nonNull(graphql.String)},
+			{Name: "createdAt", Type: nonNull(dateTimeType)},
+			{Name: "updatedAt", Type: nonNull(dateTimeType)},
+		},
+	}
+	attachmentType := &graphql.Object{
+		Name: "Attachment",
+		Fields: []*graphql.FieldDef{
+			{Name: "id", Type: nonNull(graphql.ID)},
+			{Name: "fileName", Type: nonNull(graphql.String)},
+			{Name: "contentType", Type: nonNull(graphql.String)},
+			{Name: "size", Type: nonNull(graphql.Int), Description: "Tamanho em bytes"},
+			{Name: "createdAt", Type: nonNull(dateTimeType)},
+		},
+	}
+
+	// Task referencia a si mesma em blockedBy e blocks, então os campos são
+	// adicionados depois de o tipo existir
+	taskType := &graphql.Object{Name: "Task"}
+	taskType.Fields = []*graphql.FieldDef{
+		{Name: "id", Type: nonNull(graphql.ID)},
+		{Name: "title", Type: nonNull(graphql.String)},
+		{Name: "description", Type: nonNull(graphql.String)},
+		{Name: "status", Type: nonNull(statusEnum)},
+		{Name: "priority", Type: nonNull(priorityEnum)},
+		{Name: "completed", Type: nonNull(graphql.Boolean)},
+		{Name: "completedAt", Type: dateTimeType},
+		{Name: "archived", Type: nonNull(graphql.Boolean)},
+		{Name: "archivedAt", Type: dateTimeType},
+		{Name: "dueDate", Type: dateTimeType},
+		{Name: "progress", Type: nonNull(graphql.Int), Description: "Percentual (0-100) de itens marcados no checklist",
+			Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(*models.Task).Progress(), nil }},
+		{Name: "checklist", Type: listOf(checklistItemType)},
+		{Name: "labels", Type: listOf(labelType), Resolve: r.taskLabels},
+		{Name: "comments", Type: listOf(commentType), Resolve: r.taskComments},
+		{Name: "attachments", Type: listOf(attachmentType), Resolve: r.taskAttachments},
+		{Name: "blockedBy", Type: listOf(taskType), Description: "Tarefas que precisam ser concluídas antes desta",
+			Resolve: r.taskBlockedBy},
+		{Name: "blocks", Type: listOf(taskType), Description: "Tarefas que esperam a conclusão desta",
+			Resolve: r.taskBlocks},
+	}
+
+	columnType := &graphql.Object{
+		Name: "Column",
+		Fields: []*graphql.FieldDef{
+			{Name: "status", Type: nonNull(statusEnum)},
+			{Name: "name", Type: nonNull(graphql.String)},
+			{Name: "count", Type: nonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source.(models.BoardColumn).Count(), nil }},
+			{Name: "tasks", Type: listOf(taskType), Description: "Tarefas ativas da coluna, por prioridade",
+				Resolve: func(p graphql.ResolveParams) (any, error) { return columnTasks(p.Source.(models.BoardColumn)), nil }},
+		},
+	}
+	boardType := &graphql.Object{
+		Name: "Board",
+		Fields: []*graphql.FieldDef{
+			{Name: "total", Type: nonNull(graphql.Int)},
+			{Name: "columns", Type: listOf(columnType)},
+		},
+	}
+	taskEventType := &graphql.Object{
+		Name: "TaskEvent",
+		Fields: []*graphql.FieldDef{
+			{Name: "type", Type: nonNull(taskEventTypeEnum)},
+			{Name: "taskId", Type: nonNull(graphql.ID)},
+			{Name: "task", Type: taskType, Description: "Estado da tarefa após a alteração; nulo quando removida"},
+		},
+	}
+
+	createTaskInput := &graphql.InputObject{
+		Name: "CreateTaskInput",
+		Fields: []*graphql.ArgumentDef{
+			{Name: "title", Type: nonNull(graphql.String)},
+			{Name: "description", Type: graphql.String},
+			{Name: "labels", Type: graphql.NewList(nonNull(graphql.ID))},
+			{Name: "priority", Type: priorityEnum},
+			{Name: "dueDate", Type: dateTimeType},
+			{Name: "checklist", Type: graphql.NewList(nonNull(graphql.String))},
+		},
+	}
+	updateTaskInput := &graphql.InputObject{
+		Name: "UpdateTaskInput",
+		Fields: []*graphql.ArgumentDef{
+			{Name: "title", Type: graphql.String},
+			{Name: "description", Type: graphql.String},
+			{Name: "status", Type: statusEnum},
+			{Name: "labels", Type: graphql.NewList(nonNull(graphql.ID))},
+			{Name: "priority", Type: priorityEnum},
+			{Name: "dueDate", Type: dateTimeType},
+		},
+	}
+
+	query := &graphql.Object{
+		Name: "Query",
+		Fields: []*graphql.FieldDef{
+			{Name: "tasks", Type: listOf(taskType), Resolve: r.listTasks,
+				Description: "Lista as tarefas; os filtros informados são combinados",
+				Args: []*graphql.ArgumentDef{
+					{Name: "status", Type: statusEnum},
+					{Name: "priority", Type: priorityEnum},
+					{Name: "label", Type: graphql.ID, Description: "ID da etiqueta"},
+					{Name: "archived", Type: graphql.Boolean, Default: false},
+					{Name: "overdue", Type: graphql.Boolean, Description: "Apenas tarefas não concluídas com prazo vencido"},
+					{Name: "search", Type: graphql.String, Description: "Trecho do título ou da descrição"},
+					{Name: "sort", Type: taskSortEnum},
+					{Name: "desc", Type: graphql.Boolean, Default: false},
+				}},
+			{Name: "task", Type: taskType, Resolve: r.getTask,
+				Args: []*graphql.ArgumentDef{{Name: "id", Type: nonNull(graphql.ID)}}},
+			{Name: "labels", Type: listOf(labelType), Resolve: r.listLabels},
+			{Name: "board", Type: nonNull(boardType), Resolve: r.board},
+		},
+	}
+	mutation := &graphql.Object{
+		Name: "Mutation",
+		Fields: []*graphql.FieldDef{
+			{Name: "createTask", Type: nonNull(taskType), Resolve: r.createTask,
+				Args: []*graphql.ArgumentDef{{Name: "input", Type: nonNull(createTaskInput)}}},
+			{Name: "updateTask", Type: nonNull(taskType), Resolve: r.updateTask,
+				Args: []*graphql.ArgumentDef{
+					{Name: "id", Type: nonNull(graphql.ID)},
+					{Name: "input", Type: nonNull(updateTaskInput)},
+				}},
+			{Name: "moveTask", Type: nonNull(taskType), Resolve: r.moveTask,
+				Description: "Move a tarefa para outra coluna do quadro",
+				Args: []*graphql.ArgumentDef{
+					{Name: "id", Type: nonNull(graphql.ID)},
+					{Name: "status", Type: nonNull(statusEnum)},
+				}},
+			{Name: "deleteTask", Type: nonNull(graphql.ID), Resolve: r.deleteTask,
+				Description: "Remove a tarefa e retorna o ID removido",
+				Args:        []*graphql.ArgumentDef{{Name: "id", Type: nonNull(graphql.ID)}}},
+		},
+	}
+	subscription := &graphql.Object{
+		Name: "Subscription",
+		Fields: []*graphql.FieldDef{
+			{Name: "taskChanged", Type: nonNull(taskEventType), Subscribe: r.taskChanged,
+				Description: "Eventos de criação, alteração e remoção de tarefas",
+				Args: []*graphql.ArgumentDef{
+					{Name: "id", Type: graphql.ID, Description: "Apenas os eventos desta tarefa"},
+					{Name: "status", Type: statusEnum, Description: "Apenas tarefas que ficaram com este status"},
+				}},
+		},
+	}
+
+	return graphql.NewSchema(query, mutation, subscription)
+}
+
+// listTasks aplica os filtros de tasks(...) e a ordenação pedida
+func (r *graphqlResolvers) listTasks(p graphql.ResolveParams) (any, error) {
+	all, err := r.tasks.GetAllTasksIncludingArchived()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+
+	var overdue map[string]bool
+	if want, ok := p.Args["overdue"].(bool); ok {
+		late, err := r.tasks.GetOverdueTasks()
+		if err != nil {
+			return nil, toResolverError(err)
+		}
+		overdue = make(map[string]bool, len(late))
+		for _, task := range late {
+			overdue[task.ID] = true
+		}
+		all = slices.DeleteFunc(all, func(task *models.Task) bool { return overdue[task.ID] != want })
+	}
+
+	archived, _ := p.Args["archived"].(bool)
+	status, _ := p.Args["status"].(string)
+	priority, _ := p.Args["priority"].(string)
+	label, _ := p.Args["label"].(string)
+	search, _ := p.Args["search"].(string)
+	search = strings.ToLower(search)
+
+	tasks := slices.DeleteFunc(all, func(task *models.Task) bool {
+		return task.Archived != archived ||
+			(status != "" && string(task.Status) != status) ||
+			(priority != "" && string(task.Priority) != priority) ||
+			(label != "" && !slices.Contains(task.Labels, label)) ||
+			(search != "" && !strings.Contains(strings.ToLower(task.Title+"\n"+task.Description), search))
+	})
+
+	if sort, ok := p.Args["sort"].(string); ok {
+		desc, _ := p.Args["desc"].(bool)
+		if err := service.SortTasks(tasks, sort, desc); err != nil {
+			return nil, toResolverError(err)
+		}
+	}
+	return tasks, nil
+}
+
+// getTask busca a tarefa; uma tarefa inexistente resolve para null
+func (r *graphqlResolvers) getTask(p graphql.ResolveParams) (any, error) {
+	task, err := r.tasks.GetTaskByID(p.Args["id"].(string))
+	if errors.Is(err, repository.ErrTaskNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return task, nil
+}
+
+// listLabels lista todas as etiquetas
+func (r *graphqlResolvers) listLabels(p graphql.ResolveParams) (any, error) {
+	labels, err := r.labels.GetAllLabels()
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return labels, nil
+}
+
+// board monta o quadro com as colunas na ordem dos relatórios
+func (r *graphqlResolvers) board(p graphql.ResolveParams) (any, error) {
+	report, err := r.tasks.GetBoardReport(time.Time{})
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return report, nil
+}
+
+// columnTasks devolve as tarefas da coluna sem os nomes de etiqueta do relatório
+func columnTasks(column models.BoardColumn) []*models.Task {
+	tasks := make([]*models.Task, len(column.Tasks))
+	for i, task := range column.Tasks {
+		tasks[i] = task.Task
+	}
+	return tasks
+}
+
+// taskLabels resolve os IDs de etiqueta da tarefa, ignorando as que foram removidas
+func (r *graphqlResolvers) taskLabels(p graphql.ResolveParams) (any, error) {
+	task := p.Source.(*models.Task)
+	labels := make([]*models.Label, 0, len(task.Labels))
+	for _, id := range task.Labels {
+		label, err := r.labels.GetLabelByID(id)
+		if errors.Is(err, repository.ErrLabelNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, toResolverError(err)
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// taskComments lista os comentários da tarefa
+func (r *graphqlResolvers) taskComments(p graphql.ResolveParams) (any, error) {
+	comments, err := r.tasks.GetComments(p.Source.(*models.Task).ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return comments, nil
+}
+
+// taskAttachments lista os anexos da tarefa
+func (r *graphqlResolvers) taskAttachments(p graphql.ResolveParams) (any, error) {
+	attachments, err := r.tasks.GetAttachments(p.Source.(*models.Task).ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return attachments, nil
+}
+
+// taskBlockedBy lista as tarefas que bloqueiam a tarefa
+func (r *graphqlResolvers) taskBlockedBy(p graphql.ResolveParams) (any, error) {
+	deps, err := r.tasks.GetDependencies(p.Source.(*models.Task).ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return deps.BlockedBy, nil
+}
+
+// taskBlocks lista as tarefas bloqueadas pela tarefa
+func (r *graphqlResolvers) taskBlocks(p graphql.ResolveParams) (any, error) {
+	deps, err := r.tasks.GetDependencies(p.Source.(*models.Task).ID)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return deps.Blocks, nil
+}
+
+// createTask cria a tarefa a partir de CreateTaskInput
+func (r *graphqlResolvers) createTask(p graphql.ResolveParams) (any, error) {
+	input := p.Args["input"].(map[string]any)
+	req := models.CreateTaskRequest{
+		Labels:    stringList(input["labels"]),
+		Checklist: stringList(input["checklist"]),
+	}
+	req.Title, _ = input["title"].(string)
+	req.Description, _ = input["description"].(string)
+	if priority, ok := input["priority"].(string); ok {
+		req.Priority = models.Priority(priority)
+	}
+	if dueDate, ok := input["dueDate"].(time.Time); ok {
+		req.DueDate = &dueDate
+	}
+
+	task, err := r.tasks.CreateTask(req)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return task, nil
+}
+
+// updateTask altera apenas os campos informados em UpdateTaskInput
+func (r *graphqlResolvers) updateTask(p graphql.ResolveParams) (any, error) {
+	input := p.Args["input"].(map[string]any)
+	var req models.UpdateTaskRequest
+	if title, ok := input["title"].(string); ok {
+		req.Title = &title
+	}
+	if description, ok := input["description"].(string); ok {
+		req.Description = &description
+	}
+	if status, ok := input["status"].(string); ok {
+		req.Status = (*models.Status)(&status)
+	}
+	if labels, ok := input["labels"].([]any); ok {
+		ids := stringList(labels)
+		req.Labels = &ids
+	}
+	if priority, ok := input["priority"].(string); ok {
+		req.Priority = (*models.Priority)(&priority)
+	}
+	if dueDate, ok := input["dueDate"].(time.Time); ok {
+		req.DueDate = &dueDate
+	}
+
+	task, err := r.tasks.UpdateTask(p.Args["id"].(string), req)
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return task, nil
+}
+
+// moveTask troca apenas o status da tarefa
+func (r *graphqlResolvers) moveTask(p graphql.ResolveParams) (any, error) {
+	status := models.Status(p.Args["status"].(string))
+	task, err := r.tasks.UpdateTask(p.Args["id"].(string), models.UpdateTaskRequest{Status: &status})
+	if err != nil {
+		return nil, toResolverError(err)
+	}
+	return task, nil
+}
+
+// deleteTask remove a tarefa
+func (r *graphqlResolvers) deleteTask(p graphql.ResolveParams) (any, error) {
+	id := p.Args["id"].(string)
+	if err := r.tasks.DeleteTask(id); err != nil {
+		return nil, toResolverError(err)
+	}
+	return id, nil
+}
+
+// taskChanged repassa os eventos do serviço que passam pelos filtros até o
+// contexto da requisição terminar
+func (r *graphqlResolvers) taskChanged(p graphql.ResolveParams) (<-chan any, error) {
+	id, _ := p.Args["id"].(string)
+	status, _ := p.Args["status"].(string)
+	matches := func(event models.TaskEvent) bool {
+		if id != "" && event.TaskID != id {
+			return false
+		}
+		return status == "" || (event.Task != nil && string(event.Task.Status) == status)
+	}
+
+	events, cancel := r.tasks.Subscribe()
+	out := make(chan any)
+	go func() {
+		defer close(out)
+		defer cancel()
+		for {
+			select {
+			case <-p.Context.Done():
+				return
+			case event := <-events:
+				if !matches(event) {
+					continue
+				}
+				select {
+				case out <- event:
+				case <-p.Context.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// stringList converte uma lista de entrada ([]any) em []string
+func stringList(value any) []string {
+	items, _ := value.([]any)
+	if items == nil {
+		return nil
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, ok := item.(string); ok {
+			list = append(list, text)
+		}
+	}
+	return list
+}
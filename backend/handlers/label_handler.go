@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+type LabelHandler struct {
+	service *service.LabelService
+	router  *router
+}
+
+// NewLabelHandler cria uma nova instância do handler de etiquetas
+func NewLabelHandler(service *service.LabelService) *LabelHandler {
+	h := &LabelHandler{
+		service: service,
+	}
+	h.router = newRouter(h.routes())
+	return h
+}
+
+// routes é a tabela de rotas de /labels e /labels/{id}
+func (h *LabelHandler) routes() []route {
+	return []route{
+		{"GET /labels", h.handleGetAll},
+		{"POST /labels", h.handleCreate},
+		{"GET /labels/{id}", h.handleGetByID},
+		{"PUT /labels/{id}", h.handleUpdate},
+		{"DELETE /labels/{id}", h.handleDelete},
+	}
+}
+
+// ServeHTTP despacha a requisição segundo a tabela de rotas
+func (h *LabelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleCreate processa requisições POST para criar uma etiqueta
+func (h *LabelHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateLabelRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	label, err := h.service.CreateLabel(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(label)
+}
+
+// handleGetAll processa requisições GET para listar as etiquetas
+func (h *LabelHandler) handleGetAll(w http.ResponseWriter, r *http.Request) {
+	labels, err := h.service.GetAllLabels()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(labels)
+}
+
+// handleGetByID processa requisições GET para buscar uma etiqueta por ID
+func (h *LabelHandler) handleGetByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	label, err := h.service.GetLabelByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(label)
+}
+
+// handleUpdate processa requisições PUT para atualizar uma etiqueta
+func (h *LabelHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var req models.UpdateLabelRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	label, err := h.service.UpdateLabel(id, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(label)
+}
+
+// handleDelete processa requisições DELETE para remover uma etiqueta
+func (h *LabelHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := h.service.DeleteLabel(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
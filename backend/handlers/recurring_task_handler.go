@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+type RecurringTaskHandler struct {
+	service *service.RecurringTaskService
+	router  *router
+}
+
+// NewRecurringTaskHandler cria uma nova instância do handler de tarefas recorrentes
+func NewRecurringTaskHandler(service *service.RecurringTaskService) *RecurringTaskHandler {
+	h := &RecurringTaskHandler{
+		service: service,
+	}
+	h.router = newRouter(h.routes())
+	return h
+}
+
+// routes é a tabela de rotas de /recurring-tasks e /recurring-tasks/{id}
+func (h *RecurringTaskHandler) routes() []route {
+	return []route{
+		{"GET /recurring-tasks", h.handleGetAll},
+		{"POST /recurring-tasks", h.handleCreate},
+		{"GET /recurring-tasks/{id}", h.handleGetByID},
+		{"PUT /recurring-tasks/{id}", h.handleUpdate},
+		{"DELETE /recurring-tasks/{id}", h.handleDelete},
+	}
+}
+
+// ServeHTTP despacha a requisição segundo a tabela de rotas
+func (h *RecurringTaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleCreate processa requisições POST para criar uma tarefa recorrente
+func (h *RecurringTaskHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateRecurringTaskRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template, err := h.service.CreateRecurringTask(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+// handleGetAll processa requisições GET para listar as tarefas recorrentes
+func (h *RecurringTaskHandler) handleGetAll(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.GetAllRecurringTasks()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(templates)
+}
+
+// handleGetByID processa requisições GET para buscar uma tarefa recorrente por ID
+func (h *RecurringTaskHandler) handleGetByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	template, err := h.service.GetRecurringTaskByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// handleUpdate processa requisições PUT para atualizar uma tarefa recorrente
+func (h *RecurringTaskHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var req models.UpdateRecurringTaskRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template, err := h.service.UpdateRecurringTask(id, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// handleDelete processa requisições DELETE para remover uma tarefa recorrente
+func (h *RecurringTaskHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := h.service.DeleteRecurringTask(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
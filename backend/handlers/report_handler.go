@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/acauhi/kanban-backend/report"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+type ReportHandler struct {
+	service  *service.TaskService
+	renderer *report.Renderer
+	router   *router
+}
+
+// NewReportHandler cria uma nova instância do handler de relatórios
+func NewReportHandler(service *service.TaskService, renderer *report.Renderer) *ReportHandler {
+	h := &ReportHandler{
+		service:  service,
+		renderer: renderer,
+	}
+	h.router = newRouter([]route{
+		{"GET /reports/board", h.handleBoard},
+	})
+	return h
+}
+
+// ServeHTTP atende /reports/{nome}; por ora só existe o relatório "board"
+func (h *ReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleBoard processa GET /reports/board?format=markdown|html&since=2025-01-01
+func (h *ReportHandler) handleBoard(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = report.FormatMarkdown
+	}
+	if format != report.FormatMarkdown && format != report.FormatHTML {
+		writeError(w, report.ErrUnsupportedFormat)
+		return
+	}
+
+	var since time.Time
+	if value := query.Get("since"); value != "" {
+		var err error
+		if since, err = parseSince(value); err != nil {
+			writeError(w, errInvalidSince)
+			return
+		}
+	}
+
+	board, err := h.service.GetBoardReport(since)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Renderiza antes de responder para que erros de template sobrescrito virem 500
+	var buf bytes.Buffer
+	if err := h.renderer.Render(&buf, format, board); err != nil {
+		log.Printf("report: %v", err)
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", report.ContentType(format))
+	w.WriteHeader(http.StatusOK)
+	buf.WriteTo(w)
+}
+
+// parseSince aceita uma data (início do dia em UTC) ou um timestamp RFC 3339
+func parseSince(value string) (time.Time, error) {
+	if since, err := time.Parse(time.DateOnly, value); err == nil {
+		return since, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// routerMethods são os métodos que recebem 405 nos caminhos que não os atendem
+var routerMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// route associa um padrão do http.ServeMux com método e curingas (ex:
+// "GET /tasks/{id}") ao handler; os curingas são lidos com r.PathValue
+type route struct {
+	pattern string
+	handler http.HandlerFunc
+}
+
+// router despacha as requisições segundo uma tabela de rotas
+type router struct {
+	mux *http.ServeMux
+}
+
+// newRouter registra as rotas num http.ServeMux. Os demais métodos de um
+// caminho registrado respondem 405 com o header Allow, inclusive quando
+// outra rota com curinga aceitaria o método (PUT /tasks/overdue não chega a
+// PUT /tasks/{id}), e caminhos sem rota respondem 404, ambos em
+// application/problem+json.
+func newRouter(routes []route) *router {
+	mux := http.NewServeMux()
+	var paths []string
+	allowed := make(map[string][]string)
+	for _, rt := range routes {
+		method, path, _ := strings.Cut(rt.pattern, " ")
+		mux.HandleFunc(rt.pattern, rt.handler)
+		if _, ok := allowed[path]; !ok {
+			paths = append(paths, path)
+		}
+		allowed[path] = append(allowed[path], method)
+	}
+
+	for _, path := range paths {
+		methods := allowed[path]
+		allow := allowHeader(methods)
+		for _, method := range routerMethods {
+			if !slices.Contains(methods, method) {
+				mux.HandleFunc(method+" "+path, func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Allow", allow)
+					writeError(w, errMethodNotAllowed)
+				})
+			}
+		}
+	}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, errResourceNotFound)
+	})
+	return &router{mux: mux}
+}
+
+// allowHeader lista os métodos na ordem de routerMethods; quem aceita GET
+// também aceita HEAD
+func allowHeader(methods []string) string {
+	var allow []string
+	for _, method := range routerMethods {
+		if !slices.Contains(methods, method) {
+			continue
+		}
+		allow = append(allow, method)
+		if method == http.MethodGet {
+			allow = append(allow, http.MethodHead)
+		}
+	}
+	return strings.Join(allow, ", ")
+}
+
+// ServeHTTP responde em JSON por padrão; handlers de outros formatos trocam
+// o Content-Type antes de escrever
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	rt.mux.ServeHTTP(w, r)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/apiv1"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/openapi"
+	"github.com/acauhi/kanban-backend/problem"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/service"
+	"github.com/acauhi/kanban-backend/storage"
+)
+
+var paramPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// newTaskHandler monta o TaskHandler com todos os sub-recursos habilitados
+func newTaskHandler() http.Handler {
+	svc := service.NewTaskService(repository.NewInMemoryTaskRepository(),
+		service.WithLabelRepository(repository.NewInMemoryLabelRepository()),
+		service.WithCommentRepository(repository.NewInMemoryCommentRepository()),
+		service.WithAttachmentStorage(repository.NewInMemoryAttachmentRepository(), storage.NewMemoryBlobStore()),
+		service.WithTaskTemplateRepository(repository.NewInMemoryTaskTemplateRepository()),
+		service.WithDependencyRepository(repository.NewInMemoryDependencyRepository()),
+	)
+	return NewTaskHandler(svc)
+}
+
+// samplePath troca os parâmetros {nome} do caminho por um ID inexistente
+func samplePath(path string) string {
+	return paramPattern.ReplaceAllString(path, "missing")
+}
+
+func TestUndocumentedMethodsAreRejected(t *testing.T) {
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	handler := newTaskHandler()
+	all := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch}
+
+	for path, item := range spec.Paths {
+		target := samplePath(path)
+		for _, method := range all {
+			if _, ok := item.Operations[method]; ok {
+				continue
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+			if rec.Code != http.StatusMethodNotAllowed {
+				t.Errorf("%s %s is served (%d) but not documented", method, path, rec.Code)
+			}
+			allow := strings.Split(rec.Header().Get("Allow"), ", ")
+			for documented := range item.Operations {
+				if !slices.Contains(allow, documented) {
+					t.Errorf("%s %s: expected Allow to list %s, got %q", method, path, documented, rec.Header().Get("Allow"))
+				}
+			}
+		}
+	}
+}
+
+func TestUnknownPathsAreNotFound(t *testing.T) {
+	handler := newTaskHandler()
+
+	for _, target := range []string{"/tasks/1/2", "/tasks/1/unknown", "/tasks/1/checklist/2/3", "/tasks/overdue/1"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		var body problem.Problem
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		if rec.Code != http.StatusNotFound || body.Code != problem.CodeResourceNotFound {
+			t.Errorf("GET %s: expected 404 %s, got %d %s", target, problem.CodeResourceNotFound, rec.Code, rec.Body)
+		}
+	}
+}
+
+// v2Mapper simula uma versão seguinte da API, com outro formato de tarefa
+type v2Mapper struct{}
+
+func (v2Mapper) Task(task *models.Task) any {
+	return map[string]any{"id": task.ID, "name": task.Title, "state": task.Status}
+}
+
+func (v2Mapper) Dependencies(deps *models.TaskDependencies) any {
+	return map[string]any{"taskId": deps.TaskID}
+}
+
+// Handlers de versões diferentes atendem o mesmo serviço, cada um com a sua representação
+func TestTaskMapperSelectsRepresentation(t *testing.T) {
+	svc := service.NewTaskService(repository.NewInMemoryTaskRepository())
+	v1 := NewTaskHandler(svc)
+	v2 := NewTaskHandler(svc, WithTaskMapper(v2Mapper{}))
+	task, err := svc.CreateTask(models.CreateTaskRequest{Title: "Write docs"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	get := func(handler http.Handler, target string) string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected status 200, got %d (%s)", target, rec.Code, rec.Body)
+		}
+		return strings.TrimSpace(rec.Body.String())
+	}
+
+	v1Task, _ := json.Marshal(apiv1.FromTask(task))
+	if got := get(v1, "/tasks/"+task.ID); got != string(v1Task) {
+		t.Errorf("expected the v1 representation %s, got %s", v1Task, got)
+	}
+	want := `{"id":"` + task.ID + `","name":"Write docs","state":"todo"}`
+	if got := get(v2, "/tasks/"+task.ID); got != want {
+		t.Errorf("expected the v2 representation %s, got %s", want, got)
+	}
+	if got := get(v2, "/tasks"); got != "["+want+"]" {
+		t.Errorf("expected the v2 list [%s], got %s", want, got)
+	}
+}
@@ -3,188 +3,298 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
-	"strings"
 
+	"github.com/acauhi/kanban-backend/apiv1"
 	"github.com/acauhi/kanban-backend/models"
-	"github.com/acauhi/kanban-backend/repository"
 	"github.com/acauhi/kanban-backend/service"
 )
 
 const (
-	pathTasks              = "/tasks/"
-	msgInternalServerError = "Internal server error"
-	msgInvalidRequestBody  = "Invalid request body"
-	msgTaskIDRequired      = "Task ID is required"
-	msgTaskNotFound        = "Task not found"
-	msgMethodNotAllowed    = "Method not allowed"
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
 )
 
+// TaskMapper converte as tarefas do domínio na representação JSON de uma
+// versão da API. Cada versão monta o próprio TaskHandler, de modo que
+// /api/v1 e uma futura /api/v2 atendem o mesmo serviço com formatos distintos.
+type TaskMapper interface {
+	Task(task *models.Task) any
+	Dependencies(deps *models.TaskDependencies) any
+}
+
 type TaskHandler struct {
 	service *service.TaskService
+	mapper  TaskMapper
+	router  *router
+}
+
+// TaskHandlerOption configura o TaskHandler
+type TaskHandlerOption func(*TaskHandler)
+
+// WithTaskMapper troca a representação das tarefas nas respostas; o padrão é
+// a da versão 1 (apiv1.Mapper)
+func WithTaskMapper(mapper TaskMapper) TaskHandlerOption {
+	return func(h *TaskHandler) {
+		h.mapper = mapper
+	}
 }
 
 // NewTaskHandler cria uma nova instância do handler de tarefas
-func NewTaskHandler(service *service.TaskService) *TaskHandler {
-	return &TaskHandler{
+func NewTaskHandler(service *service.TaskService, opts ...TaskHandlerOption) *TaskHandler {
+	h := &TaskHandler{
 		service: service,
+		mapper:  apiv1.Mapper{},
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.router = newRouter(h.routes())
+	return h
 }
 
-// ServeHTTP roteia as requisições HTTP para os handlers apropriados
-func (h *TaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	// Extrai o ID da tarefa da URL (se presente) sem causar panic para
-	// caminhos como "/tasks" (sem barra final).
-	// r.URL.Path pode ser "/tasks" ou "/tasks/123".
-	p := strings.TrimPrefix(r.URL.Path, "/tasks")
-	var id string
-	if p == "" || p == "/" {
-		id = ""
-	} else {
-		id = strings.TrimPrefix(p, "/")
-	}
-
-	switch r.Method {
-	case http.MethodPost:
-		if id == "" {
-			h.handleCreate(w, r)
-		} else {
-			writeError(w, http.StatusMethodNotAllowed, msgMethodNotAllowed)
-		}
-	case http.MethodGet:
-		if id == "" {
-			h.handleGetAll(w, r)
-		} else {
-			h.handleGetByID(w, r)
-		}
-	case http.MethodPut:
-		if id != "" {
-			h.handleUpdate(w, r)
-		} else {
-			writeError(w, http.StatusMethodNotAllowed, msgMethodNotAllowed)
-		}
-	case http.MethodDelete:
-		if id != "" {
-			h.handleDelete(w, r)
-		} else {
-			writeError(w, http.StatusMethodNotAllowed, msgMethodNotAllowed)
-		}
-	default:
-		writeError(w, http.StatusMethodNotAllowed, msgMethodNotAllowed)
+// routes é a tabela de rotas de /tasks e dos sub-recursos da tarefa. Rotas
+// literais como /tasks/overdue têm precedência sobre /tasks/{id}.
+func (h *TaskHandler) routes() []route {
+	return []route{
+		{"GET /tasks", h.handleGetAll},
+		{"POST /tasks", h.handleCreate},
+		{"GET /tasks/overdue", h.handleGetOverdue},
+		{"GET /tasks/export", h.handleExport},
+		{"POST /tasks/import", h.handleImport},
+
+		{"GET /tasks/{id}", h.handleGetByID},
+		{"PUT /tasks/{id}", h.handleUpdate},
+		{"PATCH /tasks/{id}", h.handlePatch},
+		{"DELETE /tasks/{id}", h.handleDelete},
+
+		{"POST /tasks/{id}/checklist", h.handleAddChecklistItem},
+		{"PUT /tasks/{id}/checklist", h.handleReorderChecklist},
+		{"PUT /tasks/{id}/checklist/{itemId}", h.handleUpdateChecklistItem},
+		{"DELETE /tasks/{id}/checklist/{itemId}", h.handleRemoveChecklistItem},
+
+		{"GET /tasks/{id}/dependencies", h.handleGetDependencies},
+		{"POST /tasks/{id}/dependencies", h.handleAddDependency},
+		{"DELETE /tasks/{id}/dependencies/{blockerId}", h.handleRemoveDependency},
+
+		{"GET /tasks/{id}/comments", h.handleGetComments},
+		{"POST /tasks/{id}/comments", h.handleAddComment},
+		{"PUT /tasks/{id}/comments/{commentId}", h.handleUpdateComment},
+		{"DELETE /tasks/{id}/comments/{commentId}", h.handleDeleteComment},
+
+		{"GET /tasks/{id}/attachments", h.handleGetAttachments},
+		{"POST /tasks/{id}/attachments", h.handleUploadAttachment},
+		{"GET /tasks/{id}/attachments/{attachmentId}", h.handleDownloadAttachment},
+		{"DELETE /tasks/{id}/attachments/{attachmentId}", h.handleDeleteAttachment},
 	}
 }
 
-// handleCreate processa requisições POST para criar uma nova tarefa
+// ServeHTTP despacha a requisição segundo a tabela de rotas
+func (h *TaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleCreate processa requisições POST para criar uma nova tarefa.
+// Com ?template={id} o corpo traz as variáveis do modelo a instanciar.
 func (h *TaskHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if templateID := r.URL.Query().Get("template"); templateID != "" {
+		h.handleCreateFromTemplate(w, r, templateID)
+		return
+	}
+
 	var req models.CreateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, msgInvalidRequestBody)
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
 		return
 	}
 
 	task, err := h.service.CreateTask(req)
 	if err != nil {
-		if errors.Is(err, service.ErrInvalidTitle) {
-			writeError(w, http.StatusBadRequest, err.Error())
-		} else {
-			writeError(w, http.StatusInternalServerError, msgInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(task)
+	h.writeTask(w, http.StatusCreated, task)
 }
 
-// handleGetAll processa requisições GET para listar todas as tarefas
-func (h *TaskHandler) handleGetAll(w http.ResponseWriter, _ *http.Request) {
-	tasks, err := h.service.GetAllTasks()
+// handleCreateFromTemplate cria uma tarefa a partir de um modelo
+func (h *TaskHandler) handleCreateFromTemplate(w http.ResponseWriter, r *http.Request, templateID string) {
+	var req models.InstantiateTemplateRequest
+	// O corpo é opcional quando o modelo não usa variáveis
+	if err := decodeJSON(w, r, &req); err != nil && !errors.Is(err, errEmptyBody) {
+		writeError(w, err)
+		return
+	}
+
+	task, err := h.service.CreateTaskFromTemplate(templateID, req)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, msgInternalServerError)
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(tasks)
+	h.writeTask(w, http.StatusCreated, task)
 }
 
-// handleGetByID processa requisições GET para buscar uma tarefa por ID
-func (h *TaskHandler) handleGetByID(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Path[len(pathTasks):]
-	if id == "" {
-		writeError(w, http.StatusBadRequest, msgTaskIDRequired)
+// handleGetAll processa requisições GET para listar todas as tarefas.
+// Com ?archived=true lista apenas as tarefas arquivadas e com ?label={id}
+// apenas as que possuem a etiqueta. ?sort=dueDate|priority|title&order=desc
+// ordena o resultado. Com If-None-Match ou If-Modified-Since, responde 304
+// sem carregar as tarefas quando o quadro não mudou.
+func (h *TaskHandler) handleGetAll(w http.ResponseWriter, r *http.Request) {
+	rev, err := h.service.Revision()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if checkNotModified(w, r, rev) {
 		return
 	}
 
-	task, err := h.service.GetTaskByID(id)
+	var tasks []*models.Task
+	query := r.URL.Query()
+	switch {
+	case query.Get("archived") == "true":
+		tasks, err = h.service.GetArchivedTasks()
+	case query.Get("label") != "":
+		tasks, err = h.service.GetTasksByLabel(query.Get("label"))
+	default:
+		tasks, err = h.service.GetAllTasks()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeSortedTasks(w, r, tasks)
+}
+
+// handleGetOverdue processa requisições GET para listar as tarefas atrasadas
+func (h *TaskHandler) handleGetOverdue(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.service.GetOverdueTasks()
 	if err != nil {
-		if errors.Is(err, repository.ErrTaskNotFound) {
-			writeError(w, http.StatusNotFound, msgTaskNotFound)
-		} else {
-			writeError(w, http.StatusInternalServerError, msgInternalServerError)
+		writeError(w, err)
+		return
+	}
+
+	h.writeSortedTasks(w, r, tasks)
+}
+
+// writeSortedTasks aplica a ordenação pedida em ?sort= e escreve a lista
+func (h *TaskHandler) writeSortedTasks(w http.ResponseWriter, r *http.Request, tasks []*models.Task) {
+	query := r.URL.Query()
+	if field := query.Get("sort"); field != "" {
+		if err := service.SortTasks(tasks, field, query.Get("order") == "desc"); err != nil {
+			writeError(w, err)
+			return
 		}
+	}
+
+	h.writeTasks(w, http.StatusOK, tasks)
+}
+
+// writeTask escreve a tarefa na representação da versão atendida pelo handler
+func (h *TaskHandler) writeTask(w http.ResponseWriter, status int, task *models.Task) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(h.mapper.Task(task))
+}
+
+// writeTasks escreve a lista de tarefas; uma lista vazia sai como []
+func (h *TaskHandler) writeTasks(w http.ResponseWriter, status int, tasks []*models.Task) {
+	dtos := make([]any, len(tasks))
+	for i, task := range tasks {
+		dtos[i] = h.mapper.Task(task)
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(dtos)
+}
+
+// handleGetByID processa requisições GET para buscar uma tarefa por ID, com
+// as mesmas validações condicionais da listagem
+func (h *TaskHandler) handleGetByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	rev, err := h.service.Revision()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	task, err := h.service.GetTaskByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if checkNotModified(w, r, rev) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(task)
+	h.writeTask(w, http.StatusOK, task)
 }
 
-// handleUpdate processa requisições PUT para atualizar uma tarefa existente
+// handleUpdate processa requisições PUT, que substituem todos os campos
+// editáveis da tarefa
 func (h *TaskHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Path[len(pathTasks):]
-	if id == "" {
-		writeError(w, http.StatusBadRequest, msgTaskIDRequired)
+	id := r.PathValue("id")
+
+	var body replaceTaskBody
+	if err := decodeJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	task, err := h.service.ReplaceTask(id, body.request())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeTask(w, http.StatusOK, task)
+}
+
+// handlePatch processa requisições PATCH com JSON Merge Patch (RFC 7396) ou
+// JSON Patch (RFC 6902), conforme o Content-Type
+func (h *TaskHandler) handlePatch(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var patch func(string, []byte) (*models.Task, error)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case mergePatchContentType:
+		patch = h.service.MergePatchTask
+	case jsonPatchContentType:
+		patch = h.service.JSONPatchTask
+	default:
+		w.Header().Set("Accept-Patch", mergePatchContentType+", "+jsonPatchContentType)
+		writeError(w, errUnsupportedPatch)
 		return
 	}
 
-	var req models.UpdateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, msgInvalidRequestBody)
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONBodySize))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: %w", errInvalidRequestBody, err))
 		return
 	}
 
-	task, err := h.service.UpdateTask(id, req)
+	task, err := patch(id, body)
 	if err != nil {
-		if errors.Is(err, repository.ErrTaskNotFound) {
-			writeError(w, http.StatusNotFound, msgTaskNotFound)
-		} else if errors.Is(err, service.ErrInvalidStatus) {
-			writeError(w, http.StatusBadRequest, err.Error())
-		} else {
-			writeError(w, http.StatusInternalServerError, msgInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(task)
+	h.writeTask(w, http.StatusOK, task)
 }
 
 // handleDelete processa requisições DELETE para remover uma tarefa
 func (h *TaskHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Path[len(pathTasks):]
-	if id == "" {
-		writeError(w, http.StatusBadRequest, msgTaskIDRequired)
-		return
-	}
+	id := r.PathValue("id")
 
 	err := h.service.DeleteTask(id)
 	if err != nil {
-		if errors.Is(err, repository.ErrTaskNotFound) {
-			writeError(w, http.StatusNotFound, msgTaskNotFound)
-		} else {
-			writeError(w, http.StatusInternalServerError, msgInternalServerError)
-		}
+		writeError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
-
-// writeError escreve uma resposta de erro em JSON
-func writeError(w http.ResponseWriter, statusCode int, message string) {
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
-}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/service"
+)
+
+type TaskTemplateHandler struct {
+	service *service.TaskTemplateService
+	router  *router
+}
+
+// NewTaskTemplateHandler cria uma nova instância do handler de modelos de tarefa
+func NewTaskTemplateHandler(service *service.TaskTemplateService) *TaskTemplateHandler {
+	h := &TaskTemplateHandler{
+		service: service,
+	}
+	h.router = newRouter(h.routes())
+	return h
+}
+
+// routes é a tabela de rotas de /task-templates e /task-templates/{id}
+func (h *TaskTemplateHandler) routes() []route {
+	return []route{
+		{"GET /task-templates", h.handleGetAll},
+		{"POST /task-templates", h.handleCreate},
+		{"GET /task-templates/{id}", h.handleGetByID},
+		{"PUT /task-templates/{id}", h.handleUpdate},
+		{"DELETE /task-templates/{id}", h.handleDelete},
+	}
+}
+
+// ServeHTTP despacha a requisição segundo a tabela de rotas
+func (h *TaskTemplateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// handleCreate processa requisições POST para criar um modelo de tarefa
+func (h *TaskTemplateHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateTaskTemplateRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+// handleGetAll processa requisições GET para listar os modelos de tarefa
+func (h *TaskTemplateHandler) handleGetAll(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.GetAllTemplates()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(templates)
+}
+
+// handleGetByID processa requisições GET para buscar um modelo de tarefa por ID
+func (h *TaskTemplateHandler) handleGetByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	template, err := h.service.GetTemplateByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// handleUpdate processa requisições PUT para atualizar um modelo de tarefa
+func (h *TaskTemplateHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var req models.UpdateTaskTemplateRequest
+	if err := decodeJSON(w, r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(id, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(template)
+}
+
+// handleDelete processa requisições DELETE para remover um modelo de tarefa
+func (h *TaskTemplateHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	if err := h.service.DeleteTemplate(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,199 @@
+package idempotency
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// createHandler simula a criação de tarefas, contando as execuções
+func createHandler(calls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id":"%d"}`, n)
+	})
+}
+
+// post envia a requisição com a chave informada
+func post(handler http.Handler, target, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareReplaysFirstResponse(t *testing.T) {
+	var calls atomic.Int32
+	handler := New(NewMemoryStore(), time.Hour).Handler(createHandler(&calls))
+
+	first := post(handler, "/tasks", "abc", `{"title":"A"}`)
+	second := post(handler, "/tasks", "abc", `{"title":"A"}`)
+
+	if calls.Load() != 1 {
+		t.Errorf("expected the handler to run once, ran %d times", calls.Load())
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %d %s, got %d %s", first.Code, first.Body, second.Code, second.Body)
+	}
+	if second.Header().Get(HeaderReplayed) != "true" || first.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("expected only the replay to be marked, got %q and %q",
+			first.Header().Get(HeaderReplayed), second.Header().Get(HeaderReplayed))
+	}
+	if second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected stored headers to be replayed, got %v", second.Header())
+	}
+
+	// Outra chave e requisições sem chave executam normalmente
+	post(handler, "/tasks", "def", `{"title":"A"}`)
+	post(handler, "/tasks", "", `{"title":"A"}`)
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 executions, got %d", calls.Load())
+	}
+}
+
+func TestMiddlewareRejectsKeyReuse(t *testing.T) {
+	var calls atomic.Int32
+	handler := New(NewMemoryStore(), time.Hour).Handler(createHandler(&calls))
+
+	post(handler, "/tasks", "abc", `{"title":"A"}`)
+	tests := []struct {
+		name, target, body string
+	}{
+		{"different body", "/tasks", `{"title":"B"}`},
+		{"different path", "/tasks/1/comments", `{"title":"A"}`},
+		{"different query", "/tasks?template=1", `{"title":"A"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := post(handler, tt.target, "abc", tt.body); rec.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected status 422, got %d %s", rec.Code, rec.Body)
+			}
+		})
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the handler to run once, ran %d times", calls.Load())
+	}
+}
+
+func TestMiddlewareRejectsConcurrentRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := New(NewMemoryStore(), time.Hour).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post(handler, "/tasks", "abc", "{}") }()
+	<-started
+
+	rec := post(handler, "/tasks", "abc", "{}")
+	if rec.Code != http.StatusConflict || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("expected 409 with Retry-After, got %d %v", rec.Code, rec.Header())
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", first.Code)
+	}
+	if rec := post(handler, "/tasks", "abc", "{}"); rec.Code != http.StatusCreated {
+		t.Errorf("expected the stored 201 after completion, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareDoesNotStoreServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	handler := New(NewMemoryStore(), time.Hour).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	if rec := post(handler, "/tasks", "abc", "{}"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", rec.Code)
+	}
+	if rec := post(handler, "/tasks", "abc", "{}"); rec.Code != http.StatusCreated || calls.Load() != 2 {
+		t.Errorf("expected the retry to run the handler again, got %d after %d calls", rec.Code, calls.Load())
+	}
+}
+
+func TestMiddlewareReleasesKeyOnPanic(t *testing.T) {
+	store := NewMemoryStore()
+	handler := New(store, time.Hour).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() { recover() }()
+		post(handler, "/tasks", "abc", "{}")
+	}()
+
+	if existing, _ := store.Reserve(&Record{Key: "abc", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}); existing != nil {
+		t.Errorf("expected the key to be released, got %+v", existing)
+	}
+}
+
+func TestMiddlewareExpiresKeys(t *testing.T) {
+	var calls atomic.Int32
+	m := New(NewMemoryStore(), time.Hour)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	handler := m.Handler(createHandler(&calls))
+
+	post(handler, "/tasks", "abc", "{}")
+	now = now.Add(59 * time.Minute)
+	post(handler, "/tasks", "abc", "{}")
+	if calls.Load() != 1 {
+		t.Fatalf("expected the key to be valid within the TTL, ran %d times", calls.Load())
+	}
+
+	now = now.Add(time.Minute)
+	if rec := post(handler, "/tasks", "abc", `{"other":true}`); rec.Code != http.StatusCreated || calls.Load() != 2 {
+		t.Errorf("expected an expired key to be reusable, got %d after %d calls", rec.Code, calls.Load())
+	}
+}
+
+func TestMiddlewareIgnoresOtherRequests(t *testing.T) {
+	var calls atomic.Int32
+	handler := New(NewMemoryStore(), time.Hour).Handler(createHandler(&calls))
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPut, "/tasks/1", strings.NewReader("{}"))
+		req.Header.Set(HeaderKey, "abc")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected PUT requests to bypass the middleware, ran %d times", calls.Load())
+	}
+
+	for _, key := range []string{strings.Repeat("k", maxKeyLength+1), "tab\tkey", "acentuação"} {
+		if rec := post(handler, "/tasks", key, "{}"); rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for key %q, got %d", key, rec.Code)
+		}
+	}
+}
+
+func TestMiddlewareLimitsBodySize(t *testing.T) {
+	var calls atomic.Int32
+	handler := New(NewMemoryStore(), time.Hour).Handler(createHandler(&calls))
+
+	rec := post(handler, "/tasks", "big", strings.Repeat("a", MaxBodySize+1))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", rec.Code)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("expected the handler not to run, got %d calls", calls.Load())
+	}
+}
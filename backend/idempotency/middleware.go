@@ -0,0 +1,214 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/acauhi/kanban-backend/problem"
+)
+
+const (
+	// HeaderKey é o header com a chave escolhida pelo cliente
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed marca as respostas repetidas a partir do registro
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// MaxBodySize é o maior corpo guardado para comparar as repetições, o
+	// mesmo limite dos corpos JSON da API
+	MaxBodySize = 1 << 20
+
+	maxKeyLength = 255
+	// inProgressRetryAfter é a espera sugerida, em segundos, enquanto a
+	// primeira requisição com a chave não termina
+	inProgressRetryAfter = 1
+)
+
+// Códigos em "code" dos erros do middleware
+const (
+	codeInvalidKey        = "invalid_idempotency_key"
+	codeKeyReused         = "idempotency_key_reused"
+	codeRequestInProgress = "idempotent_request_in_progress"
+)
+
+// Middleware repete a primeira resposta de requisições POST enviadas com a
+// mesma Idempotency-Key, para que novas tentativas do cliente não criem
+// recursos duplicados
+type Middleware struct {
+	store Store
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// New cria o middleware; as respostas ficam guardadas por ttl
+func New(store Store, ttl time.Duration) *Middleware {
+	return &Middleware{
+		store: store,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// Handler aplica as chaves às requisições POST. Os demais métodos já são
+// idempotentes e seguem direto, assim como POST sem o header.
+//
+// A chave usada com outro método, caminho ou corpo responde 422 e, enquanto
+// a primeira requisição não termina, 409 com Retry-After. Respostas 5xx não
+// são guardadas, então a tentativa seguinte executa a requisição de novo.
+//
+// O corpo é lido inteiro para comparar as repetições e corpos acima de
+// MaxBodySize respondem 413; por isso o middleware deve envolver só rotas de
+// corpo JSON, não uploads.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(HeaderKey)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validKey(key) {
+			problem.Error(w, http.StatusBadRequest, codeInvalidKey, "Idempotency-Key must have 1 to 255 printable ASCII characters")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			problem.Error(w, http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge, "request body exceeds the maximum size")
+			return
+		}
+		if err != nil {
+			problem.Error(w, http.StatusBadRequest, problem.CodeInvalidRequestBody, "invalid request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := m.now()
+		record := &Record{
+			Key:         key,
+			Fingerprint: fingerprint(r, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(m.ttl),
+		}
+		existing, err := m.store.Reserve(record)
+		if err != nil {
+			log.Printf("idempotency: reserve %q: %v", key, err)
+			problem.Error(w, http.StatusInternalServerError, problem.CodeInternalError, "internal server error")
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != record.Fingerprint:
+				problem.Error(w, http.StatusUnprocessableEntity, codeKeyReused, "Idempotency-Key was already used with a different request")
+			case existing.Response == nil:
+				w.Header().Set("Retry-After", strconv.Itoa(inProgressRetryAfter))
+				problem.Error(w, http.StatusConflict, codeRequestInProgress, "a request with this Idempotency-Key is still in progress")
+			default:
+				replay(w, existing.Response)
+			}
+			return
+		}
+
+		m.serve(w, r, next, key)
+	})
+}
+
+// serve executa a primeira requisição com a chave e guarda a resposta. A
+// chave é liberada se a resposta não puder ser guardada ou o handler entrar
+// em pânico.
+func (m *Middleware) serve(w http.ResponseWriter, r *http.Request, next http.Handler, key string) {
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := m.store.Release(key); err != nil {
+			log.Printf("idempotency: release %q: %v", key, err)
+		}
+	}()
+
+	recorder := &responseRecorder{ResponseWriter: w}
+	next.ServeHTTP(recorder, r)
+
+	response := recorder.response()
+	if response.StatusCode >= http.StatusInternalServerError {
+		return
+	}
+	if err := m.store.Complete(key, response); err != nil {
+		log.Printf("idempotency: complete %q: %v", key, err)
+		return
+	}
+	completed = true
+}
+
+// validKey aceita de 1 a 255 caracteres ASCII visíveis
+func validKey(key string) bool {
+	if len(key) > maxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < ' ' || key[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerprint identifica a requisição pelo método, caminho com query string e corpo
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replay escreve a resposta guardada
+func replay(w http.ResponseWriter, response *Response) {
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(response.StatusCode)
+	w.Write(response.Body)
+}
+
+// responseRecorder repassa a resposta ao cliente e guarda uma cópia
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header
+	body       bytes.Buffer
+}
+
+// WriteHeader registra o status e os headers enviados
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode != 0 {
+		return
+	}
+	r.statusCode = statusCode
+	r.header = r.ResponseWriter.Header().Clone()
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write copia o corpo antes de repassá-lo
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// response monta a resposta a guardar
+func (r *responseRecorder) response() *Response {
+	if r.statusCode == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+	return &Response{StatusCode: r.statusCode, Header: r.header, Body: r.body.Bytes()}
+}
@@ -0,0 +1,97 @@
+package idempotency
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// sweepInterval é o intervalo mínimo entre as limpezas de chaves expiradas
+const sweepInterval = time.Minute
+
+// Record é o estado de uma Idempotency-Key: a impressão digital da primeira
+// requisição e, depois que ela termina, a resposta a repetir
+type Record struct {
+	Key         string
+	Fingerprint string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	// Response fica nil enquanto a primeira requisição está em andamento
+	Response *Response
+}
+
+// Response é a resposta guardada para ser repetida
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Store guarda os registros das chaves até expirarem
+type Store interface {
+	// Reserve grava o registro, ainda sem resposta, se a chave estiver livre
+	// (ou o registro anterior tiver expirado em record.CreatedAt) e retorna
+	// nil; caso contrário retorna o registro existente
+	Reserve(record *Record) (*Record, error)
+	// Complete guarda a resposta da requisição que reservou a chave
+	Complete(key string, response *Response) error
+	// Release libera a chave para que a requisição possa ser refeita
+	Release(key string) error
+}
+
+// MemoryStore guarda os registros em memória; eles se perdem ao reiniciar
+type MemoryStore struct {
+	records   map[string]*Record
+	nextSweep time.Time
+	mu        sync.Mutex
+}
+
+// NewMemoryStore cria um Store em memória
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*Record),
+	}
+}
+
+// Reserve grava o registro se a chave estiver livre e remove, no máximo uma
+// vez por minuto, as chaves já expiradas
+func (s *MemoryStore) Reserve(record *Record) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := record.CreatedAt
+	if now.After(s.nextSweep) {
+		for key, existing := range s.records {
+			if !existing.ExpiresAt.After(now) {
+				delete(s.records, key)
+			}
+		}
+		s.nextSweep = now.Add(sweepInterval)
+	}
+
+	if existing, ok := s.records[record.Key]; ok && existing.ExpiresAt.After(now) {
+		clone := *existing
+		return &clone, nil
+	}
+	clone := *record
+	s.records[record.Key] = &clone
+	return nil, nil
+}
+
+// Complete guarda a resposta no registro da chave
+func (s *MemoryStore) Complete(key string, response *Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.records[key]; ok {
+		record.Response = response
+	}
+	return nil
+}
+
+// Release remove o registro da chave
+func (s *MemoryStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
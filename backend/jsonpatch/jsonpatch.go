@@ -0,0 +1,392 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch indica um documento de patch mal formado
+	ErrInvalidPatch = errors.New("invalid patch document")
+	// ErrPathNotFound indica um caminho que não existe no documento
+	ErrPathNotFound = errors.New("path not found")
+	// ErrTestFailed indica uma operação "test" cujo valor não confere
+	ErrTestFailed = errors.New("test operation failed")
+	// ErrTooManyOperations indica um patch com mais de MaxOperations operações
+	ErrTooManyOperations = errors.New("too many patch operations")
+	// ErrDocumentTooLarge indica um resultado maior que MaxDocumentSize
+	ErrDocumentTooLarge = errors.New("patched document too large")
+)
+
+const (
+	// MaxOperations é o maior número de operações aceito em um patch
+	MaxOperations = 100
+	// MaxDocumentSize limita o documento resultante, como o corpo das
+	// requisições: copy e add podem multiplicar o tamanho do documento a cada
+	// operação
+	MaxDocumentSize = 1 << 20
+)
+
+// operation é uma operação do JSON Patch, ex: {"op": "add", "path": "/labels/-", "value": "1"}
+type operation struct {
+	op    string
+	path  []string
+	from  []string
+	value any
+}
+
+// Apply aplica um JSON Patch (RFC 6902) ao documento. As operações são
+// aplicadas em ordem e, se alguma falhar, nenhuma alteração é devolvida.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &raw); err != nil {
+		return nil, fmt.Errorf("%w: must be an array of operations", ErrInvalidPatch)
+	}
+	if len(raw) > MaxOperations {
+		return nil, fmt.Errorf("%w: %d operations, the maximum is %d", ErrTooManyOperations, len(raw), MaxOperations)
+	}
+	ops := make([]operation, len(raw))
+	for i, fields := range raw {
+		op, err := parseOperation(fields)
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+		}
+		ops[i] = op
+	}
+
+	var root any
+	if err := json.Unmarshal(doc, &root); err != nil {
+		return nil, err
+	}
+	// size é um limite superior do documento codificado: cresce com cada
+	// valor inserido e não diminui com as remoções
+	size := len(doc)
+	for i, op := range ops {
+		added, err := op.apply(&root)
+		if err == nil {
+			size, err = grow(size, added)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.op, formatPointer(op.path), err)
+		}
+	}
+	result, err := json.Marshal(root)
+	if err == nil && len(result) > MaxDocumentSize {
+		err = fmt.Errorf("%w: %d bytes, the maximum is %d", ErrDocumentTooLarge, len(result), MaxDocumentSize)
+	}
+	return result, err
+}
+
+// grow soma ao tamanho estimado o valor inserido por uma operação
+func grow(size int, added any) (int, error) {
+	if added == nil {
+		return size, nil
+	}
+	encoded, err := json.Marshal(added)
+	if err != nil {
+		return size, err
+	}
+	size += len(encoded)
+	if size > MaxDocumentSize {
+		return size, fmt.Errorf("%w: the result would exceed %d bytes", ErrDocumentTooLarge, MaxDocumentSize)
+	}
+	return size, nil
+}
+
+// parseOperation lê os membros da operação, exigindo os que o op usa
+func parseOperation(fields map[string]json.RawMessage) (operation, error) {
+	var op operation
+	if err := json.Unmarshal(fields["op"], &op.op); err != nil {
+		return op, errors.New(`"op" must be a string`)
+	}
+	var err error
+	if op.path, err = pointerField(fields, "path"); err != nil {
+		return op, err
+	}
+
+	switch op.op {
+	case "add", "replace", "test":
+		value, ok := fields["value"]
+		if !ok {
+			return op, fmt.Errorf(`"value" is required for %q`, op.op)
+		}
+		if err := json.Unmarshal(value, &op.value); err != nil {
+			return op, err
+		}
+	case "move", "copy":
+		if op.from, err = pointerField(fields, "from"); err != nil {
+			return op, err
+		}
+	case "remove":
+	default:
+		return op, fmt.Errorf("unknown op %q", op.op)
+	}
+	return op, nil
+}
+
+// pointerField lê um membro obrigatório com um JSON Pointer
+func pointerField(fields map[string]json.RawMessage, name string) ([]string, error) {
+	var pointer string
+	if err := json.Unmarshal(fields[name], &pointer); err != nil {
+		return nil, fmt.Errorf("%q must be a string", name)
+	}
+	return parsePointer(pointer)
+}
+
+// parsePointer separa um JSON Pointer (RFC 6901) em tokens; "" é o documento inteiro
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// formatPointer remonta o JSON Pointer para as mensagens de erro
+func formatPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// apply executa a operação sobre o documento em root e retorna o valor que
+// ela inseriu, para a estimativa de tamanho; move não aumenta o documento
+func (op operation) apply(root *any) (any, error) {
+	var err error
+	switch op.op {
+	case "add":
+		*root, err = add(*root, op.path, op.value)
+		return op.value, err
+	case "remove":
+		if len(op.path) == 0 {
+			return nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+		}
+		*root, _, err = remove(*root, op.path)
+		return nil, err
+	case "replace":
+		*root, err = replace(*root, op.path, op.value)
+		return op.value, err
+	case "move":
+		if isPrefix(op.from, op.path) && len(op.from) < len(op.path) {
+			return nil, fmt.Errorf("%w: cannot move a value into one of its children", ErrInvalidPatch)
+		}
+		updated, value, err := remove(*root, op.from)
+		if err != nil {
+			return nil, err
+		}
+		*root, err = add(updated, op.path, value)
+		return nil, err
+	case "copy":
+		value, err := get(*root, op.from)
+		if err != nil {
+			return nil, err
+		}
+		value = deepCopy(value)
+		*root, err = add(*root, op.path, value)
+		return value, err
+	case "test":
+		value, err := get(*root, op.path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, op.value) {
+			return nil, ErrTestFailed
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.op)
+}
+
+// get retorna o valor no caminho
+func get(root any, path []string) (any, error) {
+	if len(path) == 0 {
+		return root, nil
+	}
+	var value any
+	_, err := walk(root, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			member, ok := node[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			value = member
+		case []any:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			value = node[i]
+		}
+		return parent, nil
+	})
+	return value, err
+}
+
+// add insere o valor no caminho; em arrays, desloca os itens seguintes e "-"
+// acrescenta ao final
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return walk(root, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			i := len(node)
+			if token != "-" {
+				var err error
+				if i, err = arrayIndex(token, len(node)); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(node, i, value), nil
+		}
+		return nil, ErrPathNotFound
+	})
+}
+
+// remove retira o valor do caminho, devolvendo-o junto com o novo documento
+func remove(root any, path []string) (any, any, error) {
+	var removed any
+	root, err := walk(root, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			member, ok := node[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			removed = member
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return slices.Delete(node, i, i+1), nil
+		}
+		return nil, ErrPathNotFound
+	})
+	return root, removed, err
+}
+
+// replace troca um valor existente
+func replace(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return walk(root, path, func(parent any, token string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, ErrPathNotFound
+			}
+			node[token] = value
+			return node, nil
+		case []any:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = value
+			return node, nil
+		}
+		return nil, ErrPathNotFound
+	})
+}
+
+// walk desce até o objeto ou array que contém o último token do caminho e
+// chama leaf com ele; o container devolvido por leaf substitui o original
+func walk(node any, path []string, leaf func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 0 {
+		return node, nil
+	}
+	if len(path) == 1 {
+		switch node.(type) {
+		case map[string]any, []any:
+			return leaf(node, path[0])
+		}
+		return nil, ErrPathNotFound
+	}
+
+	switch parent := node.(type) {
+	case map[string]any:
+		child, ok := parent[path[0]]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		updated, err := walk(child, path[1:], leaf)
+		if err != nil {
+			return nil, err
+		}
+		parent[path[0]] = updated
+		return parent, nil
+	case []any:
+		i, err := arrayIndex(path[0], len(parent)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := walk(parent[i], path[1:], leaf)
+		if err != nil {
+			return nil, err
+		}
+		parent[i] = updated
+		return parent, nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// arrayIndex interpreta o token como índice entre 0 e max, sem zeros à esquerda
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.Trim(token, "0123456789") != "" {
+		return 0, ErrPathNotFound
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i > max {
+		return 0, ErrPathNotFound
+	}
+	return i, nil
+}
+
+// isPrefix indica se prefix é o início de path
+func isPrefix(prefix, path []string) bool {
+	return len(prefix) <= len(path) && slices.Equal(prefix, path[:len(prefix)])
+}
+
+// deepCopy copia objetos e arrays para que o valor copiado não compartilhe
+// estrutura com a origem
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, member := range v {
+			copied[key] = deepCopy(member)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,174 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+// assertJSON compara os documentos pelo valor, ignorando a ordem dos membros
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+// Exemplos do Apêndice A da RFC 6902
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name, doc, patch, want string
+	}{
+		{"add object member", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"baz": "qux", "foo": "bar"}`},
+		{"add array element", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{"remove object member", `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{"remove array element", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{"replace value", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{
+			"move value",
+			`{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{"move array element", `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"add nested member", `{"foo": "bar"}`, `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`, `{"foo": "bar", "child": {"grandchild": {}}}`},
+		{"add array value", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{"ignore unknown members", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`, `{"foo": "bar", "baz": "qux"}`},
+		{"escaped pointer", `{"/": 9, "~1": 10}`, `[{"op": "test", "path": "/~01", "value": 10}, {"op": "remove", "path": "/~1"}]`, `{"~1": 10}`},
+		{"copy value", `{"foo": {"bar": [1]}}`, `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "add", "path": "/baz/bar/-", "value": 2}]`, `{"foo": {"bar": [1]}, "baz": {"bar": [1, 2]}}`},
+		{"test then replace", `{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/foo", "value": ["a", 2, "c"]}, {"op": "replace", "path": "/baz", "value": null}]`, `{"baz": null, "foo": ["a", 2, "c"]}`},
+		{"replace whole document", `{"foo": "bar"}`, `[{"op": "replace", "path": "", "value": {"baz": 1}}]`, `{"baz": 1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf(msgExpectedNoError, err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name, doc, patch string
+		want             error
+	}{
+		{"not an array", `{}`, `{"op": "add"}`, ErrInvalidPatch},
+		{"unknown op", `{}`, `[{"op": "merge", "path": "/a"}]`, ErrInvalidPatch},
+		{"missing value", `{}`, `[{"op": "add", "path": "/a"}]`, ErrInvalidPatch},
+		{"missing from", `{}`, `[{"op": "copy", "path": "/a"}]`, ErrInvalidPatch},
+		{"relative pointer", `{}`, `[{"op": "remove", "path": "a"}]`, ErrInvalidPatch},
+		{"move into child", `{"a": {"b": 1}}`, `[{"op": "move", "from": "/a", "path": "/a/b/c"}]`, ErrInvalidPatch},
+		{"remove missing member", `{"foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, ErrPathNotFound},
+		{"replace missing member", `{"foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": 1}]`, ErrPathNotFound},
+		{"add to missing parent", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`, ErrPathNotFound},
+		{"index out of bounds", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/2", "value": 1}]`, ErrPathNotFound},
+		{"leading zero index", `{"foo": ["a", "b"]}`, `[{"op": "remove", "path": "/foo/01"}]`, ErrPathNotFound},
+		{"dash outside add", `{"foo": ["a"]}`, `[{"op": "remove", "path": "/foo/-"}]`, ErrPathNotFound},
+		{"test mismatch", `{"baz": "qux"}`, `[{"op": "test", "path": "/baz", "value": "bar"}]`, ErrTestFailed},
+		{"test number against string", `{"/": 9}`, `[{"op": "test", "path": "/~1", "value": "9"}]`, ErrTestFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Apply([]byte(tt.doc), []byte(tt.patch)); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestApplyIsAtomic(t *testing.T) {
+	doc := []byte(`{"foo": ["a"]}`)
+	_, err := Apply(doc, []byte(`[{"op": "add", "path": "/foo/-", "value": "b"}, {"op": "test", "path": "/foo/0", "value": "z"}]`))
+	if !errors.Is(err, ErrTestFailed) {
+		t.Fatalf("expected %v, got %v", ErrTestFailed, err)
+	}
+	if err.Error() != "operation 1 (test /foo/0): test operation failed" {
+		t.Errorf("unexpected message %q", err)
+	}
+	assertJSON(t, doc, `{"foo": ["a"]}`)
+}
+
+func TestApplyLimitsSize(t *testing.T) {
+	// Cada copy dobra o array: 22 operações chegariam a dezenas de MiB
+	doubling := make([]string, 22)
+	for i := range doubling {
+		doubling[i] = `{"op": "copy", "from": "/a", "path": "/a/-"}`
+	}
+	tooMany := make([]string, MaxOperations+1)
+	for i := range tooMany {
+		tooMany[i] = `{"op": "test", "path": "/a", "value": [1]}`
+	}
+
+	tests := []struct {
+		name, doc, patch string
+		want             error
+	}{
+		{"doubling copies", `{"a": [1]}`, "[" + strings.Join(doubling, ",") + "]", ErrDocumentTooLarge},
+		{"large added value", `{}`, `[{"op": "add", "path": "/a", "value": "` + strings.Repeat("x", MaxDocumentSize) + `"}]`, ErrDocumentTooLarge},
+		{"too many operations", `{"a": [1]}`, "[" + strings.Join(tooMany, ",") + "]", ErrTooManyOperations},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Apply([]byte(tt.doc), []byte(tt.patch)); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := Apply([]byte(`{"a": [1]}`), []byte("["+strings.Join(doubling[:10], ",")+"]")); err != nil {
+		t.Errorf(msgExpectedNoError, err)
+	}
+}
+
+// Exemplos do Apêndice A da RFC 7396
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.patch, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf(msgExpectedNoError, err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+
+	if _, err := MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("expected %v, got %v", ErrInvalidPatch, err)
+	}
+}
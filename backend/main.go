@@ -1,26 +1,197 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // fusos horários das tarefas recorrentes mesmo em imagens sem tzdata
 
+	"github.com/acauhi/kanban-backend/compression"
+	"github.com/acauhi/kanban-backend/exchange"
+	"github.com/acauhi/kanban-backend/graphql"
+	"github.com/acauhi/kanban-backend/grpcapi"
 	"github.com/acauhi/kanban-backend/handlers"
+	"github.com/acauhi/kanban-backend/idempotency"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/openapi"
+	"github.com/acauhi/kanban-backend/problem"
+	"github.com/acauhi/kanban-backend/report"
 	"github.com/acauhi/kanban-backend/repository"
 	"github.com/acauhi/kanban-backend/service"
+	"github.com/acauhi/kanban-backend/storage"
+	"github.com/acauhi/kanban-backend/versioning"
+)
+
+const (
+	defaultArchiveAfterDays = 7
+	defaultArchiveInterval  = time.Hour
+	defaultAttachmentsDir   = "data/attachments"
+	defaultRecurringCheck   = time.Minute
+	defaultIdempotencyTTL   = 24 * time.Hour
+
+	defaultGRPCAddr = ":9090"
+
+	defaultGraphQLMaxDepth      = 8
+	defaultGraphQLMaxComplexity = 1000
+
+	// apiV1Prefix é o prefixo da versão atual da API REST
+	apiV1Prefix = "/api/v1"
+)
+
+// Datas padrão da descontinuação das rotas REST sem versão
+var (
+	defaultLegacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	defaultLegacySunsetAt     = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
 )
 
 // main inicializa o servidor HTTP com todas as dependências
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(os.Args[2:]); err != nil {
+			if !errors.Is(err, flag.ErrHelp) {
+				log.Print(err)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
+	blobs, err := newBlobStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	repo := repository.NewInMemoryTaskRepository()
-	svc := service.NewTaskService(repo)
-	handler := handlers.NewTaskHandler(svc)
+	labelRepo := repository.NewInMemoryLabelRepository()
+	templateRepo := repository.NewInMemoryTaskTemplateRepository()
+	svc := service.NewTaskService(repo,
+		service.WithAutoStart(envBool("CHECKLIST_AUTO_START", false)),
+		service.WithLabelRepository(labelRepo),
+		service.WithCommentRepository(repository.NewInMemoryCommentRepository()),
+		service.WithAttachmentStorage(repository.NewInMemoryAttachmentRepository(), blobs),
+		service.WithTaskTemplateRepository(templateRepo),
+	)
+	// Requisições a /tasks são validadas contra o documento OpenAPI antes de chegar ao handler
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// POST /tasks com Idempotency-Key repete a primeira resposta por
+	// IDEMPOTENCY_TTL. Só a criação passa pelo middleware, que guarda o corpo
+	// em memória; uploads e importações seguem direto.
+	idempotent := idempotency.New(idempotency.NewMemoryStore(), envDuration("IDEMPOTENCY_TTL", defaultIdempotencyTTL))
+	tasks := spec.Middleware(handlers.NewTaskHandler(svc))
+	handler := http.NewServeMux()
+	handler.Handle("POST /tasks", idempotent.Handler(tasks))
+	handler.Handle("/", tasks)
+	recurringRepo := repository.NewInMemoryRecurringTaskRepository()
+	labels := service.NewLabelService(labelRepo, svc,
+		service.WithRecurringTasks(recurringRepo),
+		service.WithTaskTemplates(templateRepo),
+	)
+	labelHandler := handlers.NewLabelHandler(labels)
+	recurring := service.NewRecurringTaskService(recurringRepo, svc)
+	recurringHandler := handlers.NewRecurringTaskHandler(recurring)
+	templateHandler := handlers.NewTaskTemplateHandler(service.NewTaskTemplateService(templateRepo, svc))
 
+	// Templates dos relatórios podem ser sobrescritos pelos arquivos de REPORT_TEMPLATES_DIR
+	renderer, err := report.NewRenderer(os.Getenv("REPORT_TEMPLATES_DIR"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	reportHandler := handlers.NewReportHandler(svc, renderer)
+	calendarHandler := handlers.NewCalendarHandler(service.NewCalendarService(repository.NewInMemoryFeedTokenRepository(), svc))
+
+	// Consultas GraphQL limitadas por GRAPHQL_MAX_DEPTH e GRAPHQL_MAX_COMPLEXITY
+	graphqlHandler, err := handlers.NewGraphQLHandler(svc, labels, graphql.Limits{
+		MaxDepth:      envInt("GRAPHQL_MAX_DEPTH", defaultGraphQLMaxDepth),
+		MaxComplexity: envInt("GRAPHQL_MAX_COMPLEXITY", defaultGraphQLMaxComplexity),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Job de arquivamento configurável via ARCHIVE_AFTER_DAYS e ARCHIVE_INTERVAL
+	archiveAfter := time.Duration(envInt("ARCHIVE_AFTER_DAYS", defaultArchiveAfterDays)) * 24 * time.Hour
+	archiver := service.NewArchiver(svc, archiveAfter, envDuration("ARCHIVE_INTERVAL", defaultArchiveInterval))
+	go archiver.Start(context.Background())
+
+	// Agendador de tarefas recorrentes, verificado a cada RECURRING_CHECK_INTERVAL
+	go recurring.Start(context.Background(), envDuration("RECURRING_CHECK_INTERVAL", defaultRecurringCheck))
+
+	// Os recursos REST são servidos em /api/v1; os caminhos antigos sem versão
+	// continuam respondendo igual, com Deprecation, Sunset e Link apontando a
+	// rota equivalente em /api/v1. Uma /api/v2 monta os próprios handlers
+	// (ex: NewTaskHandler com WithTaskMapper) ao lado desta tabela.
+	rest := []struct {
+		pattern string
+		handler http.Handler
+	}{
+		{"/tasks", handler},
+		{"/tasks/", handler},
+		{"/labels", labelHandler},
+		{"/labels/", labelHandler},
+		{"/recurring-tasks", recurringHandler},
+		{"/recurring-tasks/", recurringHandler},
+		{"/task-templates", templateHandler},
+		{"/task-templates/", templateHandler},
+		{"/reports/", reportHandler},
+		{"/calendar.ics", calendarHandler},
+		{"/calendar/tokens", calendarHandler},
+		{"/calendar/tokens/", calendarHandler},
+	}
+	// Datas configuráveis via LEGACY_DEPRECATED_AT e LEGACY_SUNSET_AT (AAAA-MM-DD)
+	legacy := versioning.Deprecation{
+		Since:     envDate("LEGACY_DEPRECATED_AT", defaultLegacyDeprecatedAt),
+		Sunset:    envDate("LEGACY_SUNSET_AT", defaultLegacySunsetAt),
+		Successor: apiV1Prefix,
+	}
+
+	// Cada handler resolve os próprios caminhos com os padrões do http.ServeMux;
+	// aqui ficam só os prefixos atendidos por cada um
+	docs := openapi.NewHandler()
 	mux := http.NewServeMux()
-	mux.Handle("/tasks", corsMiddleware(handler))
-	mux.Handle("/tasks/", corsMiddleware(handler))
+	for _, mount := range rest {
+		mux.Handle(apiV1Prefix+mount.pattern, corsMiddleware(http.StripPrefix(apiV1Prefix, mount.handler)))
+		mux.Handle(mount.pattern, corsMiddleware(versioning.Deprecated(mount.handler, legacy)))
+	}
+	for _, mount := range []struct {
+		pattern string
+		handler http.Handler
+	}{
+		{"/graphql", graphqlHandler},
+		{"/graphql/", graphqlHandler},
+		{"/openapi.json", docs},
+		{"/docs", docs},
+		{"/docs/", docs},
+	} {
+		mux.Handle(mount.pattern, corsMiddleware(mount.handler))
+	}
+
+	// API gRPC (kanban.v1.TaskService) em uma segunda porta, configurável via GRPC_ADDR
+	grpcAddr := envString("GRPC_ADDR", defaultGRPCAddr)
+	go func() {
+		log.Printf("gRPC server starting on %s", grpcAddr)
+		if err := grpcapi.NewServer(svc).ListenAndServe(grpcAddr); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// Respostas comprimidas com gzip a partir de COMPRESSION_MIN_SIZE bytes
+	compressor := compression.New(compression.WithMinSize(envInt("COMPRESSION_MIN_SIZE", compression.DefaultMinSize)))
 
 	log.Println("Server starting on :8080")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
+	if err := http.ListenAndServe(":8080", compressor.Handler(mux)); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -30,8 +201,9 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Allowing all origins in development for convenience. Adjust for production.
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Idempotency-Key, If-None-Match, If-Modified-Since")
+		w.Header().Set("Access-Control-Expose-Headers", "Deprecation, Sunset, Link, ETag")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -41,3 +213,160 @@ func corsMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// runImport executa o subcomando "import [flags] ARQUIVO". Sem -server as
+// tarefas são criadas via TaskService em um quadro em memória que existe só
+// durante o comando, útil para conferir o mapeamento; com -server o arquivo é
+// enviado para POST /api/v1/tasks/import do servidor indicado. O relatório é
+// impresso em JSON na saída padrão.
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", exchange.FormatJSON, "formato do arquivo: csv, json, trello ou github")
+	dryRun := flags.Bool("dry-run", false, "apenas valida, sem criar tarefas")
+	preserveIDs := flags.Bool("preserve-ids", false, "mantém os IDs do arquivo")
+	server := flags.String("server", "", "URL de um servidor em execução, ex: http://localhost:8080")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: kanban-backend import [flags] FILE")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	opts := models.ImportOptions{DryRun: *dryRun, PreserveIDs: *preserveIDs}
+	var report *models.ImportReport
+	if *server != "" {
+		report, err = uploadImport(*server, file, *format, opts)
+	} else {
+		report, err = importLocal(file, *format, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", report.Failed, report.Total)
+	}
+	return nil
+}
+
+// importLocal importa o arquivo em um TaskService novo, em memória
+func importLocal(r io.Reader, format string, opts models.ImportOptions) (*models.ImportReport, error) {
+	rows, failures, err := exchange.Import(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := service.NewTaskService(repository.NewInMemoryTaskRepository(),
+		service.WithLabelRepository(repository.NewInMemoryLabelRepository()),
+	)
+	report, err := svc.ImportTasks(rows, opts)
+	if err != nil {
+		return nil, err
+	}
+	report.AddFailures(failures)
+	return report, nil
+}
+
+// uploadImport envia o arquivo para o endpoint de importação do servidor
+func uploadImport(server string, r io.Reader, format string, opts models.ImportOptions) (*models.ImportReport, error) {
+	query := url.Values{"format": {format}}
+	if opts.DryRun {
+		query.Set("dryRun", "true")
+	}
+	if opts.PreserveIDs {
+		query.Set("preserveIds", "true")
+	}
+
+	resp, err := http.Post(strings.TrimRight(server, "/")+apiV1Prefix+"/tasks/import?"+query.Encode(), "application/octet-stream", r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body problem.Problem
+		json.NewDecoder(resp.Body).Decode(&body)
+		return nil, fmt.Errorf("import failed: %s: %s", resp.Status, body.Detail)
+	}
+
+	var report models.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// newBlobStore escolhe onde guardar o conteúdo dos anexos conforme BLOB_STORE:
+// "local" (padrão, em ATTACHMENTS_DIR), "s3" (S3_ENDPOINT, S3_BUCKET,
+// S3_REGION, S3_ACCESS_KEY, S3_SECRET_KEY) ou "memory"
+func newBlobStore() (storage.BlobStore, error) {
+	switch os.Getenv("BLOB_STORE") {
+	case "s3":
+		return storage.NewS3BlobStore(storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		}, nil)
+	case "memory":
+		return storage.NewMemoryBlobStore(), nil
+	default:
+		return storage.NewLocalBlobStore(envString("ATTACHMENTS_DIR", defaultAttachmentsDir))
+	}
+}
+
+// envString lê uma string da variável de ambiente, usando o padrão se ausente
+func envString(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// envInt lê um inteiro positivo da variável de ambiente, usando o padrão se ausente ou inválido
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// envBool lê um booleano (ex: "true", "1") da variável de ambiente, usando o padrão se ausente ou inválido
+func envBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// envDuration lê uma duração (ex: "30m") da variável de ambiente, usando o padrão se ausente ou inválida
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// envDate lê uma data (ex: "2027-04-19", em UTC) da variável de ambiente, usando o padrão se ausente ou inválida
+func envDate(key string, fallback time.Time) time.Time {
+	value, err := time.Parse(time.DateOnly, os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
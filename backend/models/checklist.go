@@ -0,0 +1,29 @@
+package models
+
+type ChecklistItem struct {
+	ID      string `json:"id"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	Order   int    `json:"order"`
+}
+
+type AddChecklistItemRequest struct {
+	Text string `json:"text"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text    *string `json:"text,omitempty"`
+	Checked *bool   `json:"checked,omitempty"`
+}
+
+type ReorderChecklistRequest struct {
+	ItemIDs []string `json:"itemIds"`
+}
+
+// ChecklistItemInput é um item na substituição da tarefa: sem ID cria um item
+// novo, e a posição na lista define a ordem
+type ChecklistItemInput struct {
+	ID      string `json:"id,omitempty"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
@@ -0,0 +1,11 @@
+package models
+
+type AddDependencyRequest struct {
+	BlockerID string `json:"blockerId"`
+}
+
+type TaskDependencies struct {
+	TaskID    string  `json:"taskId"`
+	BlockedBy []*Task `json:"blockedBy"`
+	Blocks    []*Task `json:"blocks"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// FeedToken dá acesso de leitura ao feed de calendário em nome de um usuário.
+// O token em texto só é devolvido na criação; o repositório guarda apenas o hash.
+type FeedToken struct {
+	ID        string    `json:"id"`
+	User      string    `json:"user"`
+	Token     string    `json:"token,omitempty"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreateFeedTokenRequest struct {
+	User string `json:"user"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// ImportTaskRow é uma tarefa lida de um arquivo de importação
+type ImportTaskRow struct {
+	Row         int             `json:"-"`
+	ID          string          `json:"id,omitempty"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Status      Status          `json:"status,omitempty"`
+	Priority    Priority        `json:"priority,omitempty"`
+	DueDate     *time.Time      `json:"dueDate,omitempty"`
+	Checklist   []ChecklistItem `json:"checklist,omitempty"`
+	// Labels são IDs de etiquetas deste quadro; os arquivos exportados trazem
+	// as etiquetas pelo nome, em LabelsByName
+	Labels []string `json:"-"`
+	// Completed e Archived ficam nil quando a origem não os informa
+	Completed *bool `json:"completed,omitempty"`
+	Archived  *bool `json:"archived,omitempty"`
+
+	// LabelsByName são etiquetas de um arquivo exportado ou de outro sistema,
+	// associadas pelo nome e criadas no quadro quando ainda não existem
+	LabelsByName []CreateLabelRequest `json:"-"`
+}
+
+// ExportTask é a tarefa como exportada: as etiquetas vão pelo nome e cor,
+// para que o arquivo possa ser importado em outro ambiente
+type ExportTask struct {
+	Task   *Task
+	Labels []CreateLabelRequest
+}
+
+type ImportOptions struct {
+	DryRun      bool
+	PreserveIDs bool
+}
+
+type ImportRowResult struct {
+	Row   int    `json:"row"`
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title,omitempty"`
+	Error string `json:"error,omitempty"`
+	// Field é o campo da linha que causou o erro, quando conhecido
+	Field string `json:"field,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool              `json:"dryRun"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// AddFailures inclui no relatório linhas que falharam antes da validação
+// (ex: erros de leitura do arquivo), mantendo a ordem das linhas
+func (r *ImportReport) AddFailures(failures []ImportRowResult) {
+	if len(failures) == 0 {
+		return
+	}
+	r.Rows = append(r.Rows, failures...)
+	r.Total += len(failures)
+	r.Failed += len(failures)
+	sort.SliceStable(r.Rows, func(i, j int) bool { return r.Rows[i].Row < r.Rows[j].Row })
+}
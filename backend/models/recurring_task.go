@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type RecurringTask struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Priority    Priority   `json:"priority,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	Schedule    string     `json:"schedule"`
+	Timezone    string     `json:"timezone"`
+	Enabled     bool       `json:"enabled"`
+	LastRunAt   *time.Time `json:"lastRunAt,omitempty"`
+	NextRunAt   *time.Time `json:"nextRunAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type CreateRecurringTaskRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Priority    Priority `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Schedule    string   `json:"schedule"`
+	Timezone    string   `json:"timezone,omitempty"`
+	Enabled     *bool    `json:"enabled,omitempty"`
+}
+
+type UpdateRecurringTaskRequest struct {
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Priority    *Priority `json:"priority,omitempty"`
+	Labels      *[]string `json:"labels,omitempty"`
+	Schedule    *string   `json:"schedule,omitempty"`
+	Timezone    *string   `json:"timezone,omitempty"`
+	Enabled     *bool     `json:"enabled,omitempty"`
+}
+
+// Occurrence identifica a ocorrência de uma tarefa recorrente que gerou uma tarefa
+type Occurrence struct {
+	RecurringTaskID string    `json:"recurringTaskId"`
+	ScheduledAt     time.Time `json:"scheduledAt"`
+}
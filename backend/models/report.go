@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// BoardReport é o retrato do quadro usado nos relatórios de status
+type BoardReport struct {
+	GeneratedAt time.Time
+	Since       time.Time
+	Total       int
+	Columns     []BoardColumn
+	// Completed lista as tarefas concluídas desde Since, inclusive as já arquivadas
+	Completed []ReportTask
+}
+
+// BoardColumn agrupa as tarefas ativas de um status
+type BoardColumn struct {
+	Status Status
+	Name   string
+	Tasks  []ReportTask
+}
+
+// Count retorna a quantidade de tarefas da coluna
+func (c BoardColumn) Count() int {
+	return len(c.Tasks)
+}
+
+// ReportTask é a tarefa com os nomes das etiquetas já resolvidos
+type ReportTask struct {
+	*Task
+	LabelNames []string
+}
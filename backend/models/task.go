@@ -1,5 +1,10 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type Status string
 
 const (
@@ -8,21 +13,91 @@ const (
 	StatusDone       Status = "done"
 )
 
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
 type Task struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	Status      Status `json:"status"`
-	Completed   bool   `json:"completed"`
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Status      Status          `json:"status"`
+	Completed   bool            `json:"completed"`
+	CompletedAt *time.Time      `json:"completedAt,omitempty"`
+	Archived    bool            `json:"archived"`
+	ArchivedAt  *time.Time      `json:"archivedAt,omitempty"`
+	Checklist   []ChecklistItem `json:"checklist,omitempty"`
+	Labels      []string        `json:"labels,omitempty"`
+	Priority    Priority        `json:"priority"`
+	DueDate     *time.Time      `json:"dueDate,omitempty"`
+	Occurrence  *Occurrence     `json:"occurrence,omitempty"`
+}
+
+// Progress retorna o percentual (0-100) de itens marcados no checklist
+func (t *Task) Progress() int {
+	if len(t.Checklist) == 0 {
+		return 0
+	}
+	checked := 0
+	for _, item := range t.Checklist {
+		if item.Checked {
+			checked++
+		}
+	}
+	return checked * 100 / len(t.Checklist)
+}
+
+// MarshalJSON inclui o progresso derivado do checklist na resposta
+func (t Task) MarshalJSON() ([]byte, error) {
+	type taskAlias Task
+	return json.Marshal(struct {
+		taskAlias
+		Progress int `json:"progress"`
+	}{
+		taskAlias: taskAlias(t),
+		Progress:  t.Progress(),
+	})
 }
 
 type CreateTaskRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	Priority    Priority   `json:"priority,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	Checklist   []string   `json:"checklist,omitempty"`
+	// ID e Occurrence são preenchidos apenas internamente (importação e
+	// agendador de recorrências) e não podem ser enviados pela API
+	ID         string      `json:"-"`
+	Occurrence *Occurrence `json:"-"`
+}
+
+// ReplaceTaskRequest é a representação completa dos campos editáveis da
+// tarefa, usada no PUT e como documento-alvo do PATCH. Campos ausentes
+// assumem os padrões da criação: status "todo", prioridade "medium" e sem
+// descrição, etiquetas, prazo ou checklist.
+type ReplaceTaskRequest struct {
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Status      Status               `json:"status"`
+	Labels      []string             `json:"labels"`
+	Priority    Priority             `json:"priority"`
+	DueDate     *time.Time           `json:"dueDate"`
+	Checklist   []ChecklistItemInput `json:"checklist"`
 }
 
+// UpdateTaskRequest altera apenas os campos informados (GraphQL, gRPC e o
+// merge patch enviado pelo pacote client)
 type UpdateTaskRequest struct {
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Status      *Status `json:"status,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Status      *Status    `json:"status,omitempty"`
+	Labels      *[]string  `json:"labels,omitempty"`
+	Priority    *Priority  `json:"priority,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
 }
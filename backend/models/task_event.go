@@ -0,0 +1,16 @@
+package models
+
+type TaskEventType string
+
+const (
+	TaskCreated TaskEventType = "created"
+	TaskUpdated TaskEventType = "updated"
+	TaskDeleted TaskEventType = "deleted"
+)
+
+// TaskEvent é uma alteração em uma tarefa; Task é nil quando a tarefa foi removida
+type TaskEvent struct {
+	Type   TaskEventType `json:"type"`
+	TaskID string        `json:"taskId"`
+	Task   *Task         `json:"task,omitempty"`
+}
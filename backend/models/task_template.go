@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+type TaskTemplate struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Priority    Priority  `json:"priority,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	Checklist   []string  `json:"checklist,omitempty"`
+	Variables   []string  `json:"variables"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type CreateTaskTemplateRequest struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Priority    Priority `json:"priority,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Checklist   []string `json:"checklist,omitempty"`
+}
+
+type UpdateTaskTemplateRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Priority    *Priority `json:"priority,omitempty"`
+	Labels      *[]string `json:"labels,omitempty"`
+	Checklist   *[]string `json:"checklist,omitempty"`
+}
+
+type InstantiateTemplateRequest struct {
+	Variables map[string]string `json:"variables,omitempty"`
+}
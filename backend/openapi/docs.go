@@ -0,0 +1,64 @@
+package openapi
+
+import (
+	"embed"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/problem"
+)
+
+// docsFiles é a página de documentação com os seus scripts e estilos,
+// embutidos no binário para que /docs funcione sem internet
+//
+//go:embed docs
+var docsFiles embed.FS
+
+// docsPolicy só permite arquivos do próprio servidor na página
+const docsPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; connect-src 'self'; img-src 'self' data:"
+
+// Handler publica o documento em /openapi.json e a página de documentação em /docs
+type Handler struct{}
+
+// NewHandler cria uma nova instância do handler da documentação
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// ServeHTTP responde o documento ou a página conforme o caminho
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		problem.Error(w, http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	switch r.URL.Path {
+	case "/openapi.json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	case "/docs", "/docs/":
+		serveDocsFile(w, "index.html")
+	default:
+		name, ok := strings.CutPrefix(r.URL.Path, "/docs/")
+		if !ok || !serveDocsFile(w, name) {
+			problem.Error(w, http.StatusNotFound, problem.CodeResourceNotFound, "resource not found")
+		}
+	}
+}
+
+// serveDocsFile responde um arquivo da página de documentação; retorna false
+// se ele não existe
+func serveDocsFile(w http.ResponseWriter, name string) bool {
+	content, err := docsFiles.ReadFile(path.Join("docs", path.Clean("/"+name)))
+	if err != nil {
+		return false
+	}
+	header := w.Header()
+	header.Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+	header.Set("Content-Security-Policy", docsPolicy)
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.Write(content)
+	return true
+}
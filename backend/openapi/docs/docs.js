@@ -0,0 +1,167 @@
+// Página de documentação da API: lê o documento OpenAPI e lista as operações
+// por tag e os schemas, sem depender de arquivos externos.
+"use strict";
+
+const methods = ["get", "post", "put", "patch", "delete", "head", "options"];
+
+// el cria um elemento com atributos e filhos; textos viram nós de texto
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [name, value] of Object.entries(attrs || {})) {
+    node.setAttribute(name, value);
+  }
+  for (const child of children.flat()) {
+    if (child !== null && child !== undefined && child !== "") {
+      node.append(child instanceof Node ? child : String(child));
+    }
+  }
+  return node;
+}
+
+// refName retorna o nome do schema de um $ref local
+function refName(ref) {
+  return ref.replace("#/components/schemas/", "");
+}
+
+// typeOf descreve o tipo do schema em uma linha, com link para os schemas nomeados
+function typeOf(schema) {
+  if (!schema) {
+    return el("span", { class: "type" }, "qualquer");
+  }
+  if (schema.$ref) {
+    const name = refName(schema.$ref);
+    return el("a", { href: "#schema-" + name, class: "type" }, name);
+  }
+  for (const key of ["oneOf", "anyOf", "allOf"]) {
+    if (schema[key]) {
+      const parts = schema[key].map(typeOf);
+      return el("span", {}, parts.flatMap((part, i) => (i ? [key === "allOf" ? " & " : " | ", part] : [part])));
+    }
+  }
+  if (schema.type === "array") {
+    return el("span", {}, typeOf(schema.items), el("span", { class: "type" }, "[]"));
+  }
+  let text = schema.type || (schema.properties ? "object" : "qualquer");
+  if (schema.format) {
+    text += " (" + schema.format + ")";
+  }
+  const parts = [el("span", { class: "type" }, text)];
+  if (schema.enum) {
+    parts.push(" ", el("code", {}, schema.enum.map((value) => JSON.stringify(value)).join(" | ")));
+  }
+  return el("span", {}, parts);
+}
+
+// constraints resume limites como maxLength e pattern
+function constraints(schema) {
+  const notes = [];
+  for (const [key, label] of [["minLength", "mín."], ["maxLength", "máx."], ["minimum", "mín."], ["maximum", "máx."], ["maxItems", "máx. itens"]]) {
+    if (schema[key] !== undefined) {
+      notes.push(label + " " + schema[key]);
+    }
+  }
+  if (schema.pattern) {
+    notes.push("padrão " + schema.pattern);
+  }
+  if (schema.default !== undefined) {
+    notes.push("padrão: " + JSON.stringify(schema.default));
+  }
+  return notes.length ? " — " + notes.join(", ") : "";
+}
+
+// properties lista os campos de um objeto; objetos sem nome são abertos até depth
+function properties(schema, depth) {
+  if (!schema || !schema.properties) {
+    return null;
+  }
+  const required = new Set(schema.required || []);
+  return el("ul", { class: "schema" }, Object.entries(schema.properties).map(([name, property]) =>
+    el("li", {},
+      el("code", { class: required.has(name) ? "required" : "" }, name), ": ", typeOf(property),
+      constraints(property),
+      property.description ? el("span", { class: "summary" }, " — " + property.description) : null,
+      depth > 0 && !property.$ref ? properties(property.type === "array" ? property.items : property, depth - 1) : null)));
+}
+
+// content mostra o schema de cada tipo de mídia de um corpo
+function content(body) {
+  if (!body || !body.content) {
+    return null;
+  }
+  return Object.entries(body.content).map(([type, media]) =>
+    el("div", {}, el("code", {}, type), " ", typeOf(media.schema),
+      media.schema && !media.schema.$ref ? properties(media.schema, 2) : null));
+}
+
+function operation(path, method, op) {
+  const parameters = op.parameters || [];
+  return el("details", { class: "operation" },
+    el("summary", {},
+      el("span", { class: "method " + method }, method),
+      el("span", { class: "path" }, path),
+      el("span", { class: "summary" }, op.summary || "")),
+    el("div", {},
+      op.description ? el("p", {}, op.description) : null,
+      parameters.length ? [
+        el("h4", {}, "Parâmetros"),
+        el("table", {},
+          el("tr", {}, el("th", {}, "Nome"), el("th", {}, "Em"), el("th", {}, "Tipo"), el("th", {}, "Descrição")),
+          parameters.map((param) => el("tr", {},
+            el("td", {}, el("code", { class: param.required ? "required" : "" }, param.name)),
+            el("td", {}, param.in),
+            el("td", {}, typeOf(param.schema), constraints(param.schema || {})),
+            el("td", {}, param.description || "")))),
+      ] : null,
+      op.requestBody ? [el("h4", {}, "Corpo"), content(op.requestBody)] : null,
+      el("h4", {}, "Respostas"),
+      el("table", {},
+        el("tr", {}, el("th", {}, "Status"), el("th", {}, "Descrição"), el("th", {}, "Conteúdo")),
+        Object.entries(op.responses || {}).map(([status, response]) => el("tr", {},
+          el("td", {}, el("code", {}, status)),
+          el("td", {}, response.description || ""),
+          el("td", {}, content(response)))))));
+}
+
+function render(root, spec) {
+  const groups = new Map();
+  for (const [path, item] of Object.entries(spec.paths || {})) {
+    for (const method of methods) {
+      const op = item[method];
+      if (!op) {
+        continue;
+      }
+      const tag = (op.tags && op.tags[0]) || "outros";
+      if (!groups.has(tag)) {
+        groups.set(tag, []);
+      }
+      groups.get(tag).push(operation(path, method, op));
+    }
+  }
+
+  const info = spec.info || {};
+  const servers = (spec.servers || []).map((server) => server.url);
+  root.replaceChildren(
+    el("h1", {}, info.title || "API"),
+    el("p", { class: "summary" }, "Versão ", info.version || "", servers.length ? ["; caminhos relativos a ", el("code", {}, servers.join(", "))] : null),
+    info.description ? el("p", {}, info.description) : null,
+    [...groups].map(([tag, operations]) => [el("h2", {}, tag), operations]),
+    el("h2", {}, "Schemas"),
+    Object.entries((spec.components && spec.components.schemas) || {}).map(([name, schema]) =>
+      el("section", { id: "schema-" + name },
+        el("h3", {}, name, " ", typeOf({ ...schema, properties: undefined, type: schema.type || (schema.properties ? "object" : undefined) })),
+        schema.description ? el("p", {}, schema.description) : null,
+        properties(schema, 2))));
+}
+
+document.addEventListener("DOMContentLoaded", async () => {
+  const root = document.getElementById("docs");
+  try {
+    const response = await fetch(root.dataset.spec);
+    if (!response.ok) {
+      throw new Error("HTTP " + response.status);
+    }
+    render(root, await response.json());
+  } catch (err) {
+    root.replaceChildren(el("p", { class: "error" }, "Não foi possível carregar " + root.dataset.spec + ": " + err.message));
+  }
+});
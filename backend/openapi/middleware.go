@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/problem"
+)
+
+// MaxBodySize limita os corpos JSON lidos para validação, o mesmo limite
+// aplicado pelos handlers
+const MaxBodySize = 1 << 20
+
+var (
+	// errInvalidRequestBody indica um corpo ilegível ou que não é JSON
+	errInvalidRequestBody = errors.New("invalid request body")
+	// errRequestTooLarge indica um corpo acima de MaxBodySize
+	errRequestTooLarge = errors.New("request body exceeds the maximum size")
+)
+
+// Middleware valida query string e corpo JSON das requisições contra o
+// documento antes de repassá-las, respondendo 400 em application/problem+json
+// quando não seguem o schema. Caminhos e métodos fora do documento seguem direto para
+// o handler, que responde 404 ou 405.
+func (s *Spec) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, item, _, ok := s.FindPath(r.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		op, ok := item.Operations[r.Method]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := s.validateQuery(r, append(item.Parameters, op.Parameters...)); err != nil {
+			writeError(w, err)
+			return
+		}
+		if err := s.validateBody(w, r, op.RequestBody); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateQuery converte cada parâmetro de query para o tipo do schema e o valida
+func (s *Spec) validateQuery(r *http.Request, params []*Parameter) error {
+	query := r.URL.Query()
+	for _, param := range params {
+		if param.In != "query" {
+			continue
+		}
+		if !query.Has(param.Name) {
+			if param.Required {
+				return &ValidationError{Field: param.Name, Message: "is required"}
+			}
+			continue
+		}
+
+		schema, err := s.resolve(param.Schema)
+		if err != nil {
+			return err
+		}
+		raw := query.Get(param.Name)
+		var value any = raw
+		switch schema.Type {
+		case "boolean":
+			if parsed, err := strconv.ParseBool(raw); err == nil {
+				value = parsed
+			}
+		case "integer", "number":
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				value = parsed
+			}
+		}
+		if err := s.validate(param.Schema, value, param.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBody valida o corpo JSON (application/json ou tipos +json, como os
+// de PATCH) contra o schema do seu Content-Type e o devolve à requisição para
+// o handler. Corpos em outros formatos (CSV, multipart) e schemas sem
+// restrições não são lidos aqui; os demais são limitados a MaxBodySize.
+func (s *Spec) validateBody(w http.ResponseWriter, r *http.Request, body *RequestBody) error {
+	if body == nil {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	// Sem Content-Type (ex: curl -d) o corpo é tratado como JSON quando é o único formato aceito
+	if _, ok := body.Content[mediaType]; !ok && len(body.Content) == 1 {
+		mediaType = "application/json"
+	}
+	media, ok := body.Content[mediaType]
+	if !ok || !isJSON(mediaType) || media.Schema == nil || isUnconstrained(media.Schema) {
+		return nil
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return errRequestTooLarge
+	}
+	if err != nil {
+		return errInvalidRequestBody
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if body.Required {
+			return errInvalidRequestBody
+		}
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return errInvalidRequestBody
+	}
+	return s.Validate(media.Schema, value)
+}
+
+// isJSON indica application/json ou um tipo com sufixo +json
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isUnconstrained indica um schema vazio ({}), que aceita qualquer valor
+func isUnconstrained(schema *Schema) bool {
+	return schema.Ref == "" && schema.Type == "" && len(schema.AnyOf) == 0 && len(schema.Enum) == 0
+}
+
+// writeError responde 400 com o erro de validação; os campos inválidos,
+// quando conhecidos, vão em "errors" com o mesmo código que os handlers
+// usariam. Corpos acima do limite respondem 413.
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errInvalidRequestBody):
+		problem.Error(w, http.StatusBadRequest, problem.CodeInvalidRequestBody, err.Error())
+		return
+	case errors.Is(err, errRequestTooLarge):
+		problem.Error(w, http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge, err.Error())
+		return
+	}
+
+	var fieldErrs ValidationErrors
+	var fieldErr *ValidationError
+	switch {
+	case errors.As(err, &fieldErrs):
+	case errors.As(err, &fieldErr):
+		fieldErrs = ValidationErrors{fieldErr}
+	}
+
+	// Um único erro com código próprio, ex: unknown_field, responde com esse
+	// código, como os handlers; vários respondem validation_failed
+	code := problem.CodeValidationFailed
+	if len(fieldErrs) == 1 && fieldErrs[0].Code != "" {
+		code = fieldErrs[0].Code
+	}
+	p := problem.New(http.StatusBadRequest, code, err.Error())
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Field == "" {
+			continue
+		}
+		fieldCode := problem.CodeValidationFailed
+		if fieldErr.Code != "" {
+			fieldCode = fieldErr.Code
+		}
+		p.Errors = append(p.Errors, problem.FieldError{Field: fieldErr.Field, Code: fieldCode, Message: fieldErr.Message})
+	}
+	problem.Write(w, p)
+}
@@ -0,0 +1,658 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/apiv1"
+	"github.com/acauhi/kanban-backend/handlers"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/problem"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/service"
+	"github.com/acauhi/kanban-backend/storage"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+var paramPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// loadSpec carrega o documento embutido, falhando o teste se for inválido
+func loadSpec(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Load()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	return spec
+}
+
+// newTaskHandler monta o TaskHandler real com todos os sub-recursos habilitados
+func newTaskHandler() http.Handler {
+	svc := service.NewTaskService(repository.NewInMemoryTaskRepository(),
+		service.WithLabelRepository(repository.NewInMemoryLabelRepository()),
+		service.WithCommentRepository(repository.NewInMemoryCommentRepository()),
+		service.WithAttachmentStorage(repository.NewInMemoryAttachmentRepository(), storage.NewMemoryBlobStore()),
+		service.WithTaskTemplateRepository(repository.NewInMemoryTaskTemplateRepository()),
+		service.WithDependencyRepository(repository.NewInMemoryDependencyRepository()),
+	)
+	return handlers.NewTaskHandler(svc)
+}
+
+// specClient envia requisições ao handler e confere cada resposta contra o documento
+type specClient struct {
+	t       *testing.T
+	spec    *Spec
+	handler http.Handler
+	covered map[string]bool
+}
+
+func newSpecClient(t *testing.T) *specClient {
+	spec := loadSpec(t)
+	return &specClient{
+		t:       t,
+		spec:    spec,
+		handler: spec.Middleware(newTaskHandler()),
+		covered: make(map[string]bool),
+	}
+}
+
+// do envia a requisição e valida status, tipo de conteúdo e corpo da resposta
+func (c *specClient) do(method, target, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	c.t.Helper()
+	req := httptest.NewRequest(method, target, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.send(req)
+}
+
+// send envia a requisição já montada, com as mesmas conferências de do
+func (c *specClient) send(req *http.Request) *httptest.ResponseRecorder {
+	c.t.Helper()
+	method, target := req.Method, req.URL.RequestURI()
+	rec := httptest.NewRecorder()
+	c.handler.ServeHTTP(rec, req)
+
+	path, item, _, ok := c.spec.FindPath(req.URL.Path)
+	if !ok {
+		c.t.Fatalf("%s %s: path not documented", method, target)
+	}
+	op, ok := item.Operations[method]
+	if !ok {
+		c.t.Fatalf("%s %s: operation not documented", method, path)
+	}
+	response, ok := op.Responses[strconv.Itoa(rec.Code)]
+	if !ok {
+		c.t.Fatalf("%s %s: status %d not documented (body %s)", method, path, rec.Code, rec.Body)
+	}
+	c.covered[method+" "+path] = true
+
+	if len(response.Content) == 0 {
+		if rec.Body.Len() > 0 {
+			c.t.Errorf("%s %s: expected empty body for %d, got %s", method, path, rec.Code, rec.Body)
+		}
+		return rec
+	}
+	mediaType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	media, ok := response.Content[mediaType]
+	if !ok {
+		media, ok = response.Content["*/*"]
+	}
+	if !ok {
+		c.t.Fatalf("%s %s: content type %q not documented for %d", method, path, mediaType, rec.Code)
+	}
+	if isJSON(mediaType) && media.Schema != nil {
+		var value any
+		if err := json.Unmarshal(rec.Body.Bytes(), &value); err != nil {
+			c.t.Fatalf("%s %s: invalid JSON response: %v", method, path, err)
+		}
+		if err := c.spec.Validate(media.Schema, value); err != nil {
+			c.t.Errorf("%s %s: response %d does not match the spec: %v\n%s", method, path, rec.Code, err, rec.Body)
+		}
+	}
+	return rec
+}
+
+// json envia um corpo JSON e decodifica a resposta em out, se informado
+func (c *specClient) json(method, target string, body any, wantStatus int, out any) {
+	c.t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf(msgExpectedNoError, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	rec := c.do(method, target, "application/json", reader)
+	if rec.Code != wantStatus {
+		c.t.Fatalf("%s %s: expected status %d, got %d (%s)", method, target, wantStatus, rec.Code, rec.Body)
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			c.t.Fatalf(msgExpectedNoError, err)
+		}
+	}
+}
+
+func TestSpecIsValid(t *testing.T) {
+	spec := loadSpec(t)
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("expected an OpenAPI 3 document, got %q", spec.OpenAPI)
+	}
+
+	ids := make(map[string]bool)
+	for path, item := range spec.Paths {
+		for method, op := range item.Operations {
+			if op.OperationID == "" || ids[op.OperationID] {
+				t.Errorf("%s %s: missing or duplicated operationId %q", method, path, op.OperationID)
+			}
+			ids[op.OperationID] = true
+			if len(op.Responses) == 0 {
+				t.Errorf("%s %s: no responses documented", method, path)
+			}
+		}
+	}
+
+	// Toda referência precisa apontar para um schema existente
+	var refs []string
+	collectRefs(reflect.ValueOf(spec.Paths), &refs)
+	collectRefs(reflect.ValueOf(spec.Components.Schemas), &refs)
+	for _, ref := range refs {
+		if _, err := spec.resolve(&Schema{Ref: ref}); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// collectRefs percorre o documento recolhendo os $ref dos schemas
+func collectRefs(v reflect.Value, refs *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectRefs(v.Elem(), refs)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			collectRefs(v.MapIndex(key), refs)
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			collectRefs(v.Index(i), refs)
+		}
+	case reflect.Struct:
+		if schema, ok := v.Interface().(Schema); ok && schema.Ref != "" {
+			*refs = append(*refs, schema.Ref)
+		}
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				collectRefs(v.Field(i), refs)
+			}
+		}
+	}
+}
+
+// samplePath troca os parâmetros {nome} do caminho por um ID inexistente
+func samplePath(path string) string {
+	return paramPattern.ReplaceAllString(path, "missing")
+}
+
+func TestSpecOperationsAreRouted(t *testing.T) {
+	spec := loadSpec(t)
+	handler := newTaskHandler()
+
+	for _, operation := range spec.Operations() {
+		method, path, _ := strings.Cut(operation, " ")
+		target := samplePath(path)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader("{}")))
+
+		if rec.Code == http.StatusMethodNotAllowed || strings.Contains(rec.Body.String(), problem.CodeResourceNotFound) {
+			t.Errorf("%s is documented but not served: %d %s", operation, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestResponsesMatchSpec(t *testing.T) {
+	c := newSpecClient(t)
+
+	var task, blocker models.Task
+	c.json(http.MethodPost, "/tasks", map[string]any{
+		"title": "Spec", "priority": "high", "dueDate": "2020-01-01T00:00:00Z", "checklist": []string{"one"},
+	}, http.StatusCreated, &task)
+	c.json(http.MethodPost, "/tasks", map[string]any{"title": "Blocker"}, http.StatusCreated, &blocker)
+	c.json(http.MethodPost, "/tasks?template=missing", nil, http.StatusNotFound, nil)
+	c.json(http.MethodPost, "/tasks", map[string]any{}, http.StatusBadRequest, nil)
+
+	c.json(http.MethodGet, "/tasks?sort=priority&order=desc", nil, http.StatusOK, nil)
+	c.json(http.MethodGet, "/tasks/overdue?sort=dueDate", nil, http.StatusOK, nil)
+	c.json(http.MethodGet, "/tasks/"+task.ID, nil, http.StatusOK, nil)
+	c.json(http.MethodGet, "/tasks/missing", nil, http.StatusNotFound, nil)
+	etag := c.do(http.MethodGet, "/tasks", "", nil).Header().Get("ETag")
+	for _, target := range []string{"/tasks", "/tasks/" + task.ID} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("If-None-Match", etag)
+		if rec := c.send(req); rec.Code != http.StatusNotModified {
+			t.Errorf("GET %s with If-None-Match: expected status 304, got %d", target, rec.Code)
+		}
+	}
+
+	// Checklist
+	c.json(http.MethodPost, "/tasks/"+task.ID+"/checklist", map[string]any{"text": "two"}, http.StatusCreated, &task)
+	c.json(http.MethodPut, "/tasks/"+task.ID+"/checklist/"+task.Checklist[0].ID, map[string]any{"checked": true}, http.StatusOK, nil)
+	c.json(http.MethodPut, "/tasks/"+task.ID+"/checklist", map[string]any{
+		"itemIds": []string{task.Checklist[1].ID, task.Checklist[0].ID},
+	}, http.StatusOK, nil)
+	c.json(http.MethodDelete, "/tasks/"+task.ID+"/checklist/"+task.Checklist[1].ID, nil, http.StatusOK, nil)
+
+	// Dependências
+	c.json(http.MethodPost, "/tasks/"+task.ID+"/dependencies", map[string]any{"blockerId": blocker.ID}, http.StatusCreated, nil)
+	c.json(http.MethodGet, "/tasks/"+task.ID+"/dependencies", nil, http.StatusOK, nil)
+	c.json(http.MethodPut, "/tasks/"+task.ID, map[string]any{"title": "Spec", "status": "done"}, http.StatusConflict, nil)
+	c.json(http.MethodDelete, "/tasks/"+task.ID+"/dependencies/"+blocker.ID, nil, http.StatusNoContent, nil)
+	c.json(http.MethodPut, "/tasks/"+task.ID, map[string]any{"title": "Spec", "status": "done", "dueDate": nil}, http.StatusOK, nil)
+
+	// PATCH
+	patches := []struct {
+		contentType, body string
+		want              int
+	}{
+		{"application/merge-patch+json", `{"description": "patched", "labels": null}`, http.StatusOK},
+		{"application/json-patch+json", `[{"op": "add", "path": "/checklist/-", "value": {"text": "three"}}]`, http.StatusOK},
+		{"application/json-patch+json", `[{"op": "add", "path": "/labels/-", "value": "missing"}]`, http.StatusBadRequest},
+		{"application/json-patch+json", `[{"op": "test", "path": "/title", "value": "Other"}]`, http.StatusConflict},
+		{"application/json-patch+json", `[{"op": "remove", "path": "/completed"}]`, http.StatusUnprocessableEntity},
+		{"application/json", `{"title": "Plain"}`, http.StatusUnsupportedMediaType},
+	}
+	for _, patch := range patches {
+		rec := c.do(http.MethodPatch, "/tasks/"+task.ID, patch.contentType, strings.NewReader(patch.body))
+		if rec.Code != patch.want {
+			t.Errorf("PATCH %s %s: expected status %d, got %d (%s)", patch.contentType, patch.body, patch.want, rec.Code, rec.Body)
+		}
+		if rec.Code == http.StatusUnsupportedMediaType && rec.Header().Get("Accept-Patch") == "" {
+			t.Error("expected Accept-Patch header on 415")
+		}
+	}
+	if rec := c.do(http.MethodPatch, "/tasks/missing", "application/merge-patch+json", strings.NewReader(`{}`)); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+
+	// Comentários
+	var comment models.Comment
+	c.json(http.MethodPost, "/tasks/"+task.ID+"/comments", map[string]any{"author": "ana", "body": "hi"}, http.StatusCreated, &comment)
+	c.json(http.MethodGet, "/tasks/"+task.ID+"/comments", nil, http.StatusOK, nil)
+	c.json(http.MethodPut, "/tasks/"+task.ID+"/comments/"+comment.ID, map[string]any{"body": "edited"}, http.StatusOK, nil)
+	c.json(http.MethodDelete, "/tasks/"+task.ID+"/comments/"+comment.ID, nil, http.StatusNoContent, nil)
+
+	// Anexos
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, _ := writer.CreateFormFile("file", "notes.txt")
+	part.Write([]byte("hello"))
+	writer.Close()
+	rec := c.do(http.MethodPost, "/tasks/"+task.ID+"/attachments", writer.FormDataContentType(), &form)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d (%s)", rec.Code, rec.Body)
+	}
+	var attachment models.Attachment
+	json.Unmarshal(rec.Body.Bytes(), &attachment)
+	c.json(http.MethodGet, "/tasks/"+task.ID+"/attachments", nil, http.StatusOK, nil)
+	if rec := c.do(http.MethodGet, "/tasks/"+task.ID+"/attachments/"+attachment.ID, "", nil); rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+	c.json(http.MethodDelete, "/tasks/"+task.ID+"/attachments/"+attachment.ID, nil, http.StatusNoContent, nil)
+
+	// Exportação e importação
+	exported := c.do(http.MethodGet, "/tasks/export?format=json", "", nil)
+	if exported.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", exported.Code)
+	}
+	c.do(http.MethodGet, "/tasks/export?format=csv", "", nil)
+	if rec := c.do(http.MethodPost, "/tasks/import?format=json&dryRun=true", "application/json", exported.Body); rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d (%s)", rec.Code, rec.Body)
+	}
+
+	c.json(http.MethodDelete, "/tasks/"+task.ID, nil, http.StatusNoContent, nil)
+
+	for _, operation := range c.spec.Operations() {
+		if !c.covered[operation] {
+			t.Errorf("%s is documented but not exercised by this test", operation)
+		}
+	}
+}
+
+// Cada schema documentado deve ter exatamente os campos JSON do modelo correspondente
+func TestModelsMatchSchemas(t *testing.T) {
+	spec := loadSpec(t)
+	tests := map[string]any{
+		"Task":                       apiv1.Task{},
+		"ChecklistItem":              apiv1.ChecklistItem{},
+		"Occurrence":                 apiv1.Occurrence{},
+		"CreateTaskRequest":          models.CreateTaskRequest{},
+		"InstantiateTemplateRequest": models.InstantiateTemplateRequest{},
+		"ReplaceTaskRequest":         models.ReplaceTaskRequest{},
+		"ChecklistItemInput":         models.ChecklistItemInput{},
+		"AddChecklistItemRequest":    models.AddChecklistItemRequest{},
+		"UpdateChecklistItemRequest": models.UpdateChecklistItemRequest{},
+		"ReorderChecklistRequest":    models.ReorderChecklistRequest{},
+		"AddDependencyRequest":       models.AddDependencyRequest{},
+		"TaskDependencies":           apiv1.TaskDependencies{},
+		"Comment":                    models.Comment{},
+		"CreateCommentRequest":       models.CreateCommentRequest{},
+		"UpdateCommentRequest":       models.UpdateCommentRequest{},
+		"Attachment":                 models.Attachment{},
+		"ImportRowResult":            models.ImportRowResult{},
+		"ImportReport":               models.ImportReport{},
+	}
+
+	for name, model := range tests {
+		t.Run(name, func(t *testing.T) {
+			schema := spec.Components.Schemas[name]
+			if schema == nil {
+				t.Fatalf("schema %s not documented", name)
+			}
+			fields := jsonFields(reflect.TypeOf(model))
+			var documented []string
+			for property := range schema.Properties {
+				documented = append(documented, property)
+			}
+			slices.Sort(fields)
+			slices.Sort(documented)
+			if !slices.Equal(fields, documented) {
+				t.Errorf("model fields %v differ from schema properties %v", fields, documented)
+			}
+		})
+	}
+}
+
+// jsonFields lista os nomes JSON dos campos exportados do tipo
+func jsonFields(typ reflect.Type) []string {
+	var fields []string
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+func TestMiddlewareRejectsInvalidRequests(t *testing.T) {
+	spec := loadSpec(t)
+	handler := spec.Middleware(newTaskHandler())
+
+	tests := []struct {
+		name, method, target, body, want string
+	}{
+		{"wrong type", http.MethodPut, "/tasks/1", `{"title": 1}`, "title: must be a string"},
+		{"malformed JSON", http.MethodPost, "/tasks", `{"title":`, "invalid request body"},
+		{"missing title", http.MethodPut, "/tasks/1", `{"status": "done"}`, "title: is required"},
+		{"bad date", http.MethodPut, "/tasks/1", `{"title": "x", "dueDate": "tomorrow"}`, "dueDate: must be an RFC 3339 date-time"},
+		{"invalid status", http.MethodPut, "/tasks/1", `{"title": "x", "status": "blocked"}`, "invalid status"},
+		{"invalid priority", http.MethodPut, "/tasks/1", `{"title": "x", "priority": "meh"}`, "invalid priority"},
+		{"missing field", http.MethodPost, "/tasks/1/comments", `{"body": "hi"}`, "author: is required"},
+		{"invalid sort", http.MethodGet, "/tasks?sort=size", "", "invalid sort field"},
+		{"invalid boolean", http.MethodGet, "/tasks?archived=maybe", "", "archived: must be a boolean"},
+		{"invalid format", http.MethodGet, "/tasks/export?format=xml", "", "unsupported format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			var body problem.Problem
+			json.Unmarshal(rec.Body.Bytes(), &body)
+			if rec.Code != http.StatusBadRequest || body.Detail != tt.want {
+				t.Errorf("expected 400 %q, got %d %s", tt.want, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestMiddlewareValidatesPatchDocuments(t *testing.T) {
+	spec := loadSpec(t)
+	handler := spec.Middleware(newTaskHandler())
+
+	tests := []struct {
+		name, contentType, body, want string
+	}{
+		{"merge patch type", "application/merge-patch+json", `{"title": 1}`, "title: must be a string"},
+		{"merge patch removing title", "application/merge-patch+json", `{"title": null}`, "title: must not be null"},
+		{"merge patch status", "application/merge-patch+json", `{"status": "blocked"}`, "invalid status"},
+		{"JSON patch not an array", "application/json-patch+json", `{"op": "add"}`, "must be an array"},
+		{"JSON patch unknown op", "application/json-patch+json", `[{"op": "merge", "path": "/title"}]`, "[0].op: must be one of add, remove, replace, move, copy, test"},
+		{"JSON patch missing path", "application/json-patch+json", `[{"op": "remove"}]`, "[0].path: is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			var body problem.Problem
+			json.Unmarshal(rec.Body.Bytes(), &body)
+			if rec.Code != http.StatusBadRequest || body.Detail != tt.want {
+				t.Errorf("expected 400 %q, got %d %s", tt.want, rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestErrorsAreProblemDocuments(t *testing.T) {
+	spec := loadSpec(t)
+	handler := spec.Middleware(newTaskHandler())
+
+	tests := []struct {
+		name, method, target, body string
+		status                     int
+		code, field                string
+	}{
+		{"missing task", http.MethodGet, "/tasks/missing", "", http.StatusNotFound, "task_not_found", ""},
+		{"unknown label", http.MethodPost, "/tasks", `{"title": "x", "labels": ["missing"]}`, http.StatusBadRequest, "label_not_found", "labels"},
+		{"empty checklist item", http.MethodPost, "/tasks", `{"title": "x", "checklist": ["a", ""]}`, http.StatusBadRequest, "invalid_checklist_text", "checklist[1]"},
+		{"schema violation", http.MethodPut, "/tasks/1", `{"title": 1}`, http.StatusBadRequest, problem.CodeValidationFailed, "title"},
+		{"malformed JSON", http.MethodPost, "/tasks", `{"title":`, http.StatusBadRequest, problem.CodeInvalidRequestBody, ""},
+		{"unknown field", http.MethodPut, "/tasks/1", `{"title": "x", "colour": "red"}`, http.StatusBadRequest, "unknown_field", "colour"},
+		{"unknown field in the schema", http.MethodPost, "/tasks", `{"title": "x", "colour": "red"}`, http.StatusBadRequest, problem.CodeUnknownField, "colour"},
+		{"whitespace title", http.MethodPost, "/tasks", `{"title": " \t "}`, http.StatusBadRequest, "invalid_title", "title"},
+		{"long comment", http.MethodPost, "/tasks/missing/comments", `{"author": "ana", "body": "` + strings.Repeat("x", service.MaxCommentBodyLength+1) + `"}`, http.StatusBadRequest, "field_too_long", "body"},
+		{"invalid UTF-8", http.MethodPut, "/tasks/1", "{\"title\": \"\xff\"}", http.StatusBadRequest, problem.CodeInvalidRequestBody, ""},
+		{"trailing data", http.MethodPut, "/tasks/1", `{"title": "x"} {}`, http.StatusBadRequest, problem.CodeInvalidRequestBody, ""},
+		{"method not allowed", http.MethodPost, "/tasks/overdue", "", http.StatusMethodNotAllowed, problem.CodeMethodNotAllowed, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			var body problem.Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf(msgExpectedNoError, err)
+			}
+			if rec.Code != tt.status || body.Status != tt.status || body.Code != tt.code {
+				t.Errorf("expected %d %s, got %d %s", tt.status, tt.code, rec.Code, rec.Body)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != problem.ContentType {
+				t.Errorf("expected Content-Type %s, got %s", problem.ContentType, contentType)
+			}
+			if body.Type != problem.TypeURI(tt.code) || body.Title != http.StatusText(tt.status) || body.Detail == "" {
+				t.Errorf("expected type, title and detail to be filled, got %+v", body)
+			}
+			if tt.field == "" && len(body.Errors) > 0 || tt.field != "" && (len(body.Errors) != 1 || body.Errors[0].Field != tt.field) {
+				t.Errorf("expected field %q, got %+v", tt.field, body.Errors)
+			}
+		})
+	}
+}
+
+func TestValidationReportsEveryField(t *testing.T) {
+	spec := loadSpec(t)
+	handler := spec.Middleware(newTaskHandler())
+
+	tests := []struct {
+		name, method, target, body string
+		fields, codes              []string
+	}{
+		{
+			"schema",
+			http.MethodPost, "/tasks", `{"title": 1, "description": false, "extra": true}`,
+			[]string{"description", "extra", "title"},
+			[]string{problem.CodeValidationFailed, problem.CodeUnknownField, problem.CodeValidationFailed},
+		},
+		{
+			"service",
+			http.MethodPost, "/tasks", `{"title": " ", "checklist": ["a", ""], "labels": ["missing"]}`,
+			[]string{"title", "checklist[1]", "labels"},
+			[]string{"invalid_title", "invalid_checklist_text", "label_not_found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			var body problem.Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf(msgExpectedNoError, err)
+			}
+			if rec.Code != http.StatusBadRequest || body.Code != problem.CodeValidationFailed {
+				t.Fatalf("expected 400 %s, got %d %s", problem.CodeValidationFailed, rec.Code, rec.Body)
+			}
+			var fields, codes []string
+			for _, fieldErr := range body.Errors {
+				fields = append(fields, fieldErr.Field)
+				codes = append(codes, fieldErr.Code)
+			}
+			if !slices.Equal(fields, tt.fields) || !slices.Equal(codes, tt.codes) {
+				t.Errorf("expected fields %v with codes %v, got %+v", tt.fields, tt.codes, body.Errors)
+			}
+		})
+	}
+}
+
+func TestRequestBodiesAreLimited(t *testing.T) {
+	c := newSpecClient(t)
+
+	var task models.Task
+	c.json(http.MethodPost, "/tasks", map[string]any{"title": "Limits"}, http.StatusCreated, &task)
+
+	// Campos de texto no limite passam; acima dele o corpo inteiro é rejeitado
+	large := `{"title": "x", "description": "` + strings.Repeat("a", MaxBodySize) + `"}`
+	for _, method := range []string{http.MethodPut, http.MethodPatch} {
+		contentType := "application/json"
+		if method == http.MethodPatch {
+			contentType = "application/merge-patch+json"
+		}
+		rec := c.do(method, "/tasks/"+task.ID, contentType, strings.NewReader(large))
+		if rec.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rec.Body.String(), problem.CodeRequestTooLarge) {
+			t.Errorf("%s: expected 413 %s, got %d %s", method, problem.CodeRequestTooLarge, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestReplaceAcceptsTaskRepresentation(t *testing.T) {
+	c := newSpecClient(t)
+
+	var task map[string]any
+	c.json(http.MethodPost, "/tasks", map[string]any{"title": "Round trip", "checklist": []string{"a"}}, http.StatusCreated, &task)
+	task["title"] = "Renamed"
+
+	// O corpo do GET, com campos somente leitura como id, progress e order, é aceito no PUT
+	var replaced models.Task
+	c.json(http.MethodPut, "/tasks/"+task["id"].(string), task, http.StatusOK, &replaced)
+	if replaced.Title != "Renamed" || len(replaced.Checklist) != 1 {
+		t.Errorf("expected the task to be replaced, got %+v", replaced)
+	}
+}
+
+func TestMiddlewarePassesValidRequests(t *testing.T) {
+	spec := loadSpec(t)
+	handler := spec.Middleware(newTaskHandler())
+
+	// Sem Content-Type o corpo continua chegando ao handler
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title": "Plain"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), `"title":"Plain"`) {
+		t.Errorf("expected task to be created, got %d %s", rec.Code, rec.Body)
+	}
+
+	// Caminhos fora do documento seguem para o handler
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/1/unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestDocsHandler(t *testing.T) {
+	handler := NewHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK || !json.Valid(rec.Body.Bytes()) {
+		t.Errorf("expected the JSON document, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "/openapi.json") {
+		t.Errorf("expected the documentation page, got %d", rec.Code)
+	}
+	// A página só usa arquivos embutidos, sem CDN nem scripts inline
+	if strings.Contains(rec.Body.String(), "https://") || strings.Contains(rec.Body.String(), "<script>") {
+		t.Errorf("expected no external or inline scripts, got %s", rec.Body)
+	}
+	if got := rec.Header().Get("Content-Security-Policy"); !strings.Contains(got, "script-src 'self'") {
+		t.Errorf("expected a self-only Content-Security-Policy, got %q", got)
+	}
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{"/docs/docs.js", http.StatusOK, "text/javascript"},
+		{"/docs/docs.css", http.StatusOK, "text/css"},
+		{"/docs/missing.js", http.StatusNotFound, "application/problem+json"},
+		{"/docs/../docs.go", http.StatusNotFound, "application/problem+json"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/docs/", nil)
+		req.URL.Path = tt.path
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tt.status || !strings.HasPrefix(rec.Header().Get("Content-Type"), tt.contentType) {
+			t.Errorf("%s: expected %d %s, got %d %q", tt.path, tt.status, tt.contentType, rec.Code, rec.Header().Get("Content-Type"))
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/acauhi/kanban-backend/problem"
+)
+
+// Schema é o subconjunto de JSON Schema do OpenAPI 3.0 usado no documento
+type Schema struct {
+	Ref                  string                `json:"$ref"`
+	Type                 string                `json:"type"`
+	Format               string                `json:"format"`
+	Nullable             bool                  `json:"nullable"`
+	Enum                 []any                 `json:"enum"`
+	Required             []string              `json:"required"`
+	Properties           map[string]*Schema    `json:"properties"`
+	AdditionalProperties *AdditionalProperties `json:"additionalProperties"`
+	Items                *Schema               `json:"items"`
+	AnyOf                []*Schema             `json:"anyOf"`
+	Minimum              *float64              `json:"minimum"`
+	Maximum              *float64              `json:"maximum"`
+	MinLength            *int                  `json:"minLength"`
+	MaxLength            *int                  `json:"maxLength"`
+	MaxItems             *int                  `json:"maxItems"`
+	// ErrorMessage substitui a mensagem de validação, para manter a mesma do
+	// serviço (ex: "invalid status") quando o valor é rejeitado pelo schema
+	ErrorMessage string `json:"x-error-message"`
+}
+
+// AdditionalProperties aceita tanto false quanto um schema para os valores
+type AdditionalProperties struct {
+	Forbidden bool
+	Schema    *Schema
+}
+
+// UnmarshalJSON interpreta additionalProperties booleano ou schema
+func (a *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.Forbidden = !allowed
+		return nil
+	}
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// ValidationError indica em qual campo o valor não segue o schema
+type ValidationError struct {
+	Field   string
+	Message string
+	// Code é o código do erro quando difere de validation_failed, ex:
+	// unknown_field, o mesmo dos handlers
+	Code string
+}
+
+// Error formata o campo e o problema, ex: "title: must be a string"
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors reúne os erros de vários campos de um mesmo objeto
+type ValidationErrors []*ValidationError
+
+// Error junta os erros de cada campo, ex: "title: must be a string; dueDate: must not be null"
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate verifica um valor já decodificado de JSON (map, slice, float64,
+// string, bool ou nil) contra o schema. Os erros de todos os campos de um
+// objeto são reunidos em ValidationErrors.
+func (s *Spec) Validate(schema *Schema, value any) error {
+	return s.validate(schema, value, "")
+}
+
+// resolve segue as referências #/components/schemas/{nome}
+func (s *Spec) resolve(schema *Schema) (*Schema, error) {
+	for schema.Ref != "" {
+		name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+		target := s.Components.Schemas[name]
+		if !ok || target == nil {
+			return nil, fmt.Errorf("openapi: unresolved reference %q", schema.Ref)
+		}
+		schema = target
+	}
+	return schema, nil
+}
+
+// validate verifica o valor, usando field para localizar o erro
+func (s *Spec) validate(schema *Schema, value any, field string) error {
+	schema, err := s.resolve(schema)
+	if err != nil {
+		return err
+	}
+	if err := s.check(schema, value, field); err != nil {
+		if schema.ErrorMessage != "" {
+			return &ValidationError{Message: schema.ErrorMessage}
+		}
+		return err
+	}
+	return nil
+}
+
+// check aplica as regras do schema já resolvido
+func (s *Spec) check(schema *Schema, value any, field string) error {
+	invalid := func(format string, args ...any) error {
+		return &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if value == nil {
+		if schema.Nullable || (schema.Type == "" && len(schema.AnyOf) == 0) {
+			return nil
+		}
+		return invalid("must not be null")
+	}
+
+	if len(schema.AnyOf) > 0 {
+		var first error
+		for _, option := range schema.AnyOf {
+			err := s.validate(option, value, field)
+			if err == nil {
+				return nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, value) {
+		options := make([]string, len(schema.Enum))
+		for i, option := range schema.Enum {
+			options[i] = fmt.Sprint(option)
+		}
+		return invalid("must be one of %s", strings.Join(options, ", "))
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return invalid("must be a string")
+		}
+		if schema.MinLength != nil && utf8.RuneCountInString(text) < *schema.MinLength {
+			return invalid("must have at least %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && utf8.RuneCountInString(text) > *schema.MaxLength {
+			return invalid("must have at most %d characters", *schema.MaxLength)
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				return invalid("must be an RFC 3339 date-time")
+			}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalid("must be a boolean")
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			return invalid("must be a %s", schema.Type)
+		}
+		if schema.Type == "integer" && number != math.Trunc(number) {
+			return invalid("must be an integer")
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			return invalid("must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			return invalid("must be at most %v", *schema.Maximum)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return invalid("must be an array")
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			return invalid("must have at most %d items", *schema.MaxItems)
+		}
+		if schema.Items == nil {
+			return nil
+		}
+		for i, item := range items {
+			if err := s.validate(schema.Items, item, fmt.Sprintf("%s[%d]", field, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return invalid("must be an object")
+		}
+		return s.checkObject(schema, object, field)
+	default:
+		return fmt.Errorf("openapi: unsupported schema type %q", schema.Type)
+	}
+	return nil
+}
+
+// checkObject verifica campos obrigatórios, propriedades e campos extras,
+// reunindo os erros de todos os campos
+func (s *Spec) checkObject(schema *Schema, object map[string]any, field string) error {
+	child := func(name string) string {
+		if field == "" {
+			return name
+		}
+		return field + "." + name
+	}
+
+	var errs ValidationErrors
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			errs = append(errs, &ValidationError{Field: child(name), Message: "is required"})
+		}
+	}
+
+	// Ordena os nomes para que os erros sejam sempre reportados na mesma ordem
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		var err error
+		value := object[name]
+		if property, ok := schema.Properties[name]; ok {
+			err = s.validate(property, value, child(name))
+		} else {
+			switch extra := schema.AdditionalProperties; {
+			case extra == nil:
+			case extra.Forbidden:
+				err = &ValidationError{Field: child(name), Message: "is not allowed", Code: problem.CodeUnknownField}
+			case extra.Schema != nil:
+				err = s.validate(extra.Schema, value, child(name))
+			}
+		}
+
+		var fieldErr *ValidationError
+		var fieldErrs ValidationErrors
+		switch {
+		case err == nil:
+		case errors.As(err, &fieldErrs):
+			errs = append(errs, fieldErrs...)
+		case errors.As(err, &fieldErr):
+			errs = append(errs, fieldErr)
+		default:
+			return err
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+//go:embed openapi.json
+var document []byte
+
+// Métodos HTTP que podem aparecer em um path item, na ordem do documento
+var methods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace,
+}
+
+// Spec é o documento OpenAPI 3 da API de tarefas, com as partes usadas na
+// validação das requisições
+type Spec struct {
+	OpenAPI    string               `json:"openapi"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+
+	routes []*route
+}
+
+// PathItem descreve as operações de um caminho, como /tasks/{id}
+type PathItem struct {
+	Parameters []*Parameter          `json:"parameters"`
+	Operations map[string]*Operation `json:"-"`
+}
+
+// UnmarshalJSON separa os parâmetros comuns das operações de cada método
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if params, ok := raw["parameters"]; ok {
+		if err := json.Unmarshal(params, &p.Parameters); err != nil {
+			return err
+		}
+	}
+	p.Operations = make(map[string]*Operation)
+	for _, method := range methods {
+		if op, ok := raw[strings.ToLower(method)]; ok {
+			var operation Operation
+			if err := json.Unmarshal(op, &operation); err != nil {
+				return fmt.Errorf("%s: %w", method, err)
+			}
+			p.Operations[method] = &operation
+		}
+	}
+	return nil
+}
+
+// Operation é uma combinação de método e caminho
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter é um parâmetro de caminho ou de query string
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody lista os tipos de conteúdo aceitos no corpo da operação
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response descreve uma resposta de um status da operação
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// MediaType associa um tipo de conteúdo ao schema do corpo
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// route é um caminho do documento quebrado em segmentos para o casamento
+type route struct {
+	path     string
+	segments []string
+	item     *PathItem
+}
+
+// Load interpreta o documento embutido no binário
+func Load() (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(document, &spec); err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+
+	for path, item := range spec.Paths {
+		spec.routes = append(spec.routes, &route{
+			path:     path,
+			segments: strings.Split(strings.Trim(path, "/"), "/"),
+			item:     item,
+		})
+	}
+	// Segmentos literais vencem parâmetros: /tasks/overdue antes de /tasks/{id}
+	sort.Slice(spec.routes, func(i, j int) bool {
+		a, b := spec.routes[i], spec.routes[j]
+		if literalCount(a) != literalCount(b) {
+			return literalCount(a) > literalCount(b)
+		}
+		return a.path < b.path
+	})
+
+	return &spec, nil
+}
+
+// JSON retorna o documento como publicado em /openapi.json
+func JSON() []byte {
+	return document
+}
+
+// FindPath retorna o caminho do documento que casa com urlPath, o item e os
+// valores dos parâmetros de caminho
+func (s *Spec) FindPath(urlPath string) (string, *PathItem, map[string]string, bool) {
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	for _, route := range s.routes {
+		if params, ok := route.match(segments); ok {
+			return route.path, route.item, params, true
+		}
+	}
+	return "", nil, nil, false
+}
+
+// Operations lista as operações do documento como "MÉTODO caminho", em ordem
+func (s *Spec) Operations() []string {
+	var ops []string
+	for path, item := range s.Paths {
+		for method := range item.Operations {
+			ops = append(ops, method+" "+path)
+		}
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// match compara os segmentos, capturando os parâmetros {nome}
+func (r *route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, segment := range r.segments {
+		if name, ok := paramName(segment); ok {
+			if segments[i] == "" {
+				return nil, false
+			}
+			params[name] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// literalCount conta os segmentos que não são parâmetros
+func literalCount(r *route) int {
+	count := 0
+	for _, segment := range r.segments {
+		if _, ok := paramName(segment); !ok {
+			count++
+		}
+	}
+	return count
+}
+
+// paramName extrai o nome de um segmento {nome}
+func paramName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ContentType é o tipo das respostas de erro (RFC 7807)
+const ContentType = "application/problem+json"
+
+// typePrefix forma o URI "type" a partir do código do erro
+const typePrefix = "urn:kanban:problem:"
+
+// Códigos genéricos, usados por todas as camadas HTTP. Os erros do domínio
+// têm códigos próprios na tabela dos handlers.
+const (
+	CodeInvalidRequestBody   = "invalid_request_body"
+	CodeValidationFailed     = "validation_failed"
+	CodeUnknownField         = "unknown_field"
+	CodeResourceNotFound     = "resource_not_found"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeInternalError        = "internal_error"
+)
+
+// Problem é o corpo application/problem+json. Code é estável e identifica o
+// erro para os clientes; Detail é uma mensagem para pessoas e pode mudar.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError aponta o campo da requisição que não passou na validação
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// New cria um Problem com o título padrão do status
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   TypeURI(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// TypeURI retorna o URI "type" do código, ex: urn:kanban:problem:task_not_found
+func TypeURI(code string) string {
+	return typePrefix + code
+}
+
+// Write escreve o problema com o Content-Type e o status correspondentes
+func Write(w http.ResponseWriter, p *Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// Error escreve um problema sem detalhes de campo
+func Error(w http.ResponseWriter, status int, code, detail string) {
+	Write(w, New(status, code, detail))
+}
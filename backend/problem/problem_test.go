@@ -0,0 +1,56 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+func TestWrite(t *testing.T) {
+	rec := httptest.NewRecorder()
+	p := New(http.StatusBadRequest, "invalid_title", "title is required")
+	p.Errors = []FieldError{{Field: "title", Code: "invalid_title", Message: "title is required"}}
+	Write(rec, p)
+
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != ContentType {
+		t.Errorf("expected 400 %s, got %d %s", ContentType, rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	want := map[string]any{
+		"type":   "urn:kanban:problem:invalid_title",
+		"title":  "Bad Request",
+		"status": float64(400),
+		"detail": "title is required",
+		"code":   "invalid_title",
+	}
+	for name, value := range want {
+		if body[name] != value {
+			t.Errorf("expected %s %v, got %v", name, value, body[name])
+		}
+	}
+	if errs, _ := body["errors"].([]any); len(errs) != 1 {
+		t.Errorf("expected one field error, got %v", body["errors"])
+	}
+}
+
+func TestErrorOmitsEmptyMembers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Error(rec, http.StatusNotFound, CodeResourceNotFound, "")
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, ok := body["detail"]; ok {
+		t.Errorf("expected no detail, got %v", body)
+	}
+	if _, ok := body["errors"]; ok {
+		t.Errorf("expected no errors, got %v", body)
+	}
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// Formatos de relatório disponíveis
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Nomes dos arquivos de template; os mesmos nomes são procurados no diretório de sobrescrita
+const (
+	markdownTemplate = "board.md.tmpl"
+	htmlTemplate     = "board.html.tmpl"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format, use markdown or html")
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// funcs são as funções disponíveis nos templates
+var funcs = map[string]any{
+	"date":     func(v any) string { return formatTime(v, "02/01/2006") },
+	"datetime": func(v any) string { return formatTime(v, "02/01/2006 15:04") },
+	"join":     strings.Join,
+	// indent prefixa cada linha do texto, útil para descrições em Markdown
+	"indent": func(prefix, text string) string {
+		return prefix + strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n"+prefix)
+	},
+}
+
+// Renderer gera os relatórios do quadro a partir dos templates
+type Renderer struct {
+	markdown *texttemplate.Template
+	html     *htmltemplate.Template
+}
+
+// NewRenderer carrega os templates padrão e, se dir não for vazio, os arquivos
+// board.md.tmpl e board.html.tmpl encontrados nele. Os arquivos do diretório
+// são lidos depois dos padrão, então podem substituir o relatório inteiro ou
+// apenas os blocos ({{define "task"}}...) que quiserem.
+func NewRenderer(dir string) (*Renderer, error) {
+	markdown := texttemplate.New(markdownTemplate).Funcs(funcs)
+	if _, err := markdown.ParseFS(defaultTemplates, "templates/"+markdownTemplate); err != nil {
+		return nil, err
+	}
+	html := htmltemplate.New(htmlTemplate).Funcs(funcs)
+	if _, err := html.ParseFS(defaultTemplates, "templates/"+htmlTemplate); err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		if override, ok, err := readOverride(dir, markdownTemplate); err != nil {
+			return nil, err
+		} else if ok {
+			if _, err := markdown.Parse(override); err != nil {
+				return nil, fmt.Errorf("%s: %w", markdownTemplate, err)
+			}
+		}
+		if override, ok, err := readOverride(dir, htmlTemplate); err != nil {
+			return nil, err
+		} else if ok {
+			if _, err := html.Parse(override); err != nil {
+				return nil, fmt.Errorf("%s: %w", htmlTemplate, err)
+			}
+		}
+	}
+
+	return &Renderer{markdown: markdown, html: html}, nil
+}
+
+// ContentType retorna o tipo MIME do formato
+func ContentType(format string) string {
+	if format == FormatHTML {
+		return "text/html; charset=utf-8"
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// Render escreve o relatório no formato pedido
+func (r *Renderer) Render(w io.Writer, format string, board *models.BoardReport) error {
+	switch format {
+	case FormatMarkdown:
+		return r.markdown.Execute(w, board)
+	case FormatHTML:
+		return r.html.Execute(w, board)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// readOverride lê o template do diretório de sobrescrita, se existir
+func readOverride(dir, name string) (string, bool, error) {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(content), true, nil
+}
+
+// formatTime formata time.Time ou *time.Time, retornando vazio para ponteiro nulo
+func formatTime(v any, layout string) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t != nil {
+			return t.Format(layout)
+		}
+	}
+	return ""
+}
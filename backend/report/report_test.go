@@ -0,0 +1,91 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+func sampleBoard() *models.BoardReport {
+	now := time.Date(2025, 1, 8, 9, 30, 0, 0, time.UTC)
+	task := &models.Task{ID: "1", Title: "Fix <login>", Description: "first\nsecond", Status: models.StatusTodo, Priority: models.PriorityHigh}
+	done := &models.Task{ID: "2", Title: "Ship", Status: models.StatusDone, Priority: models.PriorityMedium, CompletedAt: &now}
+	return &models.BoardReport{
+		GeneratedAt: now,
+		Since:       now.Add(-7 * 24 * time.Hour),
+		Total:       2,
+		Columns: []models.BoardColumn{
+			{Status: models.StatusTodo, Name: "A Fazer", Tasks: []models.ReportTask{{Task: task, LabelNames: []string{"bug"}}}},
+			{Status: models.StatusDone, Name: "Concluídas", Tasks: []models.ReportTask{{Task: done}}},
+		},
+		Completed: []models.ReportTask{{Task: done}},
+	}
+}
+
+func TestRendererDefaultTemplates(t *testing.T) {
+	renderer, err := NewRenderer("")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	var md strings.Builder
+	if err := renderer.Render(&md, FormatMarkdown, sampleBoard()); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	for _, want := range []string{"# Quadro em 08/01/2025 09:30", "## A Fazer (1)", "- **Fix <login>** (high) · bug", "  > first\n  > second", "## Concluídas desde 01/01/2025 (1)"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, md.String())
+		}
+	}
+
+	var html strings.Builder
+	if err := renderer.Render(&html, FormatHTML, sampleBoard()); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !strings.Contains(html.String(), "Fix &lt;login&gt;") || strings.Contains(html.String(), "Fix <login>") {
+		t.Errorf("expected task title to be escaped in HTML, got:\n%s", html.String())
+	}
+
+	if err := renderer.Render(&html, "pdf", sampleBoard()); err != ErrUnsupportedFormat {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
+
+func TestRendererOverridesBlocks(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "task"}}* {{.Title}} [{{.Status}}]
+{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "board.md.tmpl"), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	renderer, err := NewRenderer(dir)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	var md strings.Builder
+	if err := renderer.Render(&md, FormatMarkdown, sampleBoard()); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if !strings.Contains(md.String(), "* Fix <login> [todo]") || !strings.Contains(md.String(), "## A Fazer (1)") {
+		t.Errorf("expected overridden task block inside the default layout, got:\n%s", md.String())
+	}
+}
+
+func TestNewRendererInvalidOverride(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "board.html.tmpl"), []byte("{{.Title"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewRenderer(dir); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// AttachmentRepository armazena os metadados dos anexos; o conteúdo fica em um storage.BlobStore
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	GetByTask(taskID string) ([]*models.Attachment, error)
+	GetByID(id string) (*models.Attachment, error)
+	Delete(id string) error
+}
+
+type InMemoryAttachmentRepository struct {
+	attachments map[string]*models.Attachment
+	mu          sync.RWMutex
+}
+
+// NewInMemoryAttachmentRepository cria uma nova instância do repositório de anexos em memória
+func NewInMemoryAttachmentRepository() *InMemoryAttachmentRepository {
+	return &InMemoryAttachmentRepository{
+		attachments: make(map[string]*models.Attachment),
+		mu:          sync.RWMutex{},
+	}
+}
+
+// Create adiciona os metadados de um novo anexo
+func (r *InMemoryAttachmentRepository) Create(attachment *models.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attachments[attachment.ID] = attachment
+	return nil
+}
+
+// GetByTask retorna os anexos da tarefa em ordem de envio
+func (r *InMemoryAttachmentRepository) GetByTask(taskID string) ([]*models.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	attachments := make([]*models.Attachment, 0)
+	for _, attachment := range r.attachments {
+		if attachment.TaskID == taskID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+// GetByID busca um anexo específico pelo ID
+func (r *InMemoryAttachmentRepository) GetByID(id string) (*models.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	attachment, exists := r.attachments[id]
+	if !exists {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// Delete remove os metadados de um anexo pelo ID
+func (r *InMemoryAttachmentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.attachments[id]; !exists {
+		return ErrAttachmentNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func TestInMemoryAttachmentRepositoryGetByTask(t *testing.T) {
+	repo := NewInMemoryAttachmentRepository()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_ = repo.Create(&models.Attachment{ID: "2", TaskID: "t1", CreatedAt: base.Add(time.Second)})
+	_ = repo.Create(&models.Attachment{ID: "1", TaskID: "t1", CreatedAt: base})
+	_ = repo.Create(&models.Attachment{ID: "3", TaskID: "t2", CreatedAt: base})
+
+	attachments, err := repo.GetByTask("t1")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(attachments) != 2 || attachments[0].ID != "1" {
+		t.Errorf("expected attachments [1 2], got %v", attachments)
+	}
+}
+
+func TestInMemoryAttachmentRepositoryDelete(t *testing.T) {
+	repo := NewInMemoryAttachmentRepository()
+	_ = repo.Create(&models.Attachment{ID: "1", TaskID: "t1"})
+
+	if err := repo.Delete("1"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := repo.GetByID("1"); err != ErrAttachmentNotFound {
+		t.Errorf("expected ErrAttachmentNotFound, got %v", err)
+	}
+	if err := repo.Delete("1"); err != ErrAttachmentNotFound {
+		t.Errorf("expected ErrAttachmentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+
+type CommentRepository interface {
+	Create(comment *models.Comment) error
+	GetByTask(taskID string) ([]*models.Comment, error)
+	GetByID(id string) (*models.Comment, error)
+	Update(comment *models.Comment) error
+	Delete(id string) error
+	DeleteByTask(taskID string) error
+}
+
+type InMemoryCommentRepository struct {
+	comments map[string]*models.Comment
+	mu       sync.RWMutex
+}
+
+// NewInMemoryCommentRepository cria uma nova instância do repositório de comentários em memória
+func NewInMemoryCommentRepository() *InMemoryCommentRepository {
+	return &InMemoryCommentRepository{
+		comments: make(map[string]*models.Comment),
+		mu:       sync.RWMutex{},
+	}
+}
+
+// Create adiciona um novo comentário ao repositório
+func (r *InMemoryCommentRepository) Create(comment *models.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+// GetByTask retorna os comentários da tarefa em ordem cronológica
+func (r *InMemoryCommentRepository) GetByTask(taskID string) ([]*models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	comments := make([]*models.Comment, 0)
+	for _, comment := range r.comments {
+		if comment.TaskID == taskID {
+			comments = append(comments, comment)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, nil
+}
+
+// GetByID busca um comentário específico pelo ID
+func (r *InMemoryCommentRepository) GetByID(id string) (*models.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	comment, exists := r.comments[id]
+	if !exists {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// Update atualiza um comentário existente no repositório
+func (r *InMemoryCommentRepository) Update(comment *models.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.comments[comment.ID]; !exists {
+		return ErrCommentNotFound
+	}
+	r.comments[comment.ID] = comment
+	return nil
+}
+
+// Delete remove um comentário do repositório pelo ID
+func (r *InMemoryCommentRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.comments[id]; !exists {
+		return ErrCommentNotFound
+	}
+	delete(r.comments, id)
+	return nil
+}
+
+// DeleteByTask remove todos os comentários de uma tarefa
+func (r *InMemoryCommentRepository) DeleteByTask(taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, comment := range r.comments {
+		if comment.TaskID == taskID {
+			delete(r.comments, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func TestInMemoryCommentRepositoryGetByTaskOrdered(t *testing.T) {
+	repo := NewInMemoryCommentRepository()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_ = repo.Create(&models.Comment{ID: "2", TaskID: "t1", Body: "second", CreatedAt: base.Add(time.Minute)})
+	_ = repo.Create(&models.Comment{ID: "1", TaskID: "t1", Body: "first", CreatedAt: base})
+	_ = repo.Create(&models.Comment{ID: "3", TaskID: "t2", Body: "other", CreatedAt: base})
+
+	comments, err := repo.GetByTask("t1")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(comments) != 2 || comments[0].ID != "1" || comments[1].ID != "2" {
+		t.Errorf("expected comments [1 2], got %v", comments)
+	}
+}
+
+func TestInMemoryCommentRepositoryDeleteByTask(t *testing.T) {
+	repo := NewInMemoryCommentRepository()
+	_ = repo.Create(&models.Comment{ID: "1", TaskID: "t1"})
+	_ = repo.Create(&models.Comment{ID: "2", TaskID: "t2"})
+
+	if err := repo.DeleteByTask("t1"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if _, err := repo.GetByID("1"); err != ErrCommentNotFound {
+		t.Errorf("expected ErrCommentNotFound, got %v", err)
+	}
+	if _, err := repo.GetByID("2"); err != nil {
+		t.Errorf(msgExpectedNoError, err)
+	}
+}
+
+func TestInMemoryCommentRepositoryNotFound(t *testing.T) {
+	repo := NewInMemoryCommentRepository()
+
+	if err := repo.Update(&models.Comment{ID: "missing"}); err != ErrCommentNotFound {
+		t.Errorf("expected ErrCommentNotFound, got %v", err)
+	}
+	if err := repo.Delete("missing"); err != ErrCommentNotFound {
+		t.Errorf("expected ErrCommentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"slices"
+	"sync"
+)
+
+// DependencyRepository armazena as arestas "taskID é bloqueada por blockerID".
+// GetBlockers e GetDependents retornam os IDs em ordem crescente.
+type DependencyRepository interface {
+	Add(taskID, blockerID string) error
+	Remove(taskID, blockerID string) error
+	GetBlockers(taskID string) ([]string, error)
+	GetDependents(blockerID string) ([]string, error)
+	RemoveTask(taskID string) error
+}
+
+type InMemoryDependencyRepository struct {
+	blockers   map[string]map[string]bool
+	dependents map[string]map[string]bool
+	mu         sync.RWMutex
+}
+
+// NewInMemoryDependencyRepository cria uma nova instância do repositório de dependências em memória
+func NewInMemoryDependencyRepository() *InMemoryDependencyRepository {
+	return &InMemoryDependencyRepository{
+		blockers:   make(map[string]map[string]bool),
+		dependents: make(map[string]map[string]bool),
+		mu:         sync.RWMutex{},
+	}
+}
+
+// Add registra que taskID é bloqueada por blockerID
+func (r *InMemoryDependencyRepository) Add(taskID, blockerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	addEdge(r.blockers, taskID, blockerID)
+	addEdge(r.dependents, blockerID, taskID)
+	return nil
+}
+
+// Remove apaga a aresta entre taskID e blockerID, se existir
+func (r *InMemoryDependencyRepository) Remove(taskID, blockerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	removeEdge(r.blockers, taskID, blockerID)
+	removeEdge(r.dependents, blockerID, taskID)
+	return nil
+}
+
+// GetBlockers retorna os IDs das tarefas que bloqueiam taskID
+func (r *InMemoryDependencyRepository) GetBlockers(taskID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return edgeKeys(r.blockers[taskID]), nil
+}
+
+// GetDependents retorna os IDs das tarefas bloqueadas por blockerID
+func (r *InMemoryDependencyRepository) GetDependents(blockerID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return edgeKeys(r.dependents[blockerID]), nil
+}
+
+// RemoveTask apaga todas as arestas em que a tarefa aparece
+func (r *InMemoryDependencyRepository) RemoveTask(taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for blockerID := range r.blockers[taskID] {
+		removeEdge(r.dependents, blockerID, taskID)
+	}
+	for dependentID := range r.dependents[taskID] {
+		removeEdge(r.blockers, dependentID, taskID)
+	}
+	delete(r.blockers, taskID)
+	delete(r.dependents, taskID)
+	return nil
+}
+
+// addEdge adiciona to ao conjunto de vizinhos de from
+func addEdge(edges map[string]map[string]bool, from, to string) {
+	if edges[from] == nil {
+		edges[from] = make(map[string]bool)
+	}
+	edges[from][to] = true
+}
+
+// removeEdge remove to do conjunto de vizinhos de from
+func removeEdge(edges map[string]map[string]bool, from, to string) {
+	delete(edges[from], to)
+	if len(edges[from]) == 0 {
+		delete(edges, from)
+	}
+}
+
+// edgeKeys converte um conjunto de vizinhos em slice ordenado, para que as
+// respostas não dependam da ordem de iteração do map
+func edgeKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
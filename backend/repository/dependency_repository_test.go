@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInMemoryDependencyRepositoryAddAndGet(t *testing.T) {
+	repo := NewInMemoryDependencyRepository()
+
+	if err := repo.Add("b", "a"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	blockers, _ := repo.GetBlockers("b")
+	if len(blockers) != 1 || blockers[0] != "a" {
+		t.Errorf("expected blockers [a], got %v", blockers)
+	}
+
+	dependents, _ := repo.GetDependents("a")
+	if len(dependents) != 1 || dependents[0] != "b" {
+		t.Errorf("expected dependents [b], got %v", dependents)
+	}
+}
+
+func TestInMemoryDependencyRepositoryReturnsSortedIDs(t *testing.T) {
+	repo := NewInMemoryDependencyRepository()
+	ids := []string{"e", "b", "d", "a", "c"}
+	for _, id := range ids {
+		_ = repo.Add("task", id)
+		_ = repo.Add(id, "blocker")
+	}
+
+	want := []string{"a", "b", "c", "d", "e"}
+	for range 10 {
+		if blockers, _ := repo.GetBlockers("task"); !slices.Equal(blockers, want) {
+			t.Fatalf("expected blockers %v, got %v", want, blockers)
+		}
+		if dependents, _ := repo.GetDependents("blocker"); !slices.Equal(dependents, want) {
+			t.Fatalf("expected dependents %v, got %v", want, dependents)
+		}
+	}
+}
+
+func TestInMemoryDependencyRepositoryRemove(t *testing.T) {
+	repo := NewInMemoryDependencyRepository()
+	_ = repo.Add("b", "a")
+
+	if err := repo.Remove("b", "a"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	blockers, _ := repo.GetBlockers("b")
+	dependents, _ := repo.GetDependents("a")
+	if len(blockers) != 0 || len(dependents) != 0 {
+		t.Errorf("expected no edges, got blockers %v and dependents %v", blockers, dependents)
+	}
+}
+
+func TestInMemoryDependencyRepositoryRemoveTask(t *testing.T) {
+	repo := NewInMemoryDependencyRepository()
+	_ = repo.Add("b", "a")
+	_ = repo.Add("c", "b")
+
+	if err := repo.RemoveTask("b"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if dependents, _ := repo.GetDependents("a"); len(dependents) != 0 {
+		t.Errorf("expected no dependents of a, got %v", dependents)
+	}
+	if blockers, _ := repo.GetBlockers("c"); len(blockers) != 0 {
+		t.Errorf("expected no blockers of c, got %v", blockers)
+	}
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrFeedTokenNotFound = errors.New("feed token not found")
+
+type FeedTokenRepository interface {
+	Create(token *models.FeedToken) error
+	GetAll() ([]*models.FeedToken, error)
+	GetByHash(hash string) (*models.FeedToken, error)
+	Delete(id string) error
+}
+
+type InMemoryFeedTokenRepository struct {
+	tokens map[string]*models.FeedToken
+	mu     sync.RWMutex
+}
+
+// NewInMemoryFeedTokenRepository cria uma nova instância do repositório de tokens de feed em memória
+func NewInMemoryFeedTokenRepository() *InMemoryFeedTokenRepository {
+	return &InMemoryFeedTokenRepository{
+		tokens: make(map[string]*models.FeedToken),
+		mu:     sync.RWMutex{},
+	}
+}
+
+// Create adiciona um novo token ao repositório
+func (r *InMemoryFeedTokenRepository) Create(token *models.FeedToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token.ID] = token
+	return nil
+}
+
+// GetAll retorna todos os tokens ordenados por data de criação
+func (r *InMemoryFeedTokenRepository) GetAll() ([]*models.FeedToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tokens := make([]*models.FeedToken, 0, len(r.tokens))
+	for _, token := range r.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].CreatedAt.Before(tokens[j].CreatedAt) })
+	return tokens, nil
+}
+
+// GetByHash busca o token pelo hash do valor secreto
+func (r *InMemoryFeedTokenRepository) GetByHash(hash string) (*models.FeedToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return nil, ErrFeedTokenNotFound
+}
+
+// Delete remove um token do repositório pelo ID
+func (r *InMemoryFeedTokenRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.tokens[id]; !exists {
+		return ErrFeedTokenNotFound
+	}
+	delete(r.tokens, id)
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func TestInMemoryFeedTokenRepositoryGetByHash(t *testing.T) {
+	repo := NewInMemoryFeedTokenRepository()
+	_ = repo.Create(&models.FeedToken{ID: "1", User: "alice", TokenHash: "abc"})
+
+	token, err := repo.GetByHash("abc")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if token.User != "alice" {
+		t.Errorf("expected user alice, got %s", token.User)
+	}
+
+	if _, err := repo.GetByHash("other"); err != ErrFeedTokenNotFound {
+		t.Errorf("expected ErrFeedTokenNotFound, got %v", err)
+	}
+}
+
+func TestInMemoryFeedTokenRepositoryDelete(t *testing.T) {
+	repo := NewInMemoryFeedTokenRepository()
+	_ = repo.Create(&models.FeedToken{ID: "1", TokenHash: "abc"})
+
+	if err := repo.Delete("1"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := repo.GetByHash("abc"); err != ErrFeedTokenNotFound {
+		t.Errorf("expected revoked token to be gone, got %v", err)
+	}
+	if err := repo.Delete("1"); err != ErrFeedTokenNotFound {
+		t.Errorf("expected ErrFeedTokenNotFound, got %v", err)
+	}
+}
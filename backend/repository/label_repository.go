@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrLabelNotFound = errors.New("label not found")
+
+type LabelRepository interface {
+	Create(label *models.Label) error
+	GetAll() ([]*models.Label, error)
+	GetByID(id string) (*models.Label, error)
+	Update(label *models.Label) error
+	Delete(id string) error
+}
+
+type InMemoryLabelRepository struct {
+	labels map[string]*models.Label
+	mu     sync.RWMutex
+}
+
+// NewInMemoryLabelRepository cria uma nova instância do repositório de etiquetas em memória
+func NewInMemoryLabelRepository() *InMemoryLabelRepository {
+	return &InMemoryLabelRepository{
+		labels: make(map[string]*models.Label),
+		mu:     sync.RWMutex{},
+	}
+}
+
+// Create adiciona uma nova etiqueta ao repositório
+func (r *InMemoryLabelRepository) Create(label *models.Label) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.labels[label.ID] = label
+	return nil
+}
+
+// GetAll retorna todas as etiquetas armazenadas
+func (r *InMemoryLabelRepository) GetAll() ([]*models.Label, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	labels := make([]*models.Label, 0, len(r.labels))
+	for _, label := range r.labels {
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// GetByID busca uma etiqueta específica pelo ID
+func (r *InMemoryLabelRepository) GetByID(id string) (*models.Label, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	label, exists := r.labels[id]
+	if !exists {
+		return nil, ErrLabelNotFound
+	}
+	return label, nil
+}
+
+// Update atualiza uma etiqueta existente no repositório
+func (r *InMemoryLabelRepository) Update(label *models.Label) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.labels[label.ID]; !exists {
+		return ErrLabelNotFound
+	}
+	r.labels[label.ID] = label
+	return nil
+}
+
+// Delete remove uma etiqueta do repositório pelo ID
+func (r *InMemoryLabelRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.labels[id]; !exists {
+		return ErrLabelNotFound
+	}
+	delete(r.labels, id)
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func TestInMemoryLabelRepositoryCreateAndGet(t *testing.T) {
+	repo := NewInMemoryLabelRepository()
+
+	if err := repo.Create(&models.Label{ID: "1", Name: "bug", Color: "#ff0000"}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	label, err := repo.GetByID("1")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if label.Name != "bug" {
+		t.Errorf("expected name bug, got %s", label.Name)
+	}
+}
+
+func TestInMemoryLabelRepositoryNotFound(t *testing.T) {
+	repo := NewInMemoryLabelRepository()
+
+	if _, err := repo.GetByID("missing"); err != ErrLabelNotFound {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+	if err := repo.Update(&models.Label{ID: "missing"}); err != ErrLabelNotFound {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+	if err := repo.Delete("missing"); err != ErrLabelNotFound {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+}
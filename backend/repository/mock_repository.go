@@ -7,11 +7,13 @@ import (
 )
 
 type MockTaskRepository struct {
-	CreateFunc  func(task *models.Task) error
-	GetAllFunc  func() ([]*models.Task, error)
-	GetByIDFunc func(id string) (*models.Task, error)
-	UpdateFunc  func(task *models.Task) error
-	DeleteFunc  func(id string) error
+	CreateFunc     func(task *models.Task) error
+	GetAllFunc     func() ([]*models.Task, error)
+	GetByIDFunc    func(id string) (*models.Task, error)
+	GetByLabelFunc func(labelID string) ([]*models.Task, error)
+	UpdateFunc     func(task *models.Task) error
+	DeleteFunc     func(id string) error
+	RevisionFunc   func() (Revision, error)
 }
 
 // Create executa a função mock de criação se definida
@@ -38,6 +40,14 @@ func (m *MockTaskRepository) GetByID(id string) (*models.Task, error) {
 	return nil, nil
 }
 
+// GetByLabel executa a função mock de busca por etiqueta se definida
+func (m *MockTaskRepository) GetByLabel(labelID string) ([]*models.Task, error) {
+	if m.GetByLabelFunc != nil {
+		return m.GetByLabelFunc(labelID)
+	}
+	return nil, nil
+}
+
 // Update executa a função mock de atualização se definida
 func (m *MockTaskRepository) Update(task *models.Task) error {
 	if m.UpdateFunc != nil {
@@ -54,4 +64,12 @@ func (m *MockTaskRepository) Delete(id string) error {
 	return nil
 }
 
+// Revision executa a função mock de revisão se definida
+func (m *MockTaskRepository) Revision() (Revision, error) {
+	if m.RevisionFunc != nil {
+		return m.RevisionFunc()
+	}
+	return Revision{}, nil
+}
+
 var ErrMockError = errors.New("mock error")
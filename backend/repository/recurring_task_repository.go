@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrRecurringTaskNotFound = errors.New("recurring task not found")
+
+type RecurringTaskRepository interface {
+	Create(task *models.RecurringTask) error
+	GetAll() ([]*models.RecurringTask, error)
+	GetByID(id string) (*models.RecurringTask, error)
+	Update(task *models.RecurringTask) error
+	Delete(id string) error
+}
+
+type InMemoryRecurringTaskRepository struct {
+	tasks map[string]*models.RecurringTask
+	mu    sync.RWMutex
+}
+
+// NewInMemoryRecurringTaskRepository cria uma nova instância do repositório de tarefas recorrentes em memória
+func NewInMemoryRecurringTaskRepository() *InMemoryRecurringTaskRepository {
+	return &InMemoryRecurringTaskRepository{
+		tasks: make(map[string]*models.RecurringTask),
+		mu:    sync.RWMutex{},
+	}
+}
+
+// Create adiciona uma nova tarefa recorrente ao repositório
+func (r *InMemoryRecurringTaskRepository) Create(task *models.RecurringTask) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks[task.ID] = task
+	return nil
+}
+
+// GetAll retorna todas as tarefas recorrentes armazenadas
+func (r *InMemoryRecurringTaskRepository) GetAll() ([]*models.RecurringTask, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := make([]*models.RecurringTask, 0, len(r.tasks))
+	for _, task := range r.tasks {
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// GetByID busca uma tarefa recorrente específica pelo ID
+func (r *InMemoryRecurringTaskRepository) GetByID(id string) (*models.RecurringTask, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	task, exists := r.tasks[id]
+	if !exists {
+		return nil, ErrRecurringTaskNotFound
+	}
+	return task, nil
+}
+
+// Update atualiza uma tarefa recorrente existente no repositório
+func (r *InMemoryRecurringTaskRepository) Update(task *models.RecurringTask) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.tasks[task.ID]; !exists {
+		return ErrRecurringTaskNotFound
+	}
+	r.tasks[task.ID] = task
+	return nil
+}
+
+// Delete remove uma tarefa recorrente do repositório pelo ID
+func (r *InMemoryRecurringTaskRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.tasks[id]; !exists {
+		return ErrRecurringTaskNotFound
+	}
+	delete(r.tasks, id)
+	return nil
+}
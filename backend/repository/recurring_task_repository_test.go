@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func TestInMemoryRecurringTaskRepositoryCRUD(t *testing.T) {
+	repo := NewInMemoryRecurringTaskRepository()
+	task := &models.RecurringTask{ID: "1", Title: "Update dependencies", Schedule: "@weekly"}
+
+	if err := repo.Create(task); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	task.Title = "Update deps"
+	if err := repo.Update(task); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	retrieved, err := repo.GetByID("1")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if retrieved.Title != "Update deps" {
+		t.Errorf("expected title Update deps, got %s", retrieved.Title)
+	}
+
+	if err := repo.Delete("1"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := repo.GetByID("1"); err != ErrRecurringTaskNotFound {
+		t.Errorf("expected ErrRecurringTaskNotFound, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"errors"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/acauhi/kanban-backend/models"
 )
@@ -13,20 +15,35 @@ type TaskRepository interface {
 	Create(task *models.Task) error
 	GetAll() ([]*models.Task, error)
 	GetByID(id string) (*models.Task, error)
+	GetByLabel(labelID string) ([]*models.Task, error)
 	Update(task *models.Task) error
 	Delete(id string) error
+	Revision() (Revision, error)
+}
+
+// Revision identifica um estado do quadro: Number cresce a cada escrita nas
+// tarefas e UpdatedAt guarda quando a última aconteceu. Epoch distingue
+// instâncias do repositório, já que o contador em memória recomeça do zero a
+// cada inicialização.
+type Revision struct {
+	Epoch     int64
+	Number    uint64
+	UpdatedAt time.Time
 }
 
 type InMemoryTaskRepository struct {
-	tasks map[string]*models.Task
-	mu    sync.RWMutex
+	tasks    map[string]*models.Task
+	revision Revision
+	mu       sync.RWMutex
 }
 
 // NewInMemoryTaskRepository cria uma nova instância do repositório em memória
 func NewInMemoryTaskRepository() *InMemoryTaskRepository {
+	now := time.Now()
 	return &InMemoryTaskRepository{
-		tasks: make(map[string]*models.Task),
-		mu:    sync.RWMutex{},
+		tasks:    make(map[string]*models.Task),
+		revision: Revision{Epoch: now.UnixNano(), UpdatedAt: now},
+		mu:       sync.RWMutex{},
 	}
 }
 
@@ -35,9 +52,23 @@ func (r *InMemoryTaskRepository) Create(task *models.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.tasks[task.ID] = task
+	r.bump()
 	return nil
 }
 
+// Revision retorna a revisão atual do quadro
+func (r *InMemoryTaskRepository) Revision() (Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.revision, nil
+}
+
+// bump avança a revisão; deve ser chamado com o lock de escrita
+func (r *InMemoryTaskRepository) bump() {
+	r.revision.Number++
+	r.revision.UpdatedAt = time.Now()
+}
+
 // GetAll retorna todas as tarefas armazenadas
 func (r *InMemoryTaskRepository) GetAll() ([]*models.Task, error) {
 	r.mu.RLock()
@@ -60,6 +91,19 @@ func (r *InMemoryTaskRepository) GetByID(id string) (*models.Task, error) {
 	return task, nil
 }
 
+// GetByLabel retorna as tarefas que possuem a etiqueta informada
+func (r *InMemoryTaskRepository) GetByLabel(labelID string) ([]*models.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := make([]*models.Task, 0)
+	for _, task := range r.tasks {
+		if slices.Contains(task.Labels, labelID) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
 // Update atualiza uma tarefa existente no repositório
 func (r *InMemoryTaskRepository) Update(task *models.Task) error {
 	r.mu.Lock()
@@ -68,6 +112,7 @@ func (r *InMemoryTaskRepository) Update(task *models.Task) error {
 		return ErrTaskNotFound
 	}
 	r.tasks[task.ID] = task
+	r.bump()
 	return nil
 }
 
@@ -79,5 +124,6 @@ func (r *InMemoryTaskRepository) Delete(id string) error {
 		return ErrTaskNotFound
 	}
 	delete(r.tasks, id)
+	r.bump()
 	return nil
 }
@@ -130,3 +130,62 @@ func TestInMemoryTaskRepositoryDeleteNotFound(t *testing.T) {
 		t.Errorf(msgExpectedErrTaskNotFound, err)
 	}
 }
+
+func TestInMemoryTaskRepositoryGetByLabel(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	_ = repo.Create(&models.Task{ID: "1", Title: "Bug", Labels: []string{"bug"}})
+	_ = repo.Create(&models.Task{ID: "2", Title: "Feature", Labels: []string{"feature"}})
+
+	tasks, err := repo.GetByLabel("bug")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "1" {
+		t.Errorf("expected only task 1, got %v", tasks)
+	}
+}
+
+func TestInMemoryTaskRepositoryRevision(t *testing.T) {
+	repo := NewInMemoryTaskRepository()
+	revision := func() Revision {
+		t.Helper()
+		rev, err := repo.Revision()
+		if err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+		return rev
+	}
+
+	initial := revision()
+	if initial.Number != 0 || initial.Epoch == 0 || initial.UpdatedAt.IsZero() {
+		t.Fatalf("expected a fresh revision with epoch and time, got %+v", initial)
+	}
+
+	task := &models.Task{ID: "1", Title: "Test Task"}
+	writes := []struct {
+		name  string
+		write func() error
+	}{
+		{"create", func() error { return repo.Create(task) }},
+		{"update", func() error { return repo.Update(task) }},
+		{"delete", func() error { return repo.Delete("1") }},
+	}
+	for i, w := range writes {
+		if err := w.write(); err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+		rev := revision()
+		if rev.Number != uint64(i+1) || rev.Epoch != initial.Epoch || rev.UpdatedAt.Before(initial.UpdatedAt) {
+			t.Errorf("%s: expected revision %d in the same epoch, got %+v", w.name, i+1, rev)
+		}
+	}
+
+	// Leituras e escritas que falham não mudam a revisão
+	repo.GetAll()
+	repo.GetByID("1")
+	repo.Update(task)
+	repo.Delete("1")
+	if rev := revision(); rev.Number != uint64(len(writes)) {
+		t.Errorf("expected revision %d, got %d", len(writes), rev.Number)
+	}
+}
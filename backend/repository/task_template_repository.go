@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrTaskTemplateNotFound = errors.New("task template not found")
+
+type TaskTemplateRepository interface {
+	Create(template *models.TaskTemplate) error
+	GetAll() ([]*models.TaskTemplate, error)
+	GetByID(id string) (*models.TaskTemplate, error)
+	Update(template *models.TaskTemplate) error
+	Delete(id string) error
+}
+
+type InMemoryTaskTemplateRepository struct {
+	templates map[string]*models.TaskTemplate
+	mu        sync.RWMutex
+}
+
+// NewInMemoryTaskTemplateRepository cria uma nova instância do repositório de modelos de tarefa em memória
+func NewInMemoryTaskTemplateRepository() *InMemoryTaskTemplateRepository {
+	return &InMemoryTaskTemplateRepository{
+		templates: make(map[string]*models.TaskTemplate),
+		mu:        sync.RWMutex{},
+	}
+}
+
+// Create adiciona um novo modelo ao repositório
+func (r *InMemoryTaskTemplateRepository) Create(template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[template.ID] = template
+	return nil
+}
+
+// GetAll retorna todos os modelos de tarefa armazenados
+func (r *InMemoryTaskTemplateRepository) GetAll() ([]*models.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	templates := make([]*models.TaskTemplate, 0, len(r.templates))
+	for _, template := range r.templates {
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// GetByID busca um modelo específico pelo ID
+func (r *InMemoryTaskTemplateRepository) GetByID(id string) (*models.TaskTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	template, exists := r.templates[id]
+	if !exists {
+		return nil, ErrTaskTemplateNotFound
+	}
+	return template, nil
+}
+
+// Update atualiza um modelo existente no repositório
+func (r *InMemoryTaskTemplateRepository) Update(template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.templates[template.ID]; !exists {
+		return ErrTaskTemplateNotFound
+	}
+	r.templates[template.ID] = template
+	return nil
+}
+
+// Delete remove um modelo do repositório pelo ID
+func (r *InMemoryTaskTemplateRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.templates[id]; !exists {
+		return ErrTaskTemplateNotFound
+	}
+	delete(r.templates, id)
+	return nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func TestInMemoryTaskTemplateRepositoryCRUD(t *testing.T) {
+	repo := NewInMemoryTaskTemplateRepository()
+
+	if err := repo.Create(&models.TaskTemplate{ID: "1", Name: "Bug report"}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	all, _ := repo.GetAll()
+	if len(all) != 1 {
+		t.Errorf("expected 1 template, got %d", len(all))
+	}
+
+	if err := repo.Delete("1"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := repo.GetByID("1"); err != ErrTaskTemplateNotFound {
+		t.Errorf("expected ErrTaskTemplateNotFound, got %v", err)
+	}
+	if err := repo.Update(&models.TaskTemplate{ID: "1"}); err != ErrTaskTemplateNotFound {
+		t.Errorf("expected ErrTaskTemplateNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// maxSearch limita a busca pela próxima ocorrência (ex: "0 0 30 2 *" nunca ocorre)
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// macros aceitas como atalho para expressões comuns
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Cron é uma expressão "minuto hora dia-do-mês mês dia-da-semana" já interpretada
+type Cron struct {
+	minute, hour, dom, month, dow bitset
+	domAny, dowAny                bool
+}
+
+type bitset uint64
+
+// has indica se o valor está no conjunto
+func (b bitset) has(v int) bool {
+	return b&(1<<uint(v)) != 0
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = [5]field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Parse interpreta uma expressão cron. Cada campo aceita "*", valores,
+// intervalos ("1-5"), listas ("1,15") e passos ("*/15", "8-18/2"), além
+// das macros @hourly, @daily, @weekly, @monthly e @yearly. No dia da semana,
+// 0 e 7 representam domingo.
+func Parse(expr string) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := macros[expr]; ok {
+		expr = macro
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidExpression, len(fields), len(parts))
+	}
+
+	var sets [5]bitset
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Domingo pode ser escrito como 0 ou 7
+	if sets[4].has(7) {
+		sets[4] |= 1
+	}
+
+	return &Cron{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+// Next retorna a primeira ocorrência estritamente posterior a after, no fuso
+// de after. Retorna o instante zero se não houver ocorrência nos próximos anos.
+func (c *Cron) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		if !c.month.has(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.hour.has(t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !c.minute.has(t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches aplica a regra do cron: se dia do mês e dia da semana forem
+// ambos restritos, basta um deles coincidir
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom.has(t.Day())
+	dowMatch := c.dow.has(int(t.Weekday()))
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseField converte um campo (com listas, intervalos e passos) em conjunto
+func parseField(expr string, f field) (bitset, error) {
+	var set bitset
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q in %s", ErrInvalidExpression, stepExpr, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangeExpr != "*" {
+			loExpr, hiExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = parseValue(loExpr, f); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = parseValue(hiExpr, f); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "5/15" equivale a "5-max/15"
+				hi = f.max
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%w: range %q in %s", ErrInvalidExpression, rangeExpr, f.name)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// parseValue converte um valor numérico validando os limites do campo
+func parseValue(expr string, f field) (int, error) {
+	v, err := strconv.Atoi(expr)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: %q out of range for %s (%d-%d)", ErrInvalidExpression, expr, f.name, f.min, f.max)
+	}
+	return v, nil
+}
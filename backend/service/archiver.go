@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Archiver executa periodicamente o arquivamento de tarefas concluídas
+type Archiver struct {
+	service  *TaskService
+	maxAge   time.Duration
+	interval time.Duration
+}
+
+// NewArchiver cria um job que arquiva, a cada interval, as tarefas
+// concluídas há mais de maxAge
+func NewArchiver(service *TaskService, maxAge, interval time.Duration) *Archiver {
+	return &Archiver{
+		service:  service,
+		maxAge:   maxAge,
+		interval: interval,
+	}
+}
+
+// RunOnce executa uma única rodada de arquivamento
+func (a *Archiver) RunOnce() (int, error) {
+	return a.service.ArchiveCompletedTasks(a.maxAge)
+}
+
+// Start executa o job em loop até que o contexto seja cancelado
+func (a *Archiver) Start(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := a.RunOnce()
+			if err != nil {
+				log.Printf("archiver: %v", err)
+				continue
+			}
+			if count > 0 {
+				log.Printf("archiver: %d task(s) archived", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestArchiverRunOnceArchivesOldCompletedTasks(t *testing.T) {
+	clock := newFakeClock()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock))
+	archiver := NewArchiver(svc, 7*24*time.Hour, time.Hour)
+
+	done := models.StatusDone
+	old, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Old"})
+	_, _ = svc.UpdateTask(old.ID, models.UpdateTaskRequest{Status: &done})
+
+	clock.Advance(5 * 24 * time.Hour)
+	recent, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Recent"})
+	_, _ = svc.UpdateTask(recent.ID, models.UpdateTaskRequest{Status: &done})
+	_, _ = svc.CreateTask(models.CreateTaskRequest{Title: "Todo"})
+
+	clock.Advance(3 * 24 * time.Hour)
+	count, err := archiver.RunOnce()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 archived task, got %d", count)
+	}
+
+	active, _ := svc.GetAllTasks()
+	if len(active) != 2 {
+		t.Errorf("expected 2 active tasks, got %d", len(active))
+	}
+
+	archived, _ := svc.GetArchivedTasks()
+	if len(archived) != 1 || archived[0].ID != old.ID {
+		t.Fatalf("expected task %s to be archived, got %v", old.ID, archived)
+	}
+	if archived[0].ArchivedAt == nil || !archived[0].ArchivedAt.Equal(clock.Now()) {
+		t.Errorf("expected archivedAt %v, got %v", clock.Now(), archived[0].ArchivedAt)
+	}
+
+	count, _ = archiver.RunOnce()
+	if count != 0 {
+		t.Errorf("expected already archived tasks to be skipped, got %d", count)
+	}
+}
+
+func TestTaskServiceUpdateTaskUnarchivesWhenLeavingDone(t *testing.T) {
+	clock := newFakeClock()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock))
+
+	done := models.StatusDone
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	_, _ = svc.UpdateTask(task.ID, models.UpdateTaskRequest{Status: &done})
+
+	clock.Advance(time.Hour)
+	_, _ = svc.ArchiveCompletedTasks(time.Minute)
+
+	todo := models.StatusTodo
+	updated, err := svc.UpdateTask(task.ID, models.UpdateTaskRequest{Status: &todo})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Archived || updated.ArchivedAt != nil || updated.CompletedAt != nil {
+		t.Errorf("expected task to be restored to the board, got %+v", updated)
+	}
+}
+
+func TestTaskServiceArchiveCompletedTasksRepositoryError(t *testing.T) {
+	mockRepo := &repository.MockTaskRepository{
+		GetAllFunc: func() ([]*models.Task, error) {
+			return nil, repository.ErrMockError
+		},
+	}
+	svc := NewTaskService(mockRepo)
+
+	_, err := svc.ArchiveCompletedTasks(time.Hour)
+	if err != repository.ErrMockError {
+		t.Errorf("expected ErrMockError, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/storage"
+)
+
+// MaxAttachmentSize é o tamanho máximo de um anexo (10 MiB)
+const MaxAttachmentSize = 10 << 20
+
+// sniffLength é a quantidade de bytes analisada por http.DetectContentType
+const sniffLength = 512
+
+var (
+	ErrInvalidFileName    = errors.New("file name is required")
+	ErrAttachmentTooLarge = errors.New("attachment exceeds the maximum size")
+)
+
+// WithAttachmentStorage define onde ficam os metadados e o conteúdo dos anexos
+func WithAttachmentStorage(attachments repository.AttachmentRepository, blobs storage.BlobStore) Option {
+	return func(s *TaskService) {
+		s.attachments = attachments
+		s.blobs = blobs
+	}
+}
+
+// AddAttachment grava o conteúdo no BlobStore e registra o anexo na tarefa.
+// O tipo do conteúdo é detectado pelos primeiros bytes, não pela extensão.
+func (s *TaskService) AddAttachment(taskID, fileName string, content io.Reader) (*models.Attachment, error) {
+	fileName = strings.TrimSpace(filepath.Base(filepath.ToSlash(fileName)))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, fieldError("file", ErrInvalidFileName)
+	}
+
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	attachment := &models.Attachment{
+		ID:          generateID(),
+		TaskID:      taskID,
+		FileName:    fileName,
+		ContentType: http.DetectContentType(head),
+		CreatedAt:   s.clock.Now(),
+	}
+
+	body := &maxSizeReader{r: io.MultiReader(bytes.NewReader(head), content), remaining: MaxAttachmentSize}
+	key := attachmentKey(attachment)
+	size, err := s.blobs.Put(key, body)
+	if err != nil {
+		_ = s.blobs.Delete(key)
+		return nil, err
+	}
+	attachment.Size = size
+
+	if err := s.attachments.Create(attachment); err != nil {
+		_ = s.blobs.Delete(key)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments lista os anexos da tarefa
+func (s *TaskService) GetAttachments(taskID string) ([]*models.Attachment, error) {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.attachments.GetByTask(taskID)
+}
+
+// OpenAttachment retorna os metadados e o conteúdo do anexo; o chamador deve fechar o blob
+func (s *TaskService) OpenAttachment(taskID, attachmentID string) (*models.Attachment, storage.Blob, error) {
+	attachment, err := s.findAttachment(taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blob, err := s.blobs.Get(attachmentKey(attachment))
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, blob, nil
+}
+
+// DeleteAttachment remove o anexo e seu conteúdo
+func (s *TaskService) DeleteAttachment(taskID, attachmentID string) error {
+	attachment, err := s.findAttachment(taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	return s.removeAttachment(attachment)
+}
+
+// deleteTaskAttachments remove todos os anexos de uma tarefa
+func (s *TaskService) deleteTaskAttachments(taskID string) error {
+	attachments, err := s.attachments.GetByTask(taskID)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := s.removeAttachment(attachment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAttachment apaga metadados e conteúdo, tolerando conteúdo já ausente
+func (s *TaskService) removeAttachment(attachment *models.Attachment) error {
+	if err := s.attachments.Delete(attachment.ID); err != nil {
+		return err
+	}
+	if err := s.blobs.Delete(attachmentKey(attachment)); err != nil && !errors.Is(err, storage.ErrBlobNotFound) {
+		return err
+	}
+	return nil
+}
+
+// findAttachment busca o anexo garantindo que ele pertence à tarefa
+func (s *TaskService) findAttachment(taskID, attachmentID string) (*models.Attachment, error) {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachments.GetByID(attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != taskID {
+		return nil, repository.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// attachmentKey monta a chave do conteúdo no BlobStore
+func attachmentKey(attachment *models.Attachment) string {
+	return "tasks/" + attachment.TaskID + "/" + attachment.ID
+}
+
+// maxSizeReader falha com ErrAttachmentTooLarge ao ultrapassar o limite,
+// em vez de truncar o conteúdo silenciosamente como io.LimitReader
+type maxSizeReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read lê do reader subjacente descontando do limite restante
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	if m.remaining < 0 {
+		return 0, ErrAttachmentTooLarge
+	}
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.r.Read(p)
+	m.remaining -= int64(n)
+	if m.remaining < 0 {
+		return n, ErrAttachmentTooLarge
+	}
+	return n, err
+}
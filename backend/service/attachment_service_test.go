@@ -0,0 +1,90 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/storage"
+)
+
+// pngHeader é a assinatura de um arquivo PNG, suficiente para a detecção de tipo
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestTaskServiceAddAttachment(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Bug"})
+
+	attachment, err := svc.AddAttachment(task.ID, "../screens/crash.txt", bytes.NewReader(pngHeader))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if attachment.FileName != "crash.txt" {
+		t.Errorf("expected file name crash.txt, got %s", attachment.FileName)
+	}
+	if attachment.ContentType != "image/png" {
+		t.Errorf("expected sniffed type image/png, got %s", attachment.ContentType)
+	}
+	if attachment.Size != int64(len(pngHeader)) {
+		t.Errorf("expected size %d, got %d", len(pngHeader), attachment.Size)
+	}
+
+	_, blob, err := svc.OpenAttachment(task.ID, attachment.ID)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	defer blob.Close()
+	data, _ := io.ReadAll(blob)
+	if !bytes.Equal(data, pngHeader) {
+		t.Errorf("expected stored content to match upload")
+	}
+}
+
+func TestTaskServiceAddAttachmentTooLarge(t *testing.T) {
+	blobs := storage.NewMemoryBlobStore()
+	attachments := repository.NewInMemoryAttachmentRepository()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithAttachmentStorage(attachments, blobs))
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Bug"})
+
+	content := strings.NewReader(strings.Repeat("a", MaxAttachmentSize+1))
+	_, err := svc.AddAttachment(task.ID, "big.txt", content)
+	if err != ErrAttachmentTooLarge {
+		t.Fatalf("expected ErrAttachmentTooLarge, got %v", err)
+	}
+
+	if stored, _ := attachments.GetByTask(task.ID); len(stored) != 0 {
+		t.Errorf("expected no attachment to be recorded, got %v", stored)
+	}
+}
+
+func TestTaskServiceAddAttachmentValidation(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Bug"})
+
+	if _, err := svc.AddAttachment(task.ID, " ", strings.NewReader("x")); !errors.Is(err, ErrInvalidFileName) {
+		t.Errorf("expected ErrInvalidFileName, got %v", err)
+	}
+	if _, err := svc.AddAttachment("missing", "a.txt", strings.NewReader("x")); err != repository.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestTaskServiceDeleteTaskRemovesAttachments(t *testing.T) {
+	blobs := storage.NewMemoryBlobStore()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(),
+		WithAttachmentStorage(repository.NewInMemoryAttachmentRepository(), blobs))
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Bug"})
+	attachment, _ := svc.AddAttachment(task.ID, "log.txt", strings.NewReader("stack trace"))
+
+	if err := svc.DeleteTask(task.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if _, err := blobs.Get(attachmentKey(attachment)); err != storage.ErrBlobNotFound {
+		t.Errorf("expected blob to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+// feedTokenBytes é a quantidade de bytes aleatórios de cada token de feed
+const feedTokenBytes = 32
+
+var (
+	ErrInvalidFeedUser  = errors.New("user is required")
+	ErrInvalidFeedToken = errors.New("invalid feed token")
+)
+
+// CalendarService emite os tokens do feed de calendário e seleciona as
+// tarefas publicadas nele
+type CalendarService struct {
+	tokens repository.FeedTokenRepository
+	tasks  *TaskService
+}
+
+// NewCalendarService cria uma nova instância do serviço de calendário
+func NewCalendarService(tokens repository.FeedTokenRepository, tasks *TaskService) *CalendarService {
+	return &CalendarService{
+		tokens: tokens,
+		tasks:  tasks,
+	}
+}
+
+// CreateFeedToken gera um token aleatório para o usuário. O valor só aparece
+// na resposta desta chamada; depois disso apenas o hash fica guardado.
+func (s *CalendarService) CreateFeedToken(req models.CreateFeedTokenRequest) (*models.FeedToken, error) {
+	user := strings.TrimSpace(req.User)
+	if user == "" {
+		return nil, fieldError("user", ErrInvalidFeedUser)
+	}
+
+	secret := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	value := base64.RawURLEncoding.EncodeToString(secret)
+
+	token := &models.FeedToken{
+		ID:        generateID(),
+		User:      user,
+		TokenHash: hashFeedToken(value),
+		CreatedAt: s.tasks.clock.Now(),
+	}
+	if err := s.tokens.Create(token); err != nil {
+		return nil, err
+	}
+
+	created := *token
+	created.Token = value
+	return &created, nil
+}
+
+// GetFeedTokens lista os tokens emitidos, sem o valor secreto
+func (s *CalendarService) GetFeedTokens() ([]*models.FeedToken, error) {
+	return s.tokens.GetAll()
+}
+
+// RevokeFeedToken remove o token, cortando o acesso ao feed
+func (s *CalendarService) RevokeFeedToken(id string) error {
+	return s.tokens.Delete(id)
+}
+
+// Authenticate retorna o token correspondente ao valor informado
+func (s *CalendarService) Authenticate(value string) (*models.FeedToken, error) {
+	if value == "" {
+		return nil, ErrInvalidFeedToken
+	}
+	token, err := s.tokens.GetByHash(hashFeedToken(value))
+	if errors.Is(err, repository.ErrFeedTokenNotFound) {
+		return nil, ErrInvalidFeedToken
+	}
+	return token, err
+}
+
+// GetCalendarTasks retorna as tarefas ativas com prazo, pela ordem do prazo,
+// com os nomes das etiquetas resolvidos
+func (s *CalendarService) GetCalendarTasks() ([]models.ReportTask, error) {
+	tasks, err := s.tasks.filterTasks(func(task *models.Task) bool {
+		return !task.Archived && task.DueDate != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := SortTasks(tasks, SortByDueDate, false); err != nil {
+		return nil, err
+	}
+
+	labelNames, err := s.tasks.labelNames()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]models.ReportTask, 0, len(tasks))
+	for _, task := range tasks {
+		entries = append(entries, reportTask(task, labelNames))
+	}
+	return entries, nil
+}
+
+// hashFeedToken calcula o hash guardado no lugar do token
+func hashFeedToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestCalendarServiceFeedTokens(t *testing.T) {
+	tokens := repository.NewInMemoryFeedTokenRepository()
+	svc := NewCalendarService(tokens, NewTaskService(repository.NewInMemoryTaskRepository()))
+
+	if _, err := svc.CreateFeedToken(models.CreateFeedTokenRequest{User: " "}); !errors.Is(err, ErrInvalidFeedUser) {
+		t.Errorf("expected ErrInvalidFeedUser, got %v", err)
+	}
+
+	created, err := svc.CreateFeedToken(models.CreateFeedTokenRequest{User: "alice"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if created.Token == "" {
+		t.Fatal("expected the token value on creation")
+	}
+
+	stored, _ := svc.GetFeedTokens()
+	if len(stored) != 1 || stored[0].Token != "" || stored[0].TokenHash == created.Token {
+		t.Errorf("expected only the hash to be stored, got %+v", stored)
+	}
+
+	token, err := svc.Authenticate(created.Token)
+	if err != nil || token.User != "alice" {
+		t.Errorf("expected alice's token, got %+v and %v", token, err)
+	}
+	if _, err := svc.Authenticate("wrong"); err != ErrInvalidFeedToken {
+		t.Errorf("expected ErrInvalidFeedToken, got %v", err)
+	}
+
+	if err := svc.RevokeFeedToken(created.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := svc.Authenticate(created.Token); err != ErrInvalidFeedToken {
+		t.Errorf("expected revoked token to be rejected, got %v", err)
+	}
+}
+
+func TestCalendarServiceGetCalendarTasks(t *testing.T) {
+	tasks := NewTaskService(repository.NewInMemoryTaskRepository())
+	svc := NewCalendarService(repository.NewInMemoryFeedTokenRepository(), tasks)
+
+	later := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+	sooner := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	_, _ = tasks.CreateTask(models.CreateTaskRequest{Title: "Later", DueDate: &later})
+	_, _ = tasks.CreateTask(models.CreateTaskRequest{Title: "No due date"})
+	_, _ = tasks.CreateTask(models.CreateTaskRequest{Title: "Sooner", DueDate: &sooner})
+
+	entries, err := svc.GetCalendarTasks()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(entries) != 2 || entries[0].Title != "Sooner" || entries[1].Title != "Later" {
+		t.Errorf("expected tasks with due dates ordered by due date, got %+v", entries)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var (
+	ErrChecklistItemNotFound  = errors.New("checklist item not found")
+	ErrInvalidChecklistText   = errors.New("checklist item text is required")
+	ErrInvalidChecklistOrder  = errors.New("item ids must list every checklist item exactly once")
+	ErrDuplicateChecklistItem = errors.New("checklist item listed more than once")
+)
+
+// WithAutoStart faz com que marcar o primeiro item do checklist de uma
+// tarefa em "todo" a mova automaticamente para "in_progress"
+func WithAutoStart(enabled bool) Option {
+	return func(s *TaskService) {
+		s.autoStart = enabled
+	}
+}
+
+// AddChecklistItem adiciona um item ao final do checklist da tarefa
+func (s *TaskService) AddChecklistItem(taskID string, req models.AddChecklistItemRequest) (*models.Task, error) {
+	var v validator
+	v.checklistItem("text", req.Text)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(task.Checklist) >= MaxChecklistItems {
+		return nil, fieldError("checklist", fmt.Errorf("%w: checklist accepts at most %d items", ErrTooManyItems, MaxChecklistItems))
+	}
+
+	task.Checklist = append(task.Checklist, models.ChecklistItem{
+		ID:    generateID(),
+		Text:  strings.TrimSpace(req.Text),
+		Order: len(task.Checklist),
+	})
+
+	if err := s.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// UpdateChecklistItem altera o texto e/ou marca/desmarca um item do checklist
+func (s *TaskService) UpdateChecklistItem(taskID, itemID string, req models.UpdateChecklistItemRequest) (*models.Task, error) {
+	task, err := s.repo.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := findChecklistItem(task, itemID)
+	if idx < 0 {
+		return nil, ErrChecklistItemNotFound
+	}
+
+	if req.Text != nil {
+		var v validator
+		v.checklistItem("text", *req.Text)
+		if err := v.err(); err != nil {
+			return nil, err
+		}
+		task.Checklist[idx].Text = strings.TrimSpace(*req.Text)
+	}
+	if req.Checked != nil {
+		// O primeiro item marcado indica que o trabalho começou
+		if *req.Checked && s.autoStart && task.Status == models.StatusTodo && task.Progress() == 0 {
+			s.applyStatus(task, models.StatusInProgress)
+		}
+		task.Checklist[idx].Checked = *req.Checked
+	}
+
+	if err := s.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// ReorderChecklist reordena o checklist conforme a lista completa de IDs
+func (s *TaskService) ReorderChecklist(taskID string, req models.ReorderChecklistRequest) (*models.Task, error) {
+	task, err := s.repo.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.ItemIDs) != len(task.Checklist) {
+		return nil, fieldError("itemIds", ErrInvalidChecklistOrder)
+	}
+
+	reordered := make([]models.ChecklistItem, 0, len(task.Checklist))
+	seen := make(map[string]bool, len(req.ItemIDs))
+	for i, id := range req.ItemIDs {
+		idx := findChecklistItem(task, id)
+		if idx < 0 || seen[id] {
+			return nil, fieldError("itemIds", ErrInvalidChecklistOrder)
+		}
+		seen[id] = true
+		item := task.Checklist[idx]
+		item.Order = i
+		reordered = append(reordered, item)
+	}
+	task.Checklist = reordered
+
+	if err := s.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// RemoveChecklistItem remove um item do checklist e renumera a ordem
+func (s *TaskService) RemoveChecklistItem(taskID, itemID string) (*models.Task, error) {
+	task, err := s.repo.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := findChecklistItem(task, itemID)
+	if idx < 0 {
+		return nil, ErrChecklistItemNotFound
+	}
+
+	task.Checklist = append(task.Checklist[:idx], task.Checklist[idx+1:]...)
+	for i := range task.Checklist {
+		task.Checklist[i].Order = i
+	}
+
+	if err := s.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// findChecklistItem retorna a posição do item no checklist ou -1
+func findChecklistItem(task *models.Task, itemID string) int {
+	for i, item := range task.Checklist {
+		if item.ID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// replaceChecklist monta o checklist informado na substituição da tarefa,
+// mantendo os IDs dos itens existentes e gerando IDs para os novos. Os erros
+// de cada item são acumulados em v.
+func replaceChecklist(v *validator, task *models.Task, items []models.ChecklistItemInput) []models.ChecklistItem {
+	if len(items) == 0 {
+		return nil
+	}
+	v.count("checklist", len(items), MaxChecklistItems)
+	checklist := make([]models.ChecklistItem, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		v.checklistItem(fmt.Sprintf("checklist[%d].text", i), item.Text)
+		id := item.ID
+		switch {
+		case id == "":
+			id = generateID()
+		case seen[id]:
+			v.add(fmt.Sprintf("checklist[%d].id", i), ErrDuplicateChecklistItem)
+		case findChecklistItem(task, id) < 0:
+			v.add(fmt.Sprintf("checklist[%d].id", i), ErrChecklistItemNotFound)
+		}
+		seen[id] = true
+		checklist = append(checklist, models.ChecklistItem{ID: id, Text: strings.TrimSpace(item.Text), Checked: item.Checked, Order: i})
+	}
+	return checklist
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestTaskServiceAddChecklistItem(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+
+	_, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "Step 1"})
+	updated, err := svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "Step 2"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if len(updated.Checklist) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(updated.Checklist))
+	}
+	if updated.Checklist[1].Text != "Step 2" || updated.Checklist[1].Order != 1 {
+		t.Errorf("unexpected second item %+v", updated.Checklist[1])
+	}
+}
+
+func TestTaskServiceAddChecklistItemEmptyText(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+
+	_, err := svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{})
+	if !errors.Is(err, ErrInvalidChecklistText) {
+		t.Errorf("expected ErrInvalidChecklistText, got %v", err)
+	}
+}
+
+func TestTaskServiceUpdateChecklistItemProgress(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "A"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "B"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "C"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "D"})
+
+	checked := true
+	updated, err := svc.UpdateChecklistItem(task.ID, task.Checklist[0].ID, models.UpdateChecklistItemRequest{Checked: &checked})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if updated.Progress() != 25 {
+		t.Errorf("expected progress 25, got %d", updated.Progress())
+	}
+	if updated.Status != models.StatusTodo {
+		t.Errorf("expected status to stay %s without auto start, got %s", models.StatusTodo, updated.Status)
+	}
+}
+
+func TestTaskServiceUpdateChecklistItemAutoStart(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithAutoStart(true))
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "A"})
+
+	checked := true
+	updated, err := svc.UpdateChecklistItem(task.ID, task.Checklist[0].ID, models.UpdateChecklistItemRequest{Checked: &checked})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if updated.Status != models.StatusInProgress {
+		t.Errorf("expected status %s, got %s", models.StatusInProgress, updated.Status)
+	}
+}
+
+func TestTaskServiceUpdateChecklistItemNotFound(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+
+	checked := true
+	_, err := svc.UpdateChecklistItem(task.ID, "missing", models.UpdateChecklistItemRequest{Checked: &checked})
+	if err != ErrChecklistItemNotFound {
+		t.Errorf("expected ErrChecklistItemNotFound, got %v", err)
+	}
+}
+
+func TestTaskServiceReorderChecklist(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "A"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "B"})
+	a, b := task.Checklist[0].ID, task.Checklist[1].ID
+
+	updated, err := svc.ReorderChecklist(task.ID, models.ReorderChecklistRequest{ItemIDs: []string{b, a}})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Checklist[0].ID != b || updated.Checklist[0].Order != 0 || updated.Checklist[1].Order != 1 {
+		t.Errorf("unexpected order %+v", updated.Checklist)
+	}
+
+	_, err = svc.ReorderChecklist(task.ID, models.ReorderChecklistRequest{ItemIDs: []string{a, a}})
+	if !errors.Is(err, ErrInvalidChecklistOrder) {
+		t.Errorf("expected ErrInvalidChecklistOrder, got %v", err)
+	}
+}
+
+func TestTaskServiceRemoveChecklistItem(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "A"})
+	task, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "B"})
+
+	updated, err := svc.RemoveChecklistItem(task.ID, task.Checklist[0].ID)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(updated.Checklist) != 1 || updated.Checklist[0].Text != "B" || updated.Checklist[0].Order != 0 {
+		t.Errorf("unexpected checklist %+v", updated.Checklist)
+	}
+}
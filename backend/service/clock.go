@@ -0,0 +1,15 @@
+package service
+
+import "time"
+
+// Clock abstrai a fonte de tempo para permitir testes com relógio falso
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// Now retorna o horário atual do sistema
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
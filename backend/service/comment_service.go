@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+// Limites dos comentários (em caracteres)
+const (
+	MaxCommentBodyLength   = 10000
+	MaxCommentAuthorLength = 100
+)
+
+var (
+	ErrInvalidCommentBody   = errors.New("comment body is required")
+	ErrInvalidCommentAuthor = errors.New("comment author is required and must have at most 100 characters")
+)
+
+// WithCommentRepository substitui o repositório de comentários das tarefas
+func WithCommentRepository(comments repository.CommentRepository) Option {
+	return func(s *TaskService) {
+		s.comments = comments
+	}
+}
+
+// GetComments retorna os comentários da tarefa em ordem cronológica
+func (s *TaskService) GetComments(taskID string) ([]*models.Comment, error) {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.comments.GetByTask(taskID)
+}
+
+// AddComment adiciona um comentário em markdown à tarefa
+func (s *TaskService) AddComment(taskID string, req models.CreateCommentRequest) (*models.Comment, error) {
+	author := strings.TrimSpace(req.Author)
+	if author == "" || utf8.RuneCountInString(author) > MaxCommentAuthorLength {
+		return nil, fieldError("author", ErrInvalidCommentAuthor)
+	}
+	if err := validateCommentBody(req.Body); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	comment := &models.Comment{
+		ID:        generateID(),
+		TaskID:    taskID,
+		Author:    author,
+		Body:      req.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.comments.Create(comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment edita o texto de um comentário da tarefa
+func (s *TaskService) UpdateComment(taskID, commentID string, req models.UpdateCommentRequest) (*models.Comment, error) {
+	if err := validateCommentBody(req.Body); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.findComment(taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Body = req.Body
+	comment.UpdatedAt = s.clock.Now()
+
+	if err := s.comments.Update(comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment remove um comentário da tarefa
+func (s *TaskService) DeleteComment(taskID, commentID string) error {
+	if _, err := s.findComment(taskID, commentID); err != nil {
+		return err
+	}
+	return s.comments.Delete(commentID)
+}
+
+// findComment busca o comentário garantindo que ele pertence à tarefa
+func (s *TaskService) findComment(taskID, commentID string) (*models.Comment, error) {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.comments.GetByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TaskID != taskID {
+		return nil, repository.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// validateCommentBody valida presença e tamanho do corpo do comentário com as
+// mesmas regras dos demais textos: acima do limite é ErrFieldTooLong
+func validateCommentBody(body string) error {
+	var v validator
+	v.text("body", body, MaxCommentBodyLength, ErrInvalidCommentBody)
+	return v.err()
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestTaskServiceAddComment(t *testing.T) {
+	clock := newFakeClock()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock))
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+
+	comment, err := svc.AddComment(task.ID, models.CreateCommentRequest{Author: " ana ", Body: "**LGTM**"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if comment.Author != "ana" || comment.TaskID != task.ID || !comment.CreatedAt.Equal(clock.Now()) {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+
+	comments, _ := svc.GetComments(task.ID)
+	if len(comments) != 1 {
+		t.Errorf("expected 1 comment, got %d", len(comments))
+	}
+}
+
+func TestTaskServiceAddCommentValidation(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+
+	if _, err := svc.AddComment(task.ID, models.CreateCommentRequest{Author: "ana", Body: "  "}); !errors.Is(err, ErrInvalidCommentBody) {
+		t.Errorf("expected ErrInvalidCommentBody, got %v", err)
+	}
+	if _, err := svc.AddComment(task.ID, models.CreateCommentRequest{Body: "hi"}); !errors.Is(err, ErrInvalidCommentAuthor) {
+		t.Errorf("expected ErrInvalidCommentAuthor, got %v", err)
+	}
+	long := strings.Repeat("a", MaxCommentBodyLength+1)
+	if _, err := svc.AddComment(task.ID, models.CreateCommentRequest{Author: "ana", Body: long}); !errors.Is(err, ErrFieldTooLong) {
+		t.Errorf("expected ErrFieldTooLong, got %v", err)
+	}
+	if _, err := svc.AddComment("missing", models.CreateCommentRequest{Author: "ana", Body: "hi"}); err != repository.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestTaskServiceUpdateComment(t *testing.T) {
+	clock := newFakeClock()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock))
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	other, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Other"})
+	comment, _ := svc.AddComment(task.ID, models.CreateCommentRequest{Author: "ana", Body: "first"})
+
+	clock.Advance(time.Minute)
+	updated, err := svc.UpdateComment(task.ID, comment.ID, models.UpdateCommentRequest{Body: "edited"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Body != "edited" || !updated.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("unexpected comment %+v", updated)
+	}
+
+	_, err = svc.UpdateComment(other.ID, comment.ID, models.UpdateCommentRequest{Body: "wrong task"})
+	if err != repository.ErrCommentNotFound {
+		t.Errorf("expected ErrCommentNotFound, got %v", err)
+	}
+}
+
+func TestTaskServiceDeleteTaskRemovesComments(t *testing.T) {
+	comments := repository.NewInMemoryCommentRepository()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithCommentRepository(comments))
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	comment, _ := svc.AddComment(task.ID, models.CreateCommentRequest{Author: "ana", Body: "hi"})
+
+	if err := svc.DeleteTask(task.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if _, err := comments.GetByID(comment.ID); err != repository.ErrCommentNotFound {
+		t.Errorf("expected comment to be cascade-deleted, got %v", err)
+	}
+}
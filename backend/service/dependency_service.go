@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+var (
+	ErrSelfDependency   = errors.New("a task cannot block itself")
+	ErrDependencyCycle  = errors.New("dependency would create a cycle")
+	ErrInvalidBlockerID = errors.New("blocker id is required")
+	ErrTaskBlocked      = errors.New("task has open blockers")
+)
+
+// WithDependencyRepository substitui o repositório de dependências entre tarefas
+func WithDependencyRepository(deps repository.DependencyRepository) Option {
+	return func(s *TaskService) {
+		s.deps = deps
+	}
+}
+
+// AddDependency registra que a tarefa taskID é bloqueada por blockerID
+func (s *TaskService) AddDependency(taskID string, req models.AddDependencyRequest) (*models.TaskDependencies, error) {
+	if req.BlockerID == "" {
+		return nil, fieldError("blockerId", ErrInvalidBlockerID)
+	}
+	if req.BlockerID == taskID {
+		return nil, fieldError("blockerId", ErrSelfDependency)
+	}
+
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(req.BlockerID); err != nil {
+		return nil, err
+	}
+
+	// Se taskID já bloqueia (direta ou indiretamente) o blocker, a nova aresta fecharia um ciclo
+	cycle, err := s.isBlockedBy(req.BlockerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if cycle {
+		return nil, ErrDependencyCycle
+	}
+
+	if err := s.deps.Add(taskID, req.BlockerID); err != nil {
+		return nil, err
+	}
+
+	return s.GetDependencies(taskID)
+}
+
+// RemoveDependency remove o bloqueio de blockerID sobre taskID
+func (s *TaskService) RemoveDependency(taskID, blockerID string) error {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return err
+	}
+	return s.deps.Remove(taskID, blockerID)
+}
+
+// GetDependencies retorna as tarefas que bloqueiam e as que são bloqueadas por taskID
+func (s *TaskService) GetDependencies(taskID string) (*models.TaskDependencies, error) {
+	if _, err := s.repo.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	blockerIDs, err := s.deps.GetBlockers(taskID)
+	if err != nil {
+		return nil, err
+	}
+	dependentIDs, err := s.deps.GetDependents(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedBy, err := s.tasksByID(blockerIDs)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := s.tasksByID(dependentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaskDependencies{
+		TaskID:    taskID,
+		BlockedBy: blockedBy,
+		Blocks:    blocks,
+	}, nil
+}
+
+// hasOpenBlockers indica se alguma tarefa que bloqueia taskID ainda não foi concluída
+func (s *TaskService) hasOpenBlockers(taskID string) (bool, error) {
+	blockerIDs, err := s.deps.GetBlockers(taskID)
+	if err != nil {
+		return false, err
+	}
+	blockers, err := s.tasksByID(blockerIDs)
+	if err != nil {
+		return false, err
+	}
+	for _, blocker := range blockers {
+		if !blocker.Completed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBlockedBy percorre o grafo a partir de taskID e indica se target
+// aparece entre seus bloqueadores diretos ou transitivos
+func (s *TaskService) isBlockedBy(taskID, target string) (bool, error) {
+	visited := map[string]bool{taskID: true}
+	stack := []string{taskID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		blockerIDs, err := s.deps.GetBlockers(current)
+		if err != nil {
+			return false, err
+		}
+		for _, id := range blockerIDs {
+			if id == target {
+				return true, nil
+			}
+			if !visited[id] {
+				visited[id] = true
+				stack = append(stack, id)
+			}
+		}
+	}
+	return false, nil
+}
+
+// tasksByID carrega as tarefas pelos IDs, ignorando as que não existem mais
+func (s *TaskService) tasksByID(ids []string) ([]*models.Task, error) {
+	tasks := make([]*models.Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := s.repo.GetByID(id)
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestTaskServiceAddDependency(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	a, _ := svc.CreateTask(models.CreateTaskRequest{Title: "A"})
+	b, _ := svc.CreateTask(models.CreateTaskRequest{Title: "B"})
+
+	deps, err := svc.AddDependency(b.ID, models.AddDependencyRequest{BlockerID: a.ID})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(deps.BlockedBy) != 1 || deps.BlockedBy[0].ID != a.ID {
+		t.Errorf("expected %s to be blocked by %s, got %+v", b.ID, a.ID, deps.BlockedBy)
+	}
+
+	blockerDeps, _ := svc.GetDependencies(a.ID)
+	if len(blockerDeps.Blocks) != 1 || blockerDeps.Blocks[0].ID != b.ID {
+		t.Errorf("expected %s to block %s, got %+v", a.ID, b.ID, blockerDeps.Blocks)
+	}
+}
+
+func TestTaskServiceAddDependencySelf(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	a, _ := svc.CreateTask(models.CreateTaskRequest{Title: "A"})
+
+	_, err := svc.AddDependency(a.ID, models.AddDependencyRequest{BlockerID: a.ID})
+	if !errors.Is(err, ErrSelfDependency) {
+		t.Errorf("expected ErrSelfDependency, got %v", err)
+	}
+}
+
+func TestTaskServiceAddDependencyCycle(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	a, _ := svc.CreateTask(models.CreateTaskRequest{Title: "A"})
+	b, _ := svc.CreateTask(models.CreateTaskRequest{Title: "B"})
+	c, _ := svc.CreateTask(models.CreateTaskRequest{Title: "C"})
+
+	_, _ = svc.AddDependency(b.ID, models.AddDependencyRequest{BlockerID: a.ID})
+	_, _ = svc.AddDependency(c.ID, models.AddDependencyRequest{BlockerID: b.ID})
+
+	_, err := svc.AddDependency(a.ID, models.AddDependencyRequest{BlockerID: c.ID})
+	if err != ErrDependencyCycle {
+		t.Errorf("expected ErrDependencyCycle, got %v", err)
+	}
+}
+
+func TestTaskServiceAddDependencyBlockerNotFound(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	a, _ := svc.CreateTask(models.CreateTaskRequest{Title: "A"})
+
+	_, err := svc.AddDependency(a.ID, models.AddDependencyRequest{BlockerID: "missing"})
+	if err != repository.ErrTaskNotFound {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestTaskServiceUpdateTaskBlocked(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	a, _ := svc.CreateTask(models.CreateTaskRequest{Title: "A"})
+	b, _ := svc.CreateTask(models.CreateTaskRequest{Title: "B"})
+	_, _ = svc.AddDependency(b.ID, models.AddDependencyRequest{BlockerID: a.ID})
+
+	done := models.StatusDone
+	_, err := svc.UpdateTask(b.ID, models.UpdateTaskRequest{Status: &done})
+	if err != ErrTaskBlocked {
+		t.Fatalf("expected ErrTaskBlocked, got %v", err)
+	}
+
+	_, _ = svc.UpdateTask(a.ID, models.UpdateTaskRequest{Status: &done})
+	updated, err := svc.UpdateTask(b.ID, models.UpdateTaskRequest{Status: &done})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Status != models.StatusDone {
+		t.Errorf("expected status %s, got %s", models.StatusDone, updated.Status)
+	}
+}
+
+func TestTaskServiceDeleteTaskRemovesDependencies(t *testing.T) {
+	deps := repository.NewInMemoryDependencyRepository()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithDependencyRepository(deps))
+	a, _ := svc.CreateTask(models.CreateTaskRequest{Title: "A"})
+	b, _ := svc.CreateTask(models.CreateTaskRequest{Title: "B"})
+	_, _ = svc.AddDependency(b.ID, models.AddDependencyRequest{BlockerID: a.ID})
+
+	if err := svc.DeleteTask(a.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if blockers, _ := deps.GetBlockers(b.ID); len(blockers) != 0 {
+		t.Errorf("expected edges to be removed, got blockers %v", blockers)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/acauhi/kanban-backend/calendar"
+	"github.com/acauhi/kanban-backend/exchange"
+	"github.com/acauhi/kanban-backend/jsonpatch"
+	"github.com/acauhi/kanban-backend/report"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/storage"
+)
+
+// ErrorKind é a classe de um erro do domínio; cada transporte (REST,
+// GraphQL, gRPC) traduz a classe para o seu próprio status
+type ErrorKind int
+
+const (
+	// KindInternal é o erro inesperado, fora da tabela
+	KindInternal ErrorKind = iota
+	// KindInvalid é a requisição com dados inválidos
+	KindInvalid
+	// KindNotFound é o recurso inexistente
+	KindNotFound
+	// KindConflict é a operação impedida pelo estado atual
+	KindConflict
+	// KindTooLarge é o conteúdo acima do tamanho aceito
+	KindTooLarge
+	// KindUnprocessable é o pedido bem formado cujo resultado é inválido
+	KindUnprocessable
+	// KindUnauthorized é a credencial inválida
+	KindUnauthorized
+)
+
+// errorClass associa um erro à classe e ao código estável exposto aos
+// clientes; erros envolvidos com %w são reconhecidos por errors.Is
+type errorClass struct {
+	err  error
+	kind ErrorKind
+	code string
+}
+
+// errorClasses é a tabela única dos erros do domínio. A ordem importa apenas
+// quando um erro envolve outro.
+var errorClasses = []errorClass{
+	{repository.ErrTaskNotFound, KindNotFound, "task_not_found"},
+	{repository.ErrLabelNotFound, KindNotFound, "label_not_found"},
+	{repository.ErrCommentNotFound, KindNotFound, "comment_not_found"},
+	{repository.ErrAttachmentNotFound, KindNotFound, "attachment_not_found"},
+	{storage.ErrBlobNotFound, KindNotFound, "attachment_not_found"},
+	{repository.ErrTaskTemplateNotFound, KindNotFound, "task_template_not_found"},
+	{repository.ErrRecurringTaskNotFound, KindNotFound, "recurring_task_not_found"},
+	{repository.ErrFeedTokenNotFound, KindNotFound, "feed_token_not_found"},
+	{ErrChecklistItemNotFound, KindNotFound, "checklist_item_not_found"},
+
+	{ErrInvalidTitle, KindInvalid, "invalid_title"},
+	{ErrInvalidTaskID, KindInvalid, "invalid_task_id"},
+	{ErrInvalidUTF8, KindInvalid, "invalid_utf8"},
+	{ErrFieldTooLong, KindInvalid, "field_too_long"},
+	{ErrTooManyItems, KindInvalid, "too_many_items"},
+	{ErrInvalidStatus, KindInvalid, "invalid_status"},
+	{ErrInvalidPriority, KindInvalid, "invalid_priority"},
+	{ErrInvalidSort, KindInvalid, "invalid_sort"},
+	{ErrInvalidChecklistText, KindInvalid, "invalid_checklist_text"},
+	{ErrInvalidChecklistOrder, KindInvalid, "invalid_checklist_order"},
+	{ErrDuplicateChecklistItem, KindInvalid, "duplicate_checklist_item"},
+	{ErrMissingTemplateVariable, KindInvalid, "missing_template_variable"},
+	{ErrInvalidTemplateName, KindInvalid, "invalid_template_name"},
+	{ErrInvalidSchedule, KindInvalid, "invalid_schedule"},
+	{ErrInvalidTimezone, KindInvalid, "invalid_timezone"},
+	{ErrInvalidBlockerID, KindInvalid, "invalid_blocker_id"},
+	{ErrSelfDependency, KindInvalid, "self_dependency"},
+	{ErrInvalidCommentBody, KindInvalid, "invalid_comment_body"},
+	{ErrInvalidCommentAuthor, KindInvalid, "invalid_comment_author"},
+	{ErrInvalidFileName, KindInvalid, "invalid_file_name"},
+	{ErrInvalidLabelName, KindInvalid, "invalid_label_name"},
+	{ErrInvalidLabelColor, KindInvalid, "invalid_label_color"},
+	{ErrInvalidFeedUser, KindInvalid, "invalid_feed_user"},
+	{exchange.ErrUnsupportedFormat, KindInvalid, "unsupported_format"},
+	{report.ErrUnsupportedFormat, KindInvalid, "unsupported_format"},
+	{calendar.ErrUnsupportedComponent, KindInvalid, "unsupported_component"},
+	{jsonpatch.ErrInvalidPatch, KindInvalid, "invalid_patch"},
+
+	{ErrTaskBlocked, KindConflict, "task_blocked"},
+	{ErrDependencyCycle, KindConflict, "dependency_cycle"},
+	{ErrDuplicateLabel, KindConflict, "duplicate_label"},
+	{ErrDuplicateTemplate, KindConflict, "duplicate_template"},
+	{jsonpatch.ErrTestFailed, KindConflict, "patch_test_failed"},
+
+	{ErrAttachmentTooLarge, KindTooLarge, "attachment_too_large"},
+	{jsonpatch.ErrTooManyOperations, KindTooLarge, "too_many_patch_operations"},
+	{jsonpatch.ErrDocumentTooLarge, KindTooLarge, "patched_document_too_large"},
+
+	{jsonpatch.ErrPathNotFound, KindUnprocessable, "patch_path_not_found"},
+	{ErrInvalidPatchedTask, KindUnprocessable, "invalid_patched_task"},
+
+	{ErrInvalidFeedToken, KindUnauthorized, "invalid_feed_token"},
+}
+
+// ClassifyError retorna a classe e o código estável do erro. Erros fora da
+// tabela retornam KindInternal e código vazio.
+func ClassifyError(err error) (ErrorKind, string) {
+	for _, class := range errorClasses {
+		if errors.Is(err, class.err) {
+			return class.kind, class.code
+		}
+	}
+	return KindInternal, ""
+}
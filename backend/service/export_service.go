@@ -0,0 +1,32 @@
+package service
+
+import "github.com/acauhi/kanban-backend/models"
+
+// ExportTasks retorna todas as tarefas, inclusive arquivadas, com as
+// etiquetas pelo nome e cor. Os IDs das etiquetas só valem neste quadro; pelo
+// nome, a importação em outro ambiente as associa ou cria.
+func (s *TaskService) ExportTasks() ([]models.ExportTask, error) {
+	tasks, err := s.GetAllTasksIncludingArchived()
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.labels.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Label, len(labels))
+	for _, label := range labels {
+		byID[label.ID] = label
+	}
+
+	exported := make([]models.ExportTask, len(tasks))
+	for i, task := range tasks {
+		exported[i].Task = task
+		for _, id := range task.Labels {
+			if label, ok := byID[id]; ok {
+				exported[i].Labels = append(exported[i].Labels, models.CreateLabelRequest{Name: label.Name, Color: label.Color})
+			}
+		}
+	}
+	return exported, nil
+}
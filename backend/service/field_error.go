@@ -0,0 +1,24 @@
+package service
+
+// FieldError associa um erro de validação ao campo da requisição que o
+// causou, ex: "title" ou "checklist[2].text". errors.Is continua
+// reconhecendo o erro original.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error retorna a mensagem do erro original
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap retorna o erro original
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError cria um FieldError para o campo informado
+func fieldError(field string, err error) error {
+	return &FieldError{Field: field, Err: err}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+// defaultImportLabelColor é usada nas etiquetas importadas sem cor válida
+const defaultImportLabelColor = "#8b949e"
+
+// ImportTasks cria as tarefas das linhas importadas via CreateTask, registrando
+// o resultado de cada linha. Linhas inválidas não interrompem a importação.
+// Em modo dry-run as linhas são validadas contra uma cópia descartável do
+// repositório, sem alterar o quadro.
+func (s *TaskService) ImportTasks(rows []models.ImportTaskRow, opts models.ImportOptions) (*models.ImportReport, error) {
+	target := s
+	if opts.DryRun {
+		var err error
+		if target, err = s.dryRunCopy(); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &models.ImportReport{
+		DryRun: opts.DryRun,
+		Total:  len(rows),
+		Rows:   make([]models.ImportRowResult, 0, len(rows)),
+	}
+	for _, row := range rows {
+		result := models.ImportRowResult{Row: row.Row, Title: row.Title}
+
+		task, err := target.importRow(s, row, opts)
+		if err != nil {
+			result.Error = err.Error()
+			var fieldErr *FieldError
+			if errors.As(err, &fieldErr) {
+				result.Field = fieldErr.Field
+			}
+			report.Failed++
+		} else {
+			// No dry-run o ID gerado é descartável; só o ID preservado é informativo
+			if !opts.DryRun || (opts.PreserveIDs && row.ID != "") {
+				result.ID = task.ID
+			}
+			report.Created++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, nil
+}
+
+// importRow cria a tarefa da linha e aplica o status e o arquivamento
+// originais. origin é o serviço real, usado para detectar IDs já existentes
+// no dry-run.
+func (s *TaskService) importRow(origin *TaskService, row models.ImportTaskRow, opts models.ImportOptions) (*models.Task, error) {
+	if row.Status != "" && !isValidStatus(row.Status) {
+		return nil, ErrInvalidStatus
+	}
+	// completed é derivado do status: sem status, completed=true significa
+	// "done"; com os dois, precisam concordar
+	if row.Completed != nil {
+		switch {
+		case row.Status == "" && *row.Completed:
+			row.Status = models.StatusDone
+		case row.Status != "" && (row.Status == models.StatusDone) != *row.Completed:
+			return nil, fmt.Errorf("%w: completed must match status %q", ErrInvalidStatus, row.Status)
+		}
+	}
+	archived := row.Archived != nil && *row.Archived
+	if archived && row.Status != models.StatusDone {
+		return nil, fmt.Errorf("%w: only done tasks can be archived", ErrInvalidStatus)
+	}
+
+	req := models.CreateTaskRequest{
+		Title:       row.Title,
+		Description: row.Description,
+		Priority:    row.Priority,
+		DueDate:     row.DueDate,
+		Labels:      row.Labels,
+	}
+	for _, item := range row.Checklist {
+		req.Checklist = append(req.Checklist, item.Text)
+	}
+	if len(row.LabelsByName) > 0 {
+		ids, err := s.labelsByName(row.LabelsByName)
+		if err != nil {
+			return nil, err
+		}
+		req.Labels = append(req.Labels, ids...)
+	}
+	if opts.PreserveIDs && row.ID != "" {
+		// O ID preservado vai para URLs, IDs do GraphQL e do gRPC e chaves de
+		// anexos; só é aceito no mesmo formato dos IDs gerados
+		if !taskIDPattern.MatchString(row.ID) {
+			return nil, fieldError("id", ErrInvalidTaskID)
+		}
+		if origin != s {
+			if _, err := origin.repo.GetByID(row.ID); err == nil {
+				return nil, ErrDuplicateTaskID
+			} else if !errors.Is(err, repository.ErrTaskNotFound) {
+				return nil, err
+			}
+		}
+		req.ID = row.ID
+	}
+
+	task, err := s.CreateTask(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Os itens já marcados são gravados direto, sem passar pelo início automático
+	checked := false
+	for i, item := range row.Checklist {
+		if item.Checked {
+			task.Checklist[i].Checked = true
+			checked = true
+		}
+	}
+	if checked {
+		if err := s.saveTask(task); err != nil {
+			_ = s.DeleteTask(task.ID)
+			return nil, err
+		}
+	}
+
+	if row.Status != "" && row.Status != task.Status {
+		updated, err := s.UpdateTask(task.ID, models.UpdateTaskRequest{Status: &row.Status})
+		if err != nil {
+			// Desfaz a criação para que a linha não fique pela metade
+			_ = s.DeleteTask(task.ID)
+			return nil, fmt.Errorf("set status: %w", err)
+		}
+		task = updated
+	}
+
+	if archived {
+		now := s.clock.Now()
+		task.Archived = true
+		task.ArchivedAt = &now
+		if err := s.saveTask(task); err != nil {
+			_ = s.DeleteTask(task.ID)
+			return nil, fmt.Errorf("archive: %w", err)
+		}
+	}
+
+	return task, nil
+}
+
+// labelsByName retorna os IDs das etiquetas com os nomes informados (sem
+// diferenciar maiúsculas), criando as que não existem. Cores inválidas são
+// trocadas por defaultImportLabelColor.
+func (s *TaskService) labelsByName(reqs []models.CreateLabelRequest) ([]string, error) {
+	existing, err := s.labels.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			continue
+		}
+
+		idx := slices.IndexFunc(existing, func(label *models.Label) bool {
+			return strings.EqualFold(label.Name, name)
+		})
+		if idx >= 0 {
+			ids = append(ids, existing[idx].ID)
+			continue
+		}
+
+		color := strings.ToLower(req.Color)
+		if !labelColorPattern.MatchString(color) {
+			color = defaultImportLabelColor
+		}
+		label := &models.Label{ID: generateID(), Name: name, Color: color}
+		if err := s.labels.Create(label); err != nil {
+			return nil, err
+		}
+		existing = append(existing, label)
+		ids = append(ids, label.ID)
+	}
+	return ids, nil
+}
+
+// dryRunCopy cria um serviço com repositório de tarefas vazio e uma cópia das
+// etiquetas, compartilhando o relógio do serviço real, para validar sem persistir
+func (s *TaskService) dryRunCopy() (*TaskService, error) {
+	labels, err := s.labels.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	labelCopy := repository.NewInMemoryLabelRepository()
+	for _, label := range labels {
+		clone := *label
+		if err := labelCopy.Create(&clone); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewTaskService(repository.NewInMemoryTaskRepository(),
+		WithClock(s.clock),
+		WithLabelRepository(labelCopy),
+	), nil
+}
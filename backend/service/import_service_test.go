@@ -0,0 +1,225 @@
+package service
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/exchange"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestTaskServiceImportTasks(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	rows := []models.ImportTaskRow{
+		{Row: 1, Title: "Imported", Status: models.StatusDone},
+		{Row: 2, Title: ""},
+		{Row: 3, Title: "Bad status", Status: "blocked"},
+	}
+
+	report, err := svc.ImportTasks(rows, models.ImportOptions{})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if report.Total != 3 || report.Created != 1 || report.Failed != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Rows[1].Error != ErrInvalidTitle.Error() || report.Rows[2].Error != ErrInvalidStatus.Error() {
+		t.Errorf("unexpected row errors %+v", report.Rows)
+	}
+
+	tasks, _ := svc.GetAllTasks()
+	if len(tasks) != 1 || tasks[0].Status != models.StatusDone || !tasks[0].Completed {
+		t.Errorf("expected one completed task, got %+v", tasks)
+	}
+}
+
+func TestTaskServiceImportTasksCompletedAndArchived(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	yes, no := true, false
+	rows := []models.ImportTaskRow{
+		{Row: 1, Title: "Archived", Completed: &yes, Archived: &yes},
+		{Row: 2, Title: "Open", Status: models.StatusTodo, Completed: &no, Archived: &no},
+		{Row: 3, Title: "Contradiction", Status: models.StatusTodo, Completed: &yes},
+		{Row: 4, Title: "Archived but open", Status: models.StatusInProgress, Archived: &yes},
+	}
+
+	report, err := svc.ImportTasks(rows, models.ImportOptions{})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if report.Created != 2 || report.Failed != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	archived, _ := svc.GetTaskByID(report.Rows[0].ID)
+	if archived.Status != models.StatusDone || !archived.Completed || !archived.Archived || archived.ArchivedAt == nil {
+		t.Errorf("expected a done and archived task, got %+v", archived)
+	}
+	open, _ := svc.GetTaskByID(report.Rows[1].ID)
+	if open.Completed || open.Archived {
+		t.Errorf("expected an open task, got %+v", open)
+	}
+}
+
+func TestTaskServiceImportTasksDryRun(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	existing, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Existing"})
+	rows := []models.ImportTaskRow{
+		{Row: 1, ID: "new-id", Title: "New"},
+		{Row: 2, ID: existing.ID, Title: "Clash"},
+		{Row: 3, ID: "new-id", Title: "Repeated in file"},
+	}
+
+	report, _ := svc.ImportTasks(rows, models.ImportOptions{DryRun: true, PreserveIDs: true})
+	if report.Created != 1 || report.Failed != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Rows[0].ID != "new-id" {
+		t.Errorf("expected preserved id new-id, got %q", report.Rows[0].ID)
+	}
+
+	tasks, _ := svc.GetAllTasks()
+	if len(tasks) != 1 {
+		t.Errorf("expected dry run to leave the board untouched, got %d tasks", len(tasks))
+	}
+}
+
+func TestTaskServiceImportTasksPreserveIDs(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+
+	_, _ = svc.ImportTasks([]models.ImportTaskRow{{Row: 1, ID: "legacy-7", Title: "Legacy"}}, models.ImportOptions{PreserveIDs: true})
+
+	task, err := svc.GetTaskByID("legacy-7")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if task.Title != "Legacy" {
+		t.Errorf("expected title Legacy, got %s", task.Title)
+	}
+}
+
+func TestTaskServiceImportTasksValidatesPreservedIDs(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	rows := []models.ImportTaskRow{
+		{Row: 1, ID: "legacy_7-A", Title: "Valid"},
+		{Row: 2, ID: "../etc", Title: "Traversal"},
+		{Row: 3, ID: "a/b", Title: "Slash"},
+		{Row: 4, ID: "bad\nid", Title: "Control"},
+		{Row: 5, ID: strings.Repeat("a", 65), Title: "Too long"},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		report, err := svc.ImportTasks(rows, models.ImportOptions{DryRun: dryRun, PreserveIDs: true})
+		if err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+		if report.Created != 1 || report.Failed != 4 {
+			t.Fatalf("expected only the valid ID to be imported, got %+v", report)
+		}
+		for _, row := range report.Rows[1:] {
+			if row.Field != "id" || row.Error != ErrInvalidTaskID.Error() {
+				t.Errorf("expected an id field error on row %d, got %+v", row.Row, row)
+			}
+		}
+	}
+	if _, err := svc.GetTaskByID("legacy_7-A"); err != nil {
+		t.Errorf(msgExpectedNoError, err)
+	}
+}
+
+func TestTaskServiceImportTasksLabelsByNameAndChecklist(t *testing.T) {
+	labels := repository.NewInMemoryLabelRepository()
+	_ = labels.Create(&models.Label{ID: "bug-id", Name: "Bug", Color: "#d73a4a"})
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithLabelRepository(labels))
+	rows := []models.ImportTaskRow{{
+		Row:   1,
+		Title: "From Trello",
+		LabelsByName: []models.CreateLabelRequest{
+			{Name: "bug", Color: "#000000"},
+			{Name: "Feature", Color: "not-a-color"},
+		},
+		Checklist: []models.ChecklistItem{{Text: "done item", Checked: true}, {Text: "open item"}},
+	}}
+
+	dryRun, _ := svc.ImportTasks(rows, models.ImportOptions{DryRun: true})
+	if dryRun.Created != 1 {
+		t.Fatalf("expected dry run to succeed, got %+v", dryRun)
+	}
+	if all, _ := labels.GetAll(); len(all) != 1 {
+		t.Errorf("expected dry run not to create labels, got %d", len(all))
+	}
+
+	report, _ := svc.ImportTasks(rows, models.ImportOptions{})
+	task, err := svc.GetTaskByID(report.Rows[0].ID)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	all, _ := labels.GetAll()
+	if len(all) != 2 || len(task.Labels) != 2 || task.Labels[0] != "bug-id" {
+		t.Errorf("expected existing label reused and one created, got labels %+v on task %v", all, task.Labels)
+	}
+	for _, label := range all {
+		if label.Name == "Feature" && label.Color != defaultImportLabelColor {
+			t.Errorf("expected default color, got %s", label.Color)
+		}
+	}
+	if len(task.Checklist) != 2 || !task.Checklist[0].Checked || task.Checklist[1].Checked {
+		t.Errorf("unexpected checklist %+v", task.Checklist)
+	}
+	if task.Status != models.StatusTodo {
+		t.Errorf("expected checked items not to change the status, got %s", task.Status)
+	}
+}
+
+func TestTaskServiceExportImportIntoNewBoard(t *testing.T) {
+	labels := repository.NewInMemoryLabelRepository()
+	tasks := repository.NewInMemoryTaskRepository()
+	_ = labels.Create(&models.Label{ID: "bug-id", Name: "Bug", Color: "#d73a4a"})
+	_ = labels.Create(&models.Label{ID: "ui-id", Name: "UI", Color: "#0e8a16"})
+	source := NewTaskService(tasks, WithLabelRepository(labels))
+	if _, err := source.CreateTask(models.CreateTaskRequest{Title: "Labeled", Labels: []string{"bug-id", "ui-id"}}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	exported, err := source.ExportTasks()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	for _, format := range []string{exchange.FormatCSV, exchange.FormatJSON} {
+		var buf bytes.Buffer
+		if err := exchange.Export(&buf, format, exported); err != nil {
+			t.Fatalf("%s: "+msgExpectedNoError, format, err)
+		}
+		rows, failures, err := exchange.Import(&buf, format)
+		if err != nil || len(failures) != 0 {
+			t.Fatalf("%s: expected no failures, got %v and %v", format, failures, err)
+		}
+
+		targetLabels := repository.NewInMemoryLabelRepository()
+		target := NewTaskService(repository.NewInMemoryTaskRepository(), WithLabelRepository(targetLabels))
+		report, err := target.ImportTasks(rows, models.ImportOptions{})
+		if err != nil {
+			t.Fatalf("%s: "+msgExpectedNoError, format, err)
+		}
+		if report.Created != 1 {
+			t.Fatalf("%s: expected the labeled row to be imported, got %+v", format, report)
+		}
+
+		task, _ := target.GetTaskByID(report.Rows[0].ID)
+		var got []string
+		for _, id := range task.Labels {
+			label, err := targetLabels.GetByID(id)
+			if err != nil {
+				t.Fatalf("%s: "+msgExpectedNoError, format, err)
+			}
+			got = append(got, label.Name+" "+label.Color)
+		}
+		if !slices.Equal(got, []string{"Bug #d73a4a", "UI #0e8a16"}) {
+			t.Errorf("%s: expected the labels recreated by name and color, got %v", format, got)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"cmp"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+var (
+	ErrInvalidLabelName  = errors.New("label name is required")
+	ErrInvalidLabelColor = errors.New("label color must be a hex value like #1f6feb")
+	ErrDuplicateLabel    = errors.New("a label with this name already exists")
+)
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type LabelService struct {
+	labels    repository.LabelRepository
+	tasks     *TaskService
+	recurring repository.RecurringTaskRepository
+	templates repository.TaskTemplateRepository
+}
+
+type LabelOption func(*LabelService)
+
+// WithRecurringTasks faz a remoção de uma etiqueta desvinculá-la também das
+// tarefas recorrentes, que de outro modo falhariam a cada ocorrência
+func WithRecurringTasks(recurring repository.RecurringTaskRepository) LabelOption {
+	return func(s *LabelService) {
+		s.recurring = recurring
+	}
+}
+
+// WithTaskTemplates faz a remoção de uma etiqueta desvinculá-la também dos
+// modelos de tarefa
+func WithTaskTemplates(templates repository.TaskTemplateRepository) LabelOption {
+	return func(s *LabelService) {
+		s.templates = templates
+	}
+}
+
+// NewLabelService cria uma nova instância do serviço de etiquetas; as tarefas
+// são desvinculadas de etiquetas removidas pelo TaskService, que publica os
+// eventos de alteração
+func NewLabelService(labels repository.LabelRepository, tasks *TaskService, opts ...LabelOption) *LabelService {
+	s := &LabelService{
+		labels: labels,
+		tasks:  tasks,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithLabelRepository define o repositório usado para validar as etiquetas das tarefas
+func WithLabelRepository(labels repository.LabelRepository) Option {
+	return func(s *TaskService) {
+		s.labels = labels
+	}
+}
+
+// CreateLabel cria uma nova etiqueta com nome único e cor em hexadecimal
+func (s *LabelService) CreateLabel(req models.CreateLabelRequest) (*models.Label, error) {
+	name := strings.TrimSpace(req.Name)
+	if err := validateLabel(name, req.Color); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueName(name, ""); err != nil {
+		return nil, err
+	}
+
+	label := &models.Label{
+		ID:    generateID(),
+		Name:  name,
+		Color: strings.ToLower(req.Color),
+	}
+
+	if err := s.labels.Create(label); err != nil {
+		return nil, err
+	}
+
+	return label, nil
+}
+
+// GetAllLabels retorna todas as etiquetas do quadro, ordenadas pelo nome
+func (s *LabelService) GetAllLabels() ([]*models.Label, error) {
+	labels, err := s.labels.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(labels, func(a, b *models.Label) int {
+		return cmp.Or(strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), strings.Compare(a.ID, b.ID))
+	})
+	return labels, nil
+}
+
+// GetLabelByID busca uma etiqueta específica pelo ID
+func (s *LabelService) GetLabelByID(id string) (*models.Label, error) {
+	return s.labels.GetByID(id)
+}
+
+// UpdateLabel atualiza nome e/ou cor de uma etiqueta existente
+func (s *LabelService) UpdateLabel(id string, req models.UpdateLabelRequest) (*models.Label, error) {
+	label, err := s.labels.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, color := label.Name, label.Color
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	if req.Color != nil {
+		color = *req.Color
+	}
+	if err := validateLabel(name, color); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueName(name, id); err != nil {
+		return nil, err
+	}
+
+	return updateCopy(label, func(label *models.Label) error {
+		label.Name = name
+		label.Color = strings.ToLower(color)
+		return nil
+	}, s.labels.Update)
+}
+
+// DeleteLabel remove a etiqueta e a desvincula de todas as tarefas, das
+// tarefas recorrentes e dos modelos
+func (s *LabelService) DeleteLabel(id string) error {
+	if err := s.labels.Delete(id); err != nil {
+		return err
+	}
+	if err := s.tasks.unlinkLabel(id); err != nil {
+		return err
+	}
+
+	// Os modelos guardados são compartilhados com as requisições em
+	// andamento; a etiqueta é retirada de uma cópia
+	without := func(labels []string) []string {
+		return slices.DeleteFunc(slices.Clone(labels), func(labelID string) bool { return labelID == id })
+	}
+
+	if s.recurring != nil {
+		recurring, err := s.recurring.GetAll()
+		if err != nil {
+			return err
+		}
+		for _, task := range recurring {
+			if !slices.Contains(task.Labels, id) {
+				continue
+			}
+			if _, err := updateCopy(task, func(task *models.RecurringTask) error {
+				task.Labels = without(task.Labels)
+				return nil
+			}, s.recurring.Update); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.templates != nil {
+		templates, err := s.templates.GetAll()
+		if err != nil {
+			return err
+		}
+		for _, template := range templates {
+			if !slices.Contains(template.Labels, id) {
+				continue
+			}
+			if _, err := updateCopy(template, func(template *models.TaskTemplate) error {
+				template.Labels = without(template.Labels)
+				return nil
+			}, s.templates.Update); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureUniqueName garante que nenhuma outra etiqueta use o mesmo nome
+func (s *LabelService) ensureUniqueName(name, exceptID string) error {
+	labels, err := s.labels.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if label.ID != exceptID && strings.EqualFold(label.Name, name) {
+			return fieldError("name", ErrDuplicateLabel)
+		}
+	}
+	return nil
+}
+
+// validateLabel valida os campos obrigatórios de uma etiqueta
+func validateLabel(name, color string) error {
+	if strings.TrimSpace(name) == "" {
+		return fieldError("name", ErrInvalidLabelName)
+	}
+	if !labelColorPattern.MatchString(color) {
+		return fieldError("color", ErrInvalidLabelColor)
+	}
+	return nil
+}
+
+// GetTasksByLabel retorna as tarefas ativas que possuem a etiqueta informada
+func (s *TaskService) GetTasksByLabel(labelID string) ([]*models.Task, error) {
+	tasks, err := s.repo.GetByLabel(labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.Archived {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered, nil
+}
+
+// unlinkLabel retira a etiqueta removida das tarefas, inclusive arquivadas.
+// Cada tarefa é alterada numa cópia e gravada por saveTask, que publica o
+// evento de alteração.
+func (s *TaskService) unlinkLabel(labelID string) error {
+	tasks, err := s.repo.GetByLabel(labelID)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		updated := cloneTask(task)
+		updated.Labels = slices.DeleteFunc(updated.Labels, func(id string) bool { return id == labelID })
+		if err := s.saveTask(updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLabels valida que todas as etiquetas existem e remove duplicadas
+func (s *TaskService) resolveLabels(ids []string) ([]string, error) {
+	resolved := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if slices.Contains(resolved, id) {
+			continue
+		}
+		if _, err := s.labels.GetByID(id); errors.Is(err, repository.ErrLabelNotFound) {
+			return nil, fieldError("labels", err)
+		} else if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, id)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func newLabelTestServices() (*TaskService, *LabelService) {
+	tasks := repository.NewInMemoryTaskRepository()
+	labels := repository.NewInMemoryLabelRepository()
+	svc := NewTaskService(tasks, WithLabelRepository(labels))
+	return svc, NewLabelService(labels, svc)
+}
+
+func TestLabelServiceCreateLabel(t *testing.T) {
+	_, labels := newLabelTestServices()
+
+	label, err := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#D73A4A"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if label.Color != "#d73a4a" {
+		t.Errorf("expected normalized color #d73a4a, got %s", label.Color)
+	}
+}
+
+func TestLabelServiceCreateLabelValidation(t *testing.T) {
+	_, labels := newLabelTestServices()
+
+	if _, err := labels.CreateLabel(models.CreateLabelRequest{Name: " ", Color: "#ffffff"}); !errors.Is(err, ErrInvalidLabelName) {
+		t.Errorf("expected ErrInvalidLabelName, got %v", err)
+	}
+	if _, err := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "red"}); !errors.Is(err, ErrInvalidLabelColor) {
+		t.Errorf("expected ErrInvalidLabelColor, got %v", err)
+	}
+
+	_, _ = labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#ffffff"})
+	if _, err := labels.CreateLabel(models.CreateLabelRequest{Name: "BUG", Color: "#000000"}); !errors.Is(err, ErrDuplicateLabel) {
+		t.Errorf("expected ErrDuplicateLabel, got %v", err)
+	}
+}
+
+func TestLabelServiceTrimsAndSortsNames(t *testing.T) {
+	_, labels := newLabelTestServices()
+	for _, name := range []string{" ux ", "Bug", "docs"} {
+		if _, err := labels.CreateLabel(models.CreateLabelRequest{Name: name, Color: "#ffffff"}); err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+	}
+	if _, err := labels.CreateLabel(models.CreateLabelRequest{Name: "UX", Color: "#000000"}); !errors.Is(err, ErrDuplicateLabel) {
+		t.Errorf("expected ErrDuplicateLabel, got %v", err)
+	}
+
+	all, err := labels.GetAllLabels()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	var names []string
+	for _, label := range all {
+		names = append(names, label.Name)
+	}
+	if !slices.Equal(names, []string{"Bug", "docs", "ux"}) {
+		t.Errorf("expected trimmed names sorted by name, got %q", names)
+	}
+
+	renamed := "  feature  "
+	updated, err := labels.UpdateLabel(all[0].ID, models.UpdateLabelRequest{Name: &renamed})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Name != "feature" {
+		t.Errorf("expected the new name to be trimmed, got %q", updated.Name)
+	}
+}
+
+func TestTaskServiceCreateTaskWithLabels(t *testing.T) {
+	tasks, labels := newLabelTestServices()
+	bug, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#ff0000"})
+
+	task, err := tasks.CreateTask(models.CreateTaskRequest{Title: "Crash", Labels: []string{bug.ID, bug.ID}})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(task.Labels) != 1 || task.Labels[0] != bug.ID {
+		t.Errorf("expected labels [%s], got %v", bug.ID, task.Labels)
+	}
+
+	_, err = tasks.CreateTask(models.CreateTaskRequest{Title: "Other", Labels: []string{"missing"}})
+	if !errors.Is(err, repository.ErrLabelNotFound) {
+		t.Errorf("expected ErrLabelNotFound, got %v", err)
+	}
+}
+
+func TestTaskServiceGetTasksByLabel(t *testing.T) {
+	tasks, labels := newLabelTestServices()
+	bug, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#ff0000"})
+	_, _ = tasks.CreateTask(models.CreateTaskRequest{Title: "Crash", Labels: []string{bug.ID}})
+	_, _ = tasks.CreateTask(models.CreateTaskRequest{Title: "Feature"})
+
+	filtered, err := tasks.GetTasksByLabel(bug.ID)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(filtered) != 1 || filtered[0].Title != "Crash" {
+		t.Errorf("expected only Crash, got %v", filtered)
+	}
+}
+
+func TestLabelServiceDeleteLabelUnlinksTasks(t *testing.T) {
+	tasks, labels := newLabelTestServices()
+	bug, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#ff0000"})
+	task, _ := tasks.CreateTask(models.CreateTaskRequest{Title: "Crash", Labels: []string{bug.ID}})
+
+	events, cancel := tasks.Subscribe()
+	defer cancel()
+
+	if err := labels.DeleteLabel(bug.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	updated, _ := tasks.GetTaskByID(task.ID)
+	if len(updated.Labels) != 0 {
+		t.Errorf("expected task labels to be cleared, got %v", updated.Labels)
+	}
+	if len(task.Labels) != 1 {
+		t.Errorf("expected the task read before the removal to be untouched, got %v", task.Labels)
+	}
+	select {
+	case event := <-events:
+		if event.Type != models.TaskUpdated || event.TaskID != task.ID || len(event.Task.Labels) != 0 {
+			t.Errorf("unexpected event %+v", event)
+		}
+	default:
+		t.Error("expected a task updated event for the unlinked task")
+	}
+}
+
+func TestLabelServiceDeleteLabelUnlinksTemplates(t *testing.T) {
+	labelRepo := repository.NewInMemoryLabelRepository()
+	recurringRepo := repository.NewInMemoryRecurringTaskRepository()
+	templateRepo := repository.NewInMemoryTaskTemplateRepository()
+	tasks := NewTaskService(repository.NewInMemoryTaskRepository(), WithLabelRepository(labelRepo))
+	labels := NewLabelService(labelRepo, tasks,
+		WithRecurringTasks(recurringRepo),
+		WithTaskTemplates(templateRepo),
+	)
+	bug, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#ff0000"})
+	docs, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "docs", Color: "#00ff00"})
+	_ = recurringRepo.Create(&models.RecurringTask{ID: "r1", Title: "Triage", Labels: []string{bug.ID, docs.ID}})
+	_ = templateRepo.Create(&models.TaskTemplate{ID: "t1", Name: "Bug report", Labels: []string{bug.ID}})
+
+	if err := labels.DeleteLabel(bug.ID); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	recurring, _ := recurringRepo.GetByID("r1")
+	if len(recurring.Labels) != 1 || recurring.Labels[0] != docs.ID {
+		t.Errorf("expected only %s on the recurring task, got %v", docs.ID, recurring.Labels)
+	}
+	template, _ := templateRepo.GetByID("t1")
+	if len(template.Labels) != 0 {
+		t.Errorf("expected template labels to be cleared, got %v", template.Labels)
+	}
+}
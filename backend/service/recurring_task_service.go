@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/schedule"
+)
+
+var (
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrInvalidTimezone = errors.New("invalid timezone")
+)
+
+// RecurringTaskService gerencia os modelos de tarefas recorrentes e cria,
+// via TaskService.CreateTask, as tarefas de cada ocorrência devida
+type RecurringTaskService struct {
+	templates repository.RecurringTaskRepository
+	tasks     *TaskService
+}
+
+// NewRecurringTaskService cria uma nova instância do serviço de tarefas recorrentes
+func NewRecurringTaskService(templates repository.RecurringTaskRepository, tasks *TaskService) *RecurringTaskService {
+	return &RecurringTaskService{
+		templates: templates,
+		tasks:     tasks,
+	}
+}
+
+// CreateRecurringTask valida e cadastra um novo modelo recorrente
+func (s *RecurringTaskService) CreateRecurringTask(req models.CreateRecurringTaskRequest) (*models.RecurringTask, error) {
+	template := &models.RecurringTask{
+		ID:          generateID(),
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Labels:      req.Labels,
+		Schedule:    req.Schedule,
+		Timezone:    req.Timezone,
+		Enabled:     req.Enabled == nil || *req.Enabled,
+		CreatedAt:   s.tasks.clock.Now(),
+	}
+	if template.Priority == "" {
+		template.Priority = models.PriorityMedium
+	}
+	if template.Timezone == "" {
+		template.Timezone = "UTC"
+	}
+
+	if err := s.validate(template); err != nil {
+		return nil, err
+	}
+	if err := s.scheduleNext(template, s.tasks.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.Create(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetAllRecurringTasks retorna todos os modelos recorrentes
+func (s *RecurringTaskService) GetAllRecurringTasks() ([]*models.RecurringTask, error) {
+	return s.templates.GetAll()
+}
+
+// GetRecurringTaskByID busca um modelo recorrente pelo ID
+func (s *RecurringTaskService) GetRecurringTaskByID(id string) (*models.RecurringTask, error) {
+	return s.templates.GetByID(id)
+}
+
+// UpdateRecurringTask atualiza um modelo e recalcula a próxima ocorrência
+func (s *RecurringTaskService) UpdateRecurringTask(id string, req models.UpdateRecurringTaskRequest) (*models.RecurringTask, error) {
+	current, err := s.templates.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateCopy(current, func(template *models.RecurringTask) error {
+		setIfPresent(&template.Title, req.Title)
+		setIfPresent(&template.Description, req.Description)
+		setIfPresent(&template.Priority, req.Priority)
+		setIfPresent(&template.Labels, req.Labels)
+		setIfPresent(&template.Schedule, req.Schedule)
+		setIfPresent(&template.Timezone, req.Timezone)
+		setIfPresent(&template.Enabled, req.Enabled)
+		if err := s.validate(template); err != nil {
+			return err
+		}
+		return s.scheduleNext(template, s.tasks.clock.Now())
+	}, s.templates.Update)
+}
+
+// DeleteRecurringTask remove o modelo; as tarefas já geradas são mantidas
+func (s *RecurringTaskService) DeleteRecurringTask(id string) error {
+	return s.templates.Delete(id)
+}
+
+// RunDue cria as tarefas dos modelos cuja próxima ocorrência já passou e
+// retorna quantas foram criadas. Se o servidor ficou parado e várias
+// ocorrências foram perdidas, apenas a mais recente é criada. A ocorrência
+// é registrada na tarefa, então rodar de novo (ex: após um restart) não duplica.
+func (s *RecurringTaskService) RunDue() (int, error) {
+	templates, err := s.templates.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.tasks.clock.Now()
+	created := 0
+	// Uma recorrência com erro não impede as demais: os erros são reunidos e
+	// ela é tentada de novo no próximo ciclo
+	var errs []error
+	for _, template := range templates {
+		if !template.Enabled || template.NextRunAt == nil || template.NextRunAt.After(now) {
+			continue
+		}
+		if err := s.runOccurrence(template, now); err != nil {
+			errs = append(errs, fmt.Errorf("recurring task %s: %w", template.ID, err))
+			continue
+		}
+		created++
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// runOccurrence cria a tarefa da ocorrência devida e agenda a seguinte
+func (s *RecurringTaskService) runOccurrence(template *models.RecurringTask, now time.Time) error {
+	cron, loc, err := parseSchedule(template)
+	if err != nil {
+		return err
+	}
+
+	occurrence := template.NextRunAt.In(loc)
+	for next := cron.Next(occurrence); !next.IsZero() && !next.After(now); next = cron.Next(next) {
+		occurrence = next
+	}
+
+	if _, err := s.tasks.CreateTask(models.CreateTaskRequest{
+		Title:       template.Title,
+		Description: template.Description,
+		Priority:    template.Priority,
+		Labels:      template.Labels,
+		Occurrence: &models.Occurrence{
+			RecurringTaskID: template.ID,
+			ScheduledAt:     occurrence.UTC(),
+		},
+	}); err != nil {
+		return err
+	}
+
+	// O modelo do repositório é compartilhado com as requisições em andamento;
+	// o agendamento é gravado numa cópia
+	_, err = updateCopy(template, func(template *models.RecurringTask) error {
+		lastRun := occurrence.UTC()
+		template.LastRunAt = &lastRun
+		return s.scheduleNext(template, now)
+	}, s.templates.Update)
+	return err
+}
+
+// Start verifica as ocorrências devidas a cada interval até o contexto ser cancelado
+func (s *RecurringTaskService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.RunDue()
+			if err != nil {
+				log.Printf("recurring tasks: %v", err)
+			}
+			if count > 0 {
+				log.Printf("recurring tasks: %d task(s) created", count)
+			}
+		}
+	}
+}
+
+// validate verifica título, descrição, prioridade, etiquetas, expressão e fuso
+// do modelo, reunindo os erros de todos os campos
+func (s *RecurringTaskService) validate(template *models.RecurringTask) error {
+	var v validator
+	v.title(template.Title)
+	v.text("description", template.Description, MaxDescriptionLength, nil)
+	if !isValidPriority(template.Priority) {
+		v.add("priority", ErrInvalidPriority)
+	}
+	v.count("labels", len(template.Labels), MaxTaskLabels)
+	if len(template.Labels) > 0 {
+		labels, err := s.tasks.resolveLabels(template.Labels)
+		if v.merge(err) != nil {
+			return err
+		}
+		template.Labels = labels
+	}
+	if _, _, err := parseSchedule(template); v.merge(err) != nil {
+		return err
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	template.Title = strings.TrimSpace(template.Title)
+	return nil
+}
+
+// scheduleNext calcula a próxima ocorrência posterior a from
+func (s *RecurringTaskService) scheduleNext(template *models.RecurringTask, from time.Time) error {
+	cron, loc, err := parseSchedule(template)
+	if err != nil {
+		return err
+	}
+
+	next := cron.Next(from.In(loc))
+	if next.IsZero() {
+		template.NextRunAt = nil
+		return nil
+	}
+	next = next.UTC()
+	template.NextRunAt = &next
+	return nil
+}
+
+// parseSchedule interpreta a expressão cron e o fuso horário do modelo
+func parseSchedule(template *models.RecurringTask) (*schedule.Cron, *time.Location, error) {
+	cron, err := schedule.Parse(template.Schedule)
+	if err != nil {
+		return nil, nil, fieldError("schedule", fmt.Errorf("%w: %v", ErrInvalidSchedule, err))
+	}
+	loc, err := time.LoadLocation(template.Timezone)
+	if err != nil {
+		return nil, nil, fieldError("timezone", fmt.Errorf("%w: %s", ErrInvalidTimezone, template.Timezone))
+	}
+	return cron, loc, nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func newRecurringTestServices(clock Clock) (*TaskService, *RecurringTaskService, *repository.InMemoryRecurringTaskRepository) {
+	tasks := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock))
+	templates := repository.NewInMemoryRecurringTaskRepository()
+	return tasks, NewRecurringTaskService(templates, tasks), templates
+}
+
+func TestRecurringTaskServiceCreateRecurringTask(t *testing.T) {
+	clock := newFakeClock() // 2025-01-01 12:00 UTC, quarta-feira
+	_, recurring, _ := newRecurringTestServices(clock)
+
+	template, err := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{
+		Title:    "Update dependencies",
+		Schedule: "0 9 * * 1",
+	})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	want := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	if template.NextRunAt == nil || !template.NextRunAt.Equal(want) {
+		t.Errorf("expected next run %v, got %v", want, template.NextRunAt)
+	}
+	if !template.Enabled || template.Timezone != "UTC" || template.Priority != models.PriorityMedium {
+		t.Errorf("unexpected defaults %+v", template)
+	}
+}
+
+func TestRecurringTaskServiceCreateRecurringTaskValidation(t *testing.T) {
+	_, recurring, _ := newRecurringTestServices(newFakeClock())
+
+	_, err := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "x", Schedule: "every monday"})
+	if !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+
+	_, err = recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "x", Schedule: "@daily", Timezone: "Mars/Olympus"})
+	if !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("expected ErrInvalidTimezone, got %v", err)
+	}
+
+	_, err = recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Schedule: "@daily"})
+	if !errors.Is(err, ErrInvalidTitle) {
+		t.Errorf("expected ErrInvalidTitle, got %v", err)
+	}
+}
+
+func TestRecurringTaskServiceUpdateRecurringTask(t *testing.T) {
+	_, recurring, _ := newRecurringTestServices(newFakeClock())
+	template, _ := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "Update dependencies", Schedule: "0 9 * * 1"})
+
+	schedule := "@daily"
+	updated, err := recurring.UpdateRecurringTask(template.ID, models.UpdateRecurringTaskRequest{Schedule: &schedule})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	want := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	if updated.NextRunAt == nil || !updated.NextRunAt.Equal(want) {
+		t.Errorf("expected next run %v, got %v", want, updated.NextRunAt)
+	}
+
+	title, invalid := "Renamed", "every monday"
+	_, err = recurring.UpdateRecurringTask(template.ID, models.UpdateRecurringTaskRequest{Title: &title, Schedule: &invalid})
+	if !errors.Is(err, ErrInvalidSchedule) {
+		t.Fatalf("expected ErrInvalidSchedule, got %v", err)
+	}
+	stored, _ := recurring.GetRecurringTaskByID(template.ID)
+	if stored.Title != "Update dependencies" || stored.Schedule != schedule {
+		t.Errorf("expected a failed update to keep the recurring task, got %+v", stored)
+	}
+}
+
+func TestRecurringTaskServiceRunDue(t *testing.T) {
+	clock := newFakeClock()
+	tasks, recurring, _ := newRecurringTestServices(clock)
+	template, _ := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "Daily standup notes", Schedule: "0 9 * * *"})
+
+	if count, _ := recurring.RunDue(); count != 0 {
+		t.Fatalf("expected nothing due yet, got %d", count)
+	}
+
+	// Três ocorrências perdidas: só a mais recente vira tarefa
+	clock.Advance(3 * 24 * time.Hour)
+	shared, _ := recurring.GetRecurringTaskByID(template.ID)
+	count, err := recurring.RunDue()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 task created, got %d", count)
+	}
+
+	all, _ := tasks.GetAllTasks()
+	if len(all) != 1 || all[0].Occurrence == nil || all[0].Occurrence.RecurringTaskID != template.ID {
+		t.Fatalf("expected one generated task, got %+v", all)
+	}
+	want := time.Date(2025, 1, 4, 9, 0, 0, 0, time.UTC)
+	if !all[0].Occurrence.ScheduledAt.Equal(want) {
+		t.Errorf("expected occurrence %v, got %v", want, all[0].Occurrence.ScheduledAt)
+	}
+
+	updated, _ := recurring.GetRecurringTaskByID(template.ID)
+	if updated.NextRunAt == nil || !updated.NextRunAt.Equal(want.Add(24*time.Hour)) {
+		t.Errorf("expected next run to advance, got %v", updated.NextRunAt)
+	}
+	if updated.LastRunAt == nil || !updated.LastRunAt.Equal(want) {
+		t.Errorf("expected last run %v, got %v", want, updated.LastRunAt)
+	}
+	// O agendamento é gravado numa cópia, sem alterar o modelo que outra
+	// requisição pode estar lendo
+	if shared.LastRunAt != nil || !shared.NextRunAt.Before(want) {
+		t.Errorf("expected the previously read recurring task to be untouched, got %+v", shared)
+	}
+}
+
+func TestRecurringTaskServiceRunDueIsIdempotent(t *testing.T) {
+	clock := newFakeClock()
+	tasks, recurring, templates := newRecurringTestServices(clock)
+	template, _ := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "Backup", Schedule: "@daily"})
+	due := *template.NextRunAt
+
+	clock.Advance(24 * time.Hour)
+	_, _ = recurring.RunDue()
+
+	// Simula um restart que perdeu o avanço de NextRunAt após criar a tarefa
+	stored, _ := templates.GetByID(template.ID)
+	stored.NextRunAt = &due
+	_ = templates.Update(stored)
+	_, _ = recurring.RunDue()
+
+	all, _ := tasks.GetAllTasks()
+	if len(all) != 1 {
+		t.Errorf("expected occurrence to be created only once, got %d tasks", len(all))
+	}
+}
+
+func TestRecurringTaskServiceRunDueSkipsDisabled(t *testing.T) {
+	clock := newFakeClock()
+	tasks, recurring, _ := newRecurringTestServices(clock)
+	disabled := false
+	_, _ = recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "Paused", Schedule: "@hourly", Enabled: &disabled})
+
+	clock.Advance(2 * time.Hour)
+	_, _ = recurring.RunDue()
+
+	if all, _ := tasks.GetAllTasks(); len(all) != 0 {
+		t.Errorf("expected no tasks for disabled template, got %d", len(all))
+	}
+}
+
+func TestRecurringTaskServiceRunDueContinuesAfterErrors(t *testing.T) {
+	clock := newFakeClock()
+	labelRepo := repository.NewInMemoryLabelRepository()
+	tasks := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock), WithLabelRepository(labelRepo))
+	recurring := NewRecurringTaskService(repository.NewInMemoryRecurringTaskRepository(), tasks)
+	label := &models.Label{ID: "l1", Name: "ops", Color: "#000000"}
+	_ = labelRepo.Create(label)
+
+	broken, _ := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "Rotate keys", Schedule: "@hourly", Labels: []string{label.ID}})
+	healthy, _ := recurring.CreateRecurringTask(models.CreateRecurringTaskRequest{Title: "Backup", Schedule: "@hourly"})
+	// Etiqueta removida sem passar pelo LabelService
+	_ = labelRepo.Delete(label.ID)
+
+	clock.Advance(time.Hour)
+	count, err := recurring.RunDue()
+
+	if count != 1 || !errors.Is(err, repository.ErrLabelNotFound) {
+		t.Fatalf("expected 1 task and ErrLabelNotFound, got %d and %v", count, err)
+	}
+	if updated, _ := recurring.GetRecurringTaskByID(healthy.ID); updated.LastRunAt == nil {
+		t.Error("expected the healthy template to run")
+	}
+	if stuck, _ := recurring.GetRecurringTaskByID(broken.ID); stuck.LastRunAt != nil {
+		t.Error("expected the failing template to be retried on the next run")
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// DefaultReportPeriod é o período de tarefas concluídas usado quando since não é informado
+const DefaultReportPeriod = 7 * 24 * time.Hour
+
+// boardColumns define a ordem e o nome das colunas nos relatórios
+var boardColumns = []models.BoardColumn{
+	{Status: models.StatusTodo, Name: "A Fazer"},
+	{Status: models.StatusInProgress, Name: "Em Progresso"},
+	{Status: models.StatusDone, Name: "Concluídas"},
+}
+
+// GetBoardReport agrupa as tarefas ativas por status, ordenadas por prioridade,
+// e lista as concluídas a partir de since (padrão: últimos 7 dias), das mais
+// recentes para as mais antigas
+func (s *TaskService) GetBoardReport(since time.Time) (*models.BoardReport, error) {
+	now := s.clock.Now()
+	if since.IsZero() {
+		since = now.Add(-DefaultReportPeriod)
+	}
+
+	tasks, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	labelNames, err := s.labelNames()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SortTasks(tasks, SortByPriority, false); err != nil {
+		return nil, err
+	}
+
+	report := &models.BoardReport{
+		GeneratedAt: now,
+		Since:       since,
+		Columns:     slices.Clone(boardColumns),
+	}
+	var completed []*models.Task
+	for _, task := range tasks {
+		if task.CompletedAt != nil && !task.CompletedAt.Before(since) {
+			completed = append(completed, task)
+		}
+		if task.Archived {
+			continue
+		}
+		for i := range report.Columns {
+			if report.Columns[i].Status == task.Status {
+				report.Columns[i].Tasks = append(report.Columns[i].Tasks, reportTask(task, labelNames))
+				report.Total++
+			}
+		}
+	}
+
+	slices.SortStableFunc(completed, func(a, b *models.Task) int { return b.CompletedAt.Compare(*a.CompletedAt) })
+	for _, task := range completed {
+		report.Completed = append(report.Completed, reportTask(task, labelNames))
+	}
+
+	return report, nil
+}
+
+// labelNames mapeia o ID de cada etiqueta para o nome
+func (s *TaskService) labelNames() (map[string]string, error) {
+	labels, err := s.labels.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(labels))
+	for _, label := range labels {
+		names[label.ID] = label.Name
+	}
+	return names, nil
+}
+
+// reportTask troca os IDs das etiquetas pelos nomes
+func reportTask(task *models.Task, labelNames map[string]string) models.ReportTask {
+	names := make([]string, 0, len(task.Labels))
+	for _, id := range task.Labels {
+		if name, ok := labelNames[id]; ok {
+			names = append(names, name)
+		}
+	}
+	return models.ReportTask{Task: task, LabelNames: names}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestTaskServiceGetBoardReport(t *testing.T) {
+	clock := newFakeClock()
+	labels := repository.NewInMemoryLabelRepository()
+	_ = labels.Create(&models.Label{ID: "bug-id", Name: "bug", Color: "#d73a4a"})
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock), WithLabelRepository(labels))
+
+	done := models.StatusDone
+	old, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Old"})
+	_, _ = svc.UpdateTask(old.ID, models.UpdateTaskRequest{Status: &done})
+	_, _ = svc.ArchiveCompletedTasks(0)
+
+	clock.Advance(10 * 24 * time.Hour)
+	_, _ = svc.CreateTask(models.CreateTaskRequest{Title: "Low", Priority: models.PriorityLow})
+	_, _ = svc.CreateTask(models.CreateTaskRequest{Title: "Urgent", Priority: models.PriorityUrgent, Labels: []string{"bug-id"}})
+	recent, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Recent"})
+	_, _ = svc.UpdateTask(recent.ID, models.UpdateTaskRequest{Status: &done})
+
+	report, err := svc.GetBoardReport(time.Time{})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if report.Total != 3 || len(report.Columns) != 3 {
+		t.Fatalf("expected 3 active tasks in 3 columns, got %+v", report)
+	}
+	todo := report.Columns[0]
+	if todo.Count() != 2 || todo.Tasks[0].Title != "Urgent" || len(todo.Tasks[0].LabelNames) != 1 || todo.Tasks[0].LabelNames[0] != "bug" {
+		t.Errorf("expected todo column sorted by priority with label names, got %+v", todo.Tasks)
+	}
+	if report.Columns[2].Count() != 1 {
+		t.Errorf("expected archived task to be left out of the done column, got %d", report.Columns[2].Count())
+	}
+	if len(report.Completed) != 1 || report.Completed[0].Title != "Recent" {
+		t.Errorf("expected only tasks completed in the last 7 days, got %+v", report.Completed)
+	}
+
+	report, _ = svc.GetBoardReport(clock.Now().Add(-30 * 24 * time.Hour))
+	if len(report.Completed) != 2 || report.Completed[0].Title != "Recent" {
+		t.Errorf("expected archived task in the completed section, newest first, got %+v", report.Completed)
+	}
+}
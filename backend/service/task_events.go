@@ -0,0 +1,77 @@
+package service
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+// taskEventBuffer é quantos eventos um assinante pode acumular antes de perder os próximos
+const taskEventBuffer = 64
+
+// taskEvents distribui as alterações de tarefas para os assinantes
+type taskEvents struct {
+	mu          sync.Mutex
+	subscribers map[chan models.TaskEvent]struct{}
+}
+
+// Subscribe assina as alterações de tarefas (criação, edição, mudança de
+// status, checklist, arquivamento e remoção). Cada evento traz uma cópia da
+// tarefa. Um assinante que não consome os eventos a tempo perde os que não
+// couberem no buffer, sem atrasar as operações do serviço. A função retornada
+// cancela a assinatura e fecha o canal.
+func (s *TaskService) Subscribe() (<-chan models.TaskEvent, func()) {
+	ch := make(chan models.TaskEvent, taskEventBuffer)
+
+	s.events.mu.Lock()
+	if s.events.subscribers == nil {
+		s.events.subscribers = make(map[chan models.TaskEvent]struct{})
+	}
+	s.events.subscribers[ch] = struct{}{}
+	s.events.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.events.mu.Lock()
+			delete(s.events.subscribers, ch)
+			s.events.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publish envia o evento para os assinantes sem bloquear
+func (s *TaskService) publish(eventType models.TaskEventType, taskID string, task *models.Task) {
+	event := models.TaskEvent{Type: eventType, TaskID: taskID}
+	if task != nil {
+		event.Task = cloneTask(task)
+	}
+
+	s.events.mu.Lock()
+	defer s.events.mu.Unlock()
+	for ch := range s.events.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// saveTask grava a tarefa alterada e avisa os assinantes
+func (s *TaskService) saveTask(task *models.Task) error {
+	if err := s.repo.Update(task); err != nil {
+		return err
+	}
+	s.publish(models.TaskUpdated, task.ID, task)
+	return nil
+}
+
+// cloneTask copia a tarefa para que o evento não mude com edições posteriores
+func cloneTask(task *models.Task) *models.Task {
+	clone := *task
+	clone.Checklist = slices.Clone(task.Checklist)
+	clone.Labels = slices.Clone(task.Labels)
+	return &clone
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+// nextEvent lê o próximo evento sem bloquear o teste
+func nextEvent(t *testing.T, events <-chan models.TaskEvent) models.TaskEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	default:
+		t.Fatal("expected an event, got none")
+		return models.TaskEvent{}
+	}
+}
+
+func TestTaskServiceSubscribePublishesChanges(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	events, cancel := svc.Subscribe()
+	defer cancel()
+
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task"})
+	if event := nextEvent(t, events); event.Type != models.TaskCreated || event.TaskID != task.ID || event.Task.Title != "Task" {
+		t.Errorf("expected created event for %s, got %+v", task.ID, event)
+	}
+
+	status := models.StatusInProgress
+	_, _ = svc.UpdateTask(task.ID, models.UpdateTaskRequest{Status: &status})
+	if event := nextEvent(t, events); event.Type != models.TaskUpdated || event.Task.Status != status {
+		t.Errorf("expected updated event with status %s, got %+v", status, event)
+	}
+
+	_, _ = svc.AddChecklistItem(task.ID, models.AddChecklistItemRequest{Text: "Item"})
+	event := nextEvent(t, events)
+	if event.Type != models.TaskUpdated || len(event.Task.Checklist) != 1 {
+		t.Errorf("expected updated event with checklist, got %+v", event)
+	}
+
+	_ = svc.DeleteTask(task.ID)
+	if event := nextEvent(t, events); event.Type != models.TaskDeleted || event.TaskID != task.ID || event.Task != nil {
+		t.Errorf("expected deleted event for %s, got %+v", task.ID, event)
+	}
+}
+
+func TestTaskServiceSubscribeEventsAreCopies(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	events, cancel := svc.Subscribe()
+	defer cancel()
+
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Task", Checklist: []string{"a"}})
+	event := nextEvent(t, events)
+
+	title := "Changed"
+	_, _ = svc.UpdateTask(task.ID, models.UpdateTaskRequest{Title: &title})
+	if event.Task.Title != "Task" {
+		t.Errorf("expected event to keep title Task, got %s", event.Task.Title)
+	}
+}
+
+func TestTaskServiceSubscribeCancel(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	events, cancel := svc.Subscribe()
+	cancel()
+	cancel()
+
+	if _, open := <-events; open {
+		t.Error("expected channel to be closed after cancel")
+	}
+	if _, err := svc.CreateTask(models.CreateTaskRequest{Title: "Task"}); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+}
+
+func TestTaskServiceSubscribeDropsEventsWhenFull(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	events, cancel := svc.Subscribe()
+	defer cancel()
+
+	for range taskEventBuffer + 5 {
+		if _, err := svc.CreateTask(models.CreateTaskRequest{Title: "Task"}); err != nil {
+			t.Fatalf(msgExpectedNoError, err)
+		}
+	}
+	if len(events) != taskEventBuffer {
+		t.Errorf("expected %d buffered events, got %d", taskEventBuffer, len(events))
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/jsonpatch"
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrInvalidPatchedTask = errors.New("patched task is invalid")
+
+// MergePatchTask aplica um JSON Merge Patch (RFC 7396) aos campos editáveis
+// da tarefa; null limpa o campo
+func (s *TaskService) MergePatchTask(id string, patch []byte) (*models.Task, error) {
+	return s.patchTask(id, patch, jsonpatch.MergePatch)
+}
+
+// JSONPatchTask aplica um JSON Patch (RFC 6902) aos campos editáveis da
+// tarefa, incluindo itens de etiquetas e do checklist (ex: /checklist/0/checked)
+func (s *TaskService) JSONPatchTask(id string, patch []byte) (*models.Task, error) {
+	return s.patchTask(id, patch, jsonpatch.Apply)
+}
+
+// patchTask aplica o patch sobre a tarefa no formato de ReplaceTaskRequest e
+// a substitui pelo resultado, com as mesmas validações do PUT
+func (s *TaskService) patchTask(id string, patch []byte, apply func(doc, patch []byte) ([]byte, error)) (*models.Task, error) {
+	task, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(replaceRequest(task))
+	if err != nil {
+		return nil, err
+	}
+	patched, err := apply(doc, patch)
+	if err != nil {
+		return nil, err
+	}
+	req, err := decodeReplaceRequest(patched)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ReplaceTask(id, req)
+}
+
+// replaceRequest representa a tarefa como no corpo do PUT. Listas vazias
+// viram [] para que o patch possa acrescentar itens em "/labels/-".
+func replaceRequest(task *models.Task) models.ReplaceTaskRequest {
+	req := models.ReplaceTaskRequest{
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Labels:      append([]string{}, task.Labels...),
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		Checklist:   make([]models.ChecklistItemInput, 0, len(task.Checklist)),
+	}
+	for _, item := range task.Checklist {
+		req.Checklist = append(req.Checklist, models.ChecklistItemInput{ID: item.ID, Text: item.Text, Checked: item.Checked})
+	}
+	return req
+}
+
+// decodeReplaceRequest lê o resultado do patch; campos fora da representação
+// editável (ex: id, completed) não podem ser criados
+func decodeReplaceRequest(data []byte) (models.ReplaceTaskRequest, error) {
+	var req models.ReplaceTaskRequest
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr) && typeErr.Field == "":
+			return req, fmt.Errorf("%w: task must be an object", ErrInvalidPatchedTask)
+		case errors.As(err, &typeErr):
+			return req, fieldError(typeErr.Field, fmt.Errorf("%w: %s has an invalid type", ErrInvalidPatchedTask, typeErr.Field))
+		default:
+			return req, fmt.Errorf("%w: %s", ErrInvalidPatchedTask, strings.TrimPrefix(err.Error(), "json: "))
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/jsonpatch"
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func TestTaskServiceMergePatchTask(t *testing.T) {
+	svc, labels := newLabelTestServices()
+	label, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#d73a4a"})
+	task, _ := svc.CreateTask(models.CreateTaskRequest{
+		Title: "Original", Description: "Old", Priority: models.PriorityHigh, Labels: []string{label.ID},
+	})
+
+	// Campos ausentes não mudam e null limpa o campo
+	patched, err := svc.MergePatchTask(task.ID, []byte(`{"title": "Patched", "description": null, "labels": null, "dueDate": "2025-03-01T18:00:00Z"}`))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if patched.Title != "Patched" || patched.Description != "" || len(patched.Labels) != 0 ||
+		patched.Priority != models.PriorityHigh || patched.DueDate == nil {
+		t.Errorf("unexpected patched task %+v", patched)
+	}
+
+	if _, err := svc.MergePatchTask(task.ID, []byte(`{"dueDate": null}`)); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if stored, _ := svc.GetTaskByID(task.ID); stored.DueDate != nil {
+		t.Errorf("expected due date to be cleared, got %v", stored.DueDate)
+	}
+}
+
+func TestTaskServiceJSONPatchTask(t *testing.T) {
+	svc, labels := newLabelTestServices()
+	label, _ := labels.CreateLabel(models.CreateLabelRequest{Name: "bug", Color: "#d73a4a"})
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Original", Checklist: []string{"one", "two"}})
+	secondID := task.Checklist[1].ID
+
+	patched, err := svc.JSONPatchTask(task.ID, []byte(`[
+		{"op": "test", "path": "/title", "value": "Original"},
+		{"op": "add", "path": "/labels/-", "value": "`+label.ID+`"},
+		{"op": "replace", "path": "/checklist/1/checked", "value": true},
+		{"op": "move", "from": "/checklist/1", "path": "/checklist/0"},
+		{"op": "add", "path": "/checklist/-", "value": {"text": "three"}},
+		{"op": "replace", "path": "/status", "value": "in_progress"}
+	]`))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(patched.Labels) != 1 || patched.Status != models.StatusInProgress {
+		t.Errorf("unexpected patched task %+v", patched)
+	}
+	if len(patched.Checklist) != 3 || patched.Checklist[0].ID != secondID || !patched.Checklist[0].Checked ||
+		patched.Checklist[2].Text != "three" || patched.Checklist[2].Order != 2 {
+		t.Errorf("unexpected checklist %+v", patched.Checklist)
+	}
+}
+
+func TestTaskServicePatchTaskErrors(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Original"})
+
+	tests := []struct {
+		name  string
+		patch func(string, []byte) (*models.Task, error)
+		body  string
+		want  error
+	}{
+		{"malformed merge patch", svc.MergePatchTask, `{"title":`, jsonpatch.ErrInvalidPatch},
+		{"read-only field", svc.MergePatchTask, `{"completed": true}`, ErrInvalidPatchedTask},
+		{"wrong type", svc.MergePatchTask, `{"title": 1}`, ErrInvalidPatchedTask},
+		{"removed title", svc.MergePatchTask, `{"title": null}`, ErrInvalidTitle},
+		{"invalid status", svc.MergePatchTask, `{"status": "blocked"}`, ErrInvalidStatus},
+		{"malformed JSON patch", svc.JSONPatchTask, `{"op": "add"}`, jsonpatch.ErrInvalidPatch},
+		{"missing path", svc.JSONPatchTask, `[{"op": "replace", "path": "/completed", "value": true}]`, jsonpatch.ErrPathNotFound},
+		{"failed test", svc.JSONPatchTask, `[{"op": "test", "path": "/title", "value": "Other"}]`, jsonpatch.ErrTestFailed},
+		{"growing document", svc.JSONPatchTask, "[" + strings.Repeat(`{"op": "copy", "from": "/labels", "path": "/labels/-"},`, 30) + `{"op": "test", "path": "/title", "value": "Original"}]`, jsonpatch.ErrDocumentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.patch(task.ID, []byte(tt.body)); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := svc.MergePatchTask("missing", []byte(`{}`)); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+	if stored, _ := svc.GetTaskByID(task.ID); stored.Title != "Original" {
+		t.Errorf("expected the task to be unchanged, got %+v", stored)
+	}
+}
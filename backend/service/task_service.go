@@ -3,59 +3,205 @@ package service
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/acauhi/kanban-backend/models"
 	"github.com/acauhi/kanban-backend/repository"
+	"github.com/acauhi/kanban-backend/storage"
 )
 
 var (
-	ErrInvalidTitle  = errors.New("title is required")
-	ErrInvalidStatus = errors.New("invalid status")
+	ErrInvalidTitle    = errors.New("title is required")
+	ErrInvalidStatus   = errors.New("invalid status")
+	ErrInvalidPriority = errors.New("invalid priority")
+	ErrDuplicateTaskID = errors.New("a task with this id already exists")
+	ErrInvalidTaskID   = errors.New("id must have 1 to 64 letters, digits, '_' or '-'")
 )
 
+// taskIDPattern aceita os IDs gerados por generateID e IDs importados que
+// podem ir com segurança para caminhos de URL e chaves de armazenamento
+var taskIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
 // idCounter helps ensure unique IDs when created in rapid succession during tests
 var idCounter int64
 
 type TaskService struct {
-	repo repository.TaskRepository
+	repo        repository.TaskRepository
+	deps        repository.DependencyRepository
+	labels      repository.LabelRepository
+	comments    repository.CommentRepository
+	attachments repository.AttachmentRepository
+	blobs       storage.BlobStore
+	templates   repository.TaskTemplateRepository
+	clock       Clock
+	autoStart   bool
+	events      taskEvents
+}
+
+// Option configura parâmetros opcionais do TaskService
+type Option func(*TaskService)
+
+// WithClock substitui o relógio usado pelo serviço (útil em testes)
+func WithClock(clock Clock) Option {
+	return func(s *TaskService) {
+		s.clock = clock
+	}
 }
 
 // NewTaskService cria uma nova instância do serviço de tarefas
-func NewTaskService(repo repository.TaskRepository) *TaskService {
-	return &TaskService{
-		repo: repo,
+func NewTaskService(repo repository.TaskRepository, opts ...Option) *TaskService {
+	s := &TaskService{
+		repo:        repo,
+		deps:        repository.NewInMemoryDependencyRepository(),
+		labels:      repository.NewInMemoryLabelRepository(),
+		comments:    repository.NewInMemoryCommentRepository(),
+		attachments: repository.NewInMemoryAttachmentRepository(),
+		blobs:       storage.NewMemoryBlobStore(),
+		templates:   repository.NewInMemoryTaskTemplateRepository(),
+		clock:       systemClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // CreateTask cria uma nova tarefa com status inicial "todo"
 func (s *TaskService) CreateTask(req models.CreateTaskRequest) (*models.Task, error) {
-	if req.Title == "" {
-		return nil, ErrInvalidTitle
+	var v validator
+	v.title(req.Title)
+	v.text("description", req.Description, MaxDescriptionLength, nil)
+
+	priority := req.Priority
+	if priority == "" {
+		priority = models.PriorityMedium
+	}
+	if !isValidPriority(priority) {
+		v.add("priority", ErrInvalidPriority)
+	}
+
+	v.count("checklist", len(req.Checklist), MaxChecklistItems)
+	checklist := make([]models.ChecklistItem, 0, len(req.Checklist))
+	for i, text := range req.Checklist {
+		v.checklistItem(fmt.Sprintf("checklist[%d]", i), text)
+		checklist = append(checklist, models.ChecklistItem{ID: generateID(), Text: strings.TrimSpace(text), Order: i})
+	}
+
+	v.count("labels", len(req.Labels), MaxTaskLabels)
+	var labels []string
+	if len(req.Labels) > 0 {
+		var err error
+		if labels, err = s.resolveLabels(req.Labels); v.merge(err) != nil {
+			return nil, err
+		}
+	}
+	if err := v.err(); err != nil {
+		return nil, err
 	}
 
-	// Gera ID único usando timestamp + UUID
-	id := generateID()
+	// Ocorrências de tarefas recorrentes geram no máximo uma tarefa
+	if req.Occurrence != nil {
+		existing, err := s.findOccurrence(*req.Occurrence)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	// Gera ID único usando timestamp + UUID, exceto quando a importação preserva o original
+	id := req.ID
+	if id == "" {
+		id = generateID()
+	} else if _, err := s.repo.GetByID(id); err == nil {
+		return nil, ErrDuplicateTaskID
+	} else if !errors.Is(err, repository.ErrTaskNotFound) {
+		return nil, err
+	}
 
 	task := &models.Task{
 		ID:          id,
-		Title:       req.Title,
+		Title:       strings.TrimSpace(req.Title),
 		Description: req.Description,
 		Status:      models.StatusTodo,
 		Completed:   false,
+		Labels:      labels,
+		Priority:    priority,
+		DueDate:     req.DueDate,
+		Occurrence:  req.Occurrence,
+	}
+	if len(checklist) > 0 {
+		task.Checklist = checklist
 	}
 
 	if err := s.repo.Create(task); err != nil {
 		return nil, err
 	}
+	s.publish(models.TaskCreated, task.ID, task)
 
 	return task, nil
 }
 
-// GetAllTasks retorna todas as tarefas cadastradas
+// GetAllTasks retorna as tarefas ativas do quadro (sem as arquivadas)
 func (s *TaskService) GetAllTasks() ([]*models.Task, error) {
-	return s.repo.GetAll()
+	return s.filterTasks(func(task *models.Task) bool { return !task.Archived })
+}
+
+// GetAllTasksIncludingArchived retorna todas as tarefas, inclusive as arquivadas,
+// ordenadas por ID (ordem de criação)
+func (s *TaskService) GetAllTasksIncludingArchived() ([]*models.Task, error) {
+	tasks, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(tasks, func(a, b *models.Task) int { return strings.Compare(a.ID, b.ID) })
+	return tasks, nil
+}
+
+// GetArchivedTasks retorna apenas as tarefas arquivadas
+func (s *TaskService) GetArchivedTasks() ([]*models.Task, error) {
+	return s.filterTasks(func(task *models.Task) bool { return task.Archived })
+}
+
+// GetOverdueTasks retorna as tarefas ativas e não concluídas cujo prazo já passou
+func (s *TaskService) GetOverdueTasks() ([]*models.Task, error) {
+	now := s.clock.Now()
+	return s.filterTasks(func(task *models.Task) bool {
+		return !task.Archived && !task.Completed && task.DueDate != nil && task.DueDate.Before(now)
+	})
+}
+
+// ArchiveCompletedTasks arquiva as tarefas concluídas há mais de maxAge
+// e retorna quantas foram arquivadas
+func (s *TaskService) ArchiveCompletedTasks(maxAge time.Duration) (int, error) {
+	tasks, err := s.repo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.clock.Now()
+	archived := 0
+	for _, task := range tasks {
+		if task.Archived || task.Status != models.StatusDone || task.CompletedAt == nil {
+			continue
+		}
+		if now.Sub(*task.CompletedAt) < maxAge {
+			continue
+		}
+		task.Archived = true
+		task.ArchivedAt = &now
+		if err := s.saveTask(task); err != nil {
+			return archived, err
+		}
+		archived++
+	}
+
+	return archived, nil
 }
 
 // GetTaskByID busca uma tarefa específica pelo ID
@@ -63,6 +209,11 @@ func (s *TaskService) GetTaskByID(id string) (*models.Task, error) {
 	return s.repo.GetByID(id)
 }
 
+// Revision retorna a revisão atual do quadro, que muda a cada escrita nas tarefas
+func (s *TaskService) Revision() (repository.Revision, error) {
+	return s.repo.Revision()
+}
+
 // UpdateTask atualiza os campos de uma tarefa existente
 func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest) (*models.Task, error) {
 	task, err := s.repo.GetByID(id)
@@ -70,34 +221,190 @@ func (s *TaskService) UpdateTask(id string, req models.UpdateTaskRequest) (*mode
 		return nil, err
 	}
 
+	// A tarefa guardada só muda depois que a requisição inteira é aceita
+	var v validator
 	if req.Title != nil {
-		if *req.Title == "" {
-			return nil, ErrInvalidTitle
+		v.title(*req.Title)
+	}
+	if req.Description != nil {
+		v.text("description", *req.Description, MaxDescriptionLength, nil)
+	}
+	if req.Priority != nil && !isValidPriority(*req.Priority) {
+		v.add("priority", ErrInvalidPriority)
+	}
+	var labels []string
+	if req.Labels != nil {
+		v.count("labels", len(*req.Labels), MaxTaskLabels)
+		if labels, err = s.resolveLabels(*req.Labels); v.merge(err) != nil {
+			return nil, err
 		}
-		task.Title = *req.Title
+	}
+	if req.Status != nil && !isValidStatus(*req.Status) {
+		v.add("status", ErrInvalidStatus)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if req.Status != nil && *req.Status == models.StatusDone && !task.Completed {
+		blocked, err := s.hasOpenBlockers(task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrTaskBlocked
+		}
+	}
+
+	if req.Title != nil {
+		task.Title = strings.TrimSpace(*req.Title)
 	}
 	if req.Description != nil {
 		task.Description = *req.Description
 	}
-	if req.Status != nil {
-		if !isValidStatus(*req.Status) {
-			return nil, ErrInvalidStatus
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.DueDate != nil {
+		task.DueDate = req.DueDate
+	}
+	if req.Labels != nil {
+		task.Labels = labels
+	}
+	if req.Status != nil && *req.Status != task.Status {
+		s.applyStatus(task, *req.Status)
+	}
+
+	if err := s.saveTask(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// ReplaceTask substitui todos os campos editáveis da tarefa (PUT). A
+// requisição é validada por inteiro antes de qualquer alteração.
+func (s *TaskService) ReplaceTask(id string, req models.ReplaceTaskRequest) (*models.Task, error) {
+	task, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var v validator
+	v.title(req.Title)
+	v.text("description", req.Description, MaxDescriptionLength, nil)
+	status := req.Status
+	if status == "" {
+		status = models.StatusTodo
+	}
+	if !isValidStatus(status) {
+		v.add("status", ErrInvalidStatus)
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = models.PriorityMedium
+	}
+	if !isValidPriority(priority) {
+		v.add("priority", ErrInvalidPriority)
+	}
+	v.count("labels", len(req.Labels), MaxTaskLabels)
+	var labels []string
+	if len(req.Labels) > 0 {
+		if labels, err = s.resolveLabels(req.Labels); v.merge(err) != nil {
+			return nil, err
 		}
-		task.Status = *req.Status
-		// Atualiza o campo 'completed' baseado no status
-		task.Completed = (*req.Status == models.StatusDone)
+	}
+	checklist := replaceChecklist(&v, task, req.Checklist)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if status == models.StatusDone && !task.Completed {
+		blocked, err := s.hasOpenBlockers(task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrTaskBlocked
+		}
+	}
+
+	task.Title = strings.TrimSpace(req.Title)
+	task.Description = req.Description
+	task.Priority = priority
+	task.DueDate = req.DueDate
+	task.Labels = labels
+	task.Checklist = checklist
+	if status != task.Status {
+		s.applyStatus(task, status)
 	}
 
-	if err := s.repo.Update(task); err != nil {
+	if err := s.saveTask(task); err != nil {
 		return nil, err
 	}
 
 	return task, nil
 }
 
-// DeleteTask remove uma tarefa pelo ID
+// DeleteTask remove uma tarefa pelo ID junto com suas dependências, comentários e anexos
 func (s *TaskService) DeleteTask(id string) error {
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	s.publish(models.TaskDeleted, id, nil)
+	if err := s.deps.RemoveTask(id); err != nil {
+		return err
+	}
+	if err := s.comments.DeleteByTask(id); err != nil {
+		return err
+	}
+	return s.deleteTaskAttachments(id)
+}
+
+// applyStatus altera o status da tarefa mantendo os campos derivados
+// (completed, data de conclusão e arquivamento) consistentes
+func (s *TaskService) applyStatus(task *models.Task, status models.Status) {
+	task.Status = status
+	// Atualiza o campo 'completed' baseado no status
+	task.Completed = (status == models.StatusDone)
+	if task.Completed {
+		now := s.clock.Now()
+		task.CompletedAt = &now
+		return
+	}
+	// Tarefas que saem de "done" deixam de ser concluídas e voltam ao quadro
+	task.CompletedAt = nil
+	task.Archived = false
+	task.ArchivedAt = nil
+}
+
+// findOccurrence busca a tarefa já gerada para a ocorrência, se houver
+func (s *TaskService) findOccurrence(occurrence models.Occurrence) (*models.Task, error) {
+	tasks, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if task.Occurrence != nil && task.Occurrence.RecurringTaskID == occurrence.RecurringTaskID &&
+			task.Occurrence.ScheduledAt.Equal(occurrence.ScheduledAt) {
+			return task, nil
+		}
+	}
+	return nil, nil
+}
+
+// filterTasks retorna as tarefas do repositório que satisfazem o predicado
+func (s *TaskService) filterTasks(keep func(*models.Task) bool) ([]*models.Task, error) {
+	tasks, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if keep(task) {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered, nil
 }
 
 // isValidStatus valida se o status fornecido é um dos valores permitidos
@@ -110,6 +417,16 @@ func isValidStatus(status models.Status) bool {
 	}
 }
 
+// isValidPriority valida se a prioridade fornecida é um dos valores permitidos
+func isValidPriority(priority models.Priority) bool {
+	switch priority {
+	case models.PriorityLow, models.PriorityMedium, models.PriorityHigh, models.PriorityUrgent:
+		return true
+	default:
+		return false
+	}
+}
+
 // generateID cria um ID único para uma tarefa
 func generateID() string {
 	// combine timestamp with an atomic counter to avoid collisions in tests
@@ -1,7 +1,9 @@
 package service
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/acauhi/kanban-backend/models"
 	"github.com/acauhi/kanban-backend/repository"
@@ -41,7 +43,7 @@ func TestTaskServiceCreateTaskEmptyTitle(t *testing.T) {
 	}
 
 	_, err := svc.CreateTask(req)
-	if err != ErrInvalidTitle {
+	if !errors.Is(err, ErrInvalidTitle) {
 		t.Errorf("expected ErrInvalidTitle, got %v", err)
 	}
 }
@@ -85,7 +87,7 @@ func TestTaskServiceUpdateTaskInvalidStatus(t *testing.T) {
 	}
 
 	_, err := svc.UpdateTask(task.ID, req)
-	if err != ErrInvalidStatus {
+	if !errors.Is(err, ErrInvalidStatus) {
 		t.Errorf("expected ErrInvalidStatus, got %v", err)
 	}
 }
@@ -147,7 +149,7 @@ func TestTaskServiceUpdateTaskEmptyTitle(t *testing.T) {
 	req := models.UpdateTaskRequest{Title: &emptyTitle}
 
 	_, err := svc.UpdateTask(task.ID, req)
-	if err != ErrInvalidTitle {
+	if !errors.Is(err, ErrInvalidTitle) {
 		t.Errorf("expected ErrInvalidTitle, got %v", err)
 	}
 }
@@ -206,3 +208,138 @@ func TestTaskServiceUpdateTaskRepositoryError(t *testing.T) {
 		t.Errorf("expected ErrMockError, got %v", err)
 	}
 }
+
+func TestTaskServiceCreateTaskDefaultPriority(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+
+	task, err := svc.CreateTask(models.CreateTaskRequest{Title: "Test"})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if task.Priority != models.PriorityMedium {
+		t.Errorf("expected priority %s, got %s", models.PriorityMedium, task.Priority)
+	}
+}
+
+func TestTaskServiceInvalidPriority(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+
+	_, err := svc.CreateTask(models.CreateTaskRequest{Title: "Test", Priority: "critical"})
+	if !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("expected ErrInvalidPriority, got %v", err)
+	}
+
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Test"})
+	invalid := models.Priority("critical")
+	_, err = svc.UpdateTask(task.ID, models.UpdateTaskRequest{Priority: &invalid})
+	if !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("expected ErrInvalidPriority, got %v", err)
+	}
+}
+
+func TestTaskServiceGetOverdueTasks(t *testing.T) {
+	clock := newFakeClock()
+	svc := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(clock))
+
+	yesterday := clock.Now().Add(-24 * time.Hour)
+	tomorrow := clock.Now().Add(24 * time.Hour)
+	_, _ = svc.CreateTask(models.CreateTaskRequest{Title: "Late", DueDate: &yesterday})
+	_, _ = svc.CreateTask(models.CreateTaskRequest{Title: "On time", DueDate: &tomorrow})
+	_, _ = svc.CreateTask(models.CreateTaskRequest{Title: "No due date"})
+	finished, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Finished", DueDate: &yesterday})
+	done := models.StatusDone
+	_, _ = svc.UpdateTask(finished.ID, models.UpdateTaskRequest{Status: &done})
+
+	overdue, err := svc.GetOverdueTasks()
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if len(overdue) != 1 || overdue[0].Title != "Late" {
+		t.Errorf("expected only Late to be overdue, got %v", taskTitles(overdue))
+	}
+
+	clock.Advance(48 * time.Hour)
+	overdue, _ = svc.GetOverdueTasks()
+	if len(overdue) != 2 {
+		t.Errorf("expected 2 overdue tasks after advancing the clock, got %d", len(overdue))
+	}
+}
+
+func TestTaskServiceReplaceTask(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	svc := NewTaskService(repo)
+
+	due := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+	task, _ := svc.CreateTask(models.CreateTaskRequest{
+		Title: "Original", Description: "Old", Priority: models.PriorityHigh, DueDate: &due, Checklist: []string{"one", "two"},
+	})
+	secondID := task.Checklist[1].ID
+
+	// Campos ausentes voltam ao padrão; itens com ID são mantidos e os sem ID criados
+	replaced, err := svc.ReplaceTask(task.ID, models.ReplaceTaskRequest{
+		Title:  "Replaced",
+		Status: models.StatusInProgress,
+		Checklist: []models.ChecklistItemInput{
+			{ID: secondID, Text: "two", Checked: true},
+			{Text: "three"},
+		},
+	})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if replaced.Title != "Replaced" || replaced.Description != "" || replaced.DueDate != nil ||
+		replaced.Priority != models.PriorityMedium || replaced.Status != models.StatusInProgress {
+		t.Errorf("unexpected replaced task %+v", replaced)
+	}
+	if len(replaced.Checklist) != 2 || replaced.Checklist[0].ID != secondID || !replaced.Checklist[0].Checked ||
+		replaced.Checklist[1].ID == "" || replaced.Checklist[1].Order != 1 {
+		t.Errorf("unexpected checklist %+v", replaced.Checklist)
+	}
+}
+
+func TestTaskServiceReplaceTaskValidatesBeforeChanging(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	svc := NewTaskService(repo)
+
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "Original", Checklist: []string{"one"}})
+	itemID := task.Checklist[0].ID
+
+	tests := []struct {
+		name  string
+		req   models.ReplaceTaskRequest
+		want  error
+		field string
+	}{
+		{"empty title", models.ReplaceTaskRequest{}, ErrInvalidTitle, "title"},
+		{"invalid status", models.ReplaceTaskRequest{Title: "x", Status: "blocked"}, ErrInvalidStatus, "status"},
+		{"invalid priority", models.ReplaceTaskRequest{Title: "x", Priority: "meh"}, ErrInvalidPriority, "priority"},
+		{"unknown label", models.ReplaceTaskRequest{Title: "x", Labels: []string{"missing"}}, repository.ErrLabelNotFound, "labels"},
+		{"empty item", models.ReplaceTaskRequest{Title: "x", Checklist: []models.ChecklistItemInput{{}}}, ErrInvalidChecklistText, "checklist[0].text"},
+		{"unknown item", models.ReplaceTaskRequest{Title: "x", Checklist: []models.ChecklistItemInput{{ID: "missing", Text: "a"}}}, ErrChecklistItemNotFound, "checklist[0].id"},
+		{
+			"duplicated item",
+			models.ReplaceTaskRequest{Title: "x", Checklist: []models.ChecklistItemInput{{ID: itemID, Text: "a"}, {ID: itemID, Text: "b"}}},
+			ErrDuplicateChecklistItem,
+			"checklist[1].id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ReplaceTask(task.ID, tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.field {
+				t.Errorf("expected the error to name field %q, got %#v", tt.field, err)
+			}
+		})
+	}
+
+	stored, _ := svc.GetTaskByID(task.ID)
+	if stored.Title != "Original" || len(stored.Checklist) != 1 {
+		t.Errorf("expected the task to be unchanged, got %+v", stored)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// Campos aceitos para ordenação da listagem de tarefas
+const (
+	SortByDueDate  = "dueDate"
+	SortByPriority = "priority"
+	SortByTitle    = "title"
+)
+
+// priorityRank define o peso de cada prioridade (maior = mais importante)
+var priorityRank = map[models.Priority]int{
+	models.PriorityLow:    0,
+	models.PriorityMedium: 1,
+	models.PriorityHigh:   2,
+	models.PriorityUrgent: 3,
+}
+
+// SortTasks ordena as tarefas pelo campo informado. A ordem padrão é a mais
+// útil para o quadro: prazos mais próximos e prioridades mais altas primeiro;
+// desc inverte essa ordem. Tarefas sem prazo ficam sempre no final.
+func SortTasks(tasks []*models.Task, field string, desc bool) error {
+	var cmp func(a, b *models.Task) int
+	switch field {
+	case SortByDueDate:
+		cmp = func(a, b *models.Task) int {
+			if a.DueDate == nil || b.DueDate == nil {
+				return compareMissing(a.DueDate == nil, b.DueDate == nil, desc)
+			}
+			return a.DueDate.Compare(*b.DueDate)
+		}
+	case SortByPriority:
+		cmp = func(a, b *models.Task) int {
+			return priorityRank[b.Priority] - priorityRank[a.Priority]
+		}
+	case SortByTitle:
+		cmp = func(a, b *models.Task) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+	default:
+		return ErrInvalidSort
+	}
+
+	slices.SortStableFunc(tasks, func(a, b *models.Task) int {
+		if desc {
+			return cmp(b, a)
+		}
+		return cmp(a, b)
+	})
+	return nil
+}
+
+// compareMissing posiciona valores ausentes no final independente da direção
+func compareMissing(aMissing, bMissing, desc bool) int {
+	result := 0
+	switch {
+	case aMissing && !bMissing:
+		result = 1
+	case !aMissing && bMissing:
+		result = -1
+	}
+	// A comparação é invertida quando desc=true, então compensamos aqui
+	if desc {
+		return -result
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/acauhi/kanban-backend/models"
+)
+
+func taskTitles(tasks []*models.Task) []string {
+	titles := make([]string, len(tasks))
+	for i, task := range tasks {
+		titles[i] = task.Title
+	}
+	return titles
+}
+
+func TestSortTasksByDueDate(t *testing.T) {
+	early := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := early.Add(48 * time.Hour)
+	tasks := []*models.Task{
+		{Title: "none"},
+		{Title: "late", DueDate: &late},
+		{Title: "early", DueDate: &early},
+	}
+
+	if err := SortTasks(tasks, SortByDueDate, false); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if got := taskTitles(tasks); got[0] != "early" || got[1] != "late" || got[2] != "none" {
+		t.Errorf("unexpected ascending order %v", got)
+	}
+
+	_ = SortTasks(tasks, SortByDueDate, true)
+	if got := taskTitles(tasks); got[0] != "late" || got[1] != "early" || got[2] != "none" {
+		t.Errorf("unexpected descending order %v", got)
+	}
+}
+
+func TestSortTasksByPriority(t *testing.T) {
+	tasks := []*models.Task{
+		{Title: "low", Priority: models.PriorityLow},
+		{Title: "urgent", Priority: models.PriorityUrgent},
+		{Title: "medium", Priority: models.PriorityMedium},
+	}
+
+	_ = SortTasks(tasks, SortByPriority, false)
+	if got := taskTitles(tasks); got[0] != "urgent" || got[1] != "medium" || got[2] != "low" {
+		t.Errorf("unexpected order %v", got)
+	}
+}
+
+func TestSortTasksInvalidField(t *testing.T) {
+	if err := SortTasks(nil, "color", false); err != ErrInvalidSort {
+		t.Errorf("expected ErrInvalidSort, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+var (
+	ErrInvalidTemplateName     = errors.New("template name is required")
+	ErrDuplicateTemplate       = errors.New("a template with this name already exists")
+	ErrMissingTemplateVariable = errors.New("missing template variables")
+)
+
+// templateVariablePattern reconhece variáveis no formato {{nome}}
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// builtinTemplateVariables são preenchidas automaticamente quando não informadas
+var builtinTemplateVariables = []string{"date"}
+
+type TaskTemplateService struct {
+	templates repository.TaskTemplateRepository
+	tasks     *TaskService
+}
+
+// NewTaskTemplateService cria uma nova instância do serviço de modelos de tarefa
+func NewTaskTemplateService(templates repository.TaskTemplateRepository, tasks *TaskService) *TaskTemplateService {
+	return &TaskTemplateService{
+		templates: templates,
+		tasks:     tasks,
+	}
+}
+
+// WithTaskTemplateRepository define o repositório usado por CreateTaskFromTemplate
+func WithTaskTemplateRepository(templates repository.TaskTemplateRepository) Option {
+	return func(s *TaskService) {
+		s.templates = templates
+	}
+}
+
+// CreateTemplate valida e cadastra um novo modelo de tarefa
+func (s *TaskTemplateService) CreateTemplate(req models.CreateTaskTemplateRequest) (*models.TaskTemplate, error) {
+	template := &models.TaskTemplate{
+		ID:          generateID(),
+		Name:        strings.TrimSpace(req.Name),
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    req.Priority,
+		Labels:      req.Labels,
+		Checklist:   req.Checklist,
+		CreatedAt:   s.tasks.clock.Now(),
+	}
+	if template.Priority == "" {
+		template.Priority = models.PriorityMedium
+	}
+
+	if err := s.validate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.Create(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetAllTemplates retorna todos os modelos de tarefa
+func (s *TaskTemplateService) GetAllTemplates() ([]*models.TaskTemplate, error) {
+	return s.templates.GetAll()
+}
+
+// GetTemplateByID busca um modelo de tarefa pelo ID
+func (s *TaskTemplateService) GetTemplateByID(id string) (*models.TaskTemplate, error) {
+	return s.templates.GetByID(id)
+}
+
+// UpdateTemplate atualiza os campos informados de um modelo de tarefa
+func (s *TaskTemplateService) UpdateTemplate(id string, req models.UpdateTaskTemplateRequest) (*models.TaskTemplate, error) {
+	current, err := s.templates.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updateCopy(current, func(template *models.TaskTemplate) error {
+		if req.Name != nil {
+			template.Name = strings.TrimSpace(*req.Name)
+		}
+		setIfPresent(&template.Title, req.Title)
+		setIfPresent(&template.Description, req.Description)
+		setIfPresent(&template.Priority, req.Priority)
+		setIfPresent(&template.Labels, req.Labels)
+		setIfPresent(&template.Checklist, req.Checklist)
+		return s.validate(template)
+	}, s.templates.Update)
+}
+
+// DeleteTemplate remove um modelo de tarefa
+func (s *TaskTemplateService) DeleteTemplate(id string) error {
+	return s.templates.Delete(id)
+}
+
+// validate verifica os campos do modelo e registra as variáveis usadas
+func (s *TaskTemplateService) validate(template *models.TaskTemplate) error {
+	var v validator
+	v.text("name", template.Name, MaxTitleLength, ErrInvalidTemplateName)
+	v.title(template.Title)
+	v.text("description", template.Description, MaxDescriptionLength, nil)
+	if !isValidPriority(template.Priority) {
+		v.add("priority", ErrInvalidPriority)
+	}
+	v.count("checklist", len(template.Checklist), MaxChecklistItems)
+	for i, item := range template.Checklist {
+		v.checklistItem(fmt.Sprintf("checklist[%d]", i), item)
+	}
+	v.count("labels", len(template.Labels), MaxTaskLabels)
+	if len(template.Labels) > 0 {
+		labels, err := s.tasks.resolveLabels(template.Labels)
+		if v.merge(err) != nil {
+			return err
+		}
+		template.Labels = labels
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	template.Title = strings.TrimSpace(template.Title)
+
+	templates, err := s.templates.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, other := range templates {
+		if other.ID != template.ID && strings.EqualFold(other.Name, template.Name) {
+			return fieldError("name", ErrDuplicateTemplate)
+		}
+	}
+
+	template.Variables = templateVariables(template)
+	return nil
+}
+
+// CreateTaskFromTemplate instancia o modelo com as variáveis informadas e cria a tarefa
+func (s *TaskService) CreateTaskFromTemplate(templateID string, req models.InstantiateTemplateRequest) (*models.Task, error) {
+	template, err := s.templates.GetByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	createReq, err := s.instantiateTemplate(template, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateTask(createReq)
+}
+
+// instantiateTemplate substitui as variáveis {{nome}} do modelo e monta o CreateTaskRequest
+func (s *TaskService) instantiateTemplate(template *models.TaskTemplate, vars map[string]string) (models.CreateTaskRequest, error) {
+	values := map[string]string{"date": s.clock.Now().Format("2006-01-02")}
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	var missing []string
+	for _, name := range template.Variables {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return models.CreateTaskRequest{}, fmt.Errorf("%w: %s", ErrMissingTemplateVariable, strings.Join(missing, ", "))
+	}
+
+	substitute := func(text string) string {
+		return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+			name := templateVariablePattern.FindStringSubmatch(match)[1]
+			return values[name]
+		})
+	}
+
+	checklist := make([]string, len(template.Checklist))
+	for i, item := range template.Checklist {
+		checklist[i] = substitute(item)
+	}
+
+	return models.CreateTaskRequest{
+		Title:       substitute(template.Title),
+		Description: substitute(template.Description),
+		Priority:    template.Priority,
+		Labels:      slices.Clone(template.Labels),
+		Checklist:   checklist,
+	}, nil
+}
+
+// templateVariables lista, em ordem alfabética, as variáveis usadas no modelo
+// que precisam ser informadas na instanciação
+func templateVariables(template *models.TaskTemplate) []string {
+	texts := append([]string{template.Title, template.Description}, template.Checklist...)
+
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, match := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+			seen[match[1]] = true
+		}
+	}
+	for _, name := range builtinTemplateVariables {
+		delete(seen, name)
+	}
+
+	vars := make([]string, 0, len(seen))
+	for name := range seen {
+		vars = append(vars, name)
+	}
+	sort.Strings(vars)
+	return vars
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+func newTemplateTestServices() (*TaskService, *TaskTemplateService) {
+	templates := repository.NewInMemoryTaskTemplateRepository()
+	tasks := NewTaskService(repository.NewInMemoryTaskRepository(), WithClock(newFakeClock()), WithTaskTemplateRepository(templates))
+	return tasks, NewTaskTemplateService(templates, tasks)
+}
+
+func bugReportTemplate() models.CreateTaskTemplateRequest {
+	return models.CreateTaskTemplateRequest{
+		Name:        "Bug report",
+		Title:       "[Bug] {{component}}: {{summary}}",
+		Description: "Reported on {{date}}\n\n## Steps to reproduce\n",
+		Priority:    models.PriorityHigh,
+		Checklist:   []string{"Reproduce on {{component}}", "Write regression test"},
+	}
+}
+
+func TestTaskTemplateServiceCreateTemplate(t *testing.T) {
+	_, templates := newTemplateTestServices()
+
+	template, err := templates.CreateTemplate(bugReportTemplate())
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if want := []string{"component", "summary"}; !reflect.DeepEqual(template.Variables, want) {
+		t.Errorf("expected variables %v, got %v", want, template.Variables)
+	}
+
+	if _, err := templates.CreateTemplate(bugReportTemplate()); !errors.Is(err, ErrDuplicateTemplate) {
+		t.Errorf("expected ErrDuplicateTemplate, got %v", err)
+	}
+}
+
+func TestTaskTemplateServiceCreateTemplateValidation(t *testing.T) {
+	_, templates := newTemplateTestServices()
+
+	if _, err := templates.CreateTemplate(models.CreateTaskTemplateRequest{Title: "x"}); !errors.Is(err, ErrInvalidTemplateName) {
+		t.Errorf("expected ErrInvalidTemplateName, got %v", err)
+	}
+	if _, err := templates.CreateTemplate(models.CreateTaskTemplateRequest{Name: "x"}); !errors.Is(err, ErrInvalidTitle) {
+		t.Errorf("expected ErrInvalidTitle, got %v", err)
+	}
+}
+
+func TestTaskTemplateServiceUpdateTemplate(t *testing.T) {
+	_, templates := newTemplateTestServices()
+	template, _ := templates.CreateTemplate(bugReportTemplate())
+
+	title, empty := "[Bug] {{summary}}", ""
+	updated, err := templates.UpdateTemplate(template.ID, models.UpdateTaskTemplateRequest{Title: &title})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if updated.Title != title || updated.Name != "Bug report" || !reflect.DeepEqual(updated.Variables, []string{"component", "summary"}) {
+		t.Errorf("unexpected updated template %+v", updated)
+	}
+
+	description := "changed"
+	_, err = templates.UpdateTemplate(template.ID, models.UpdateTaskTemplateRequest{Description: &description, Title: &empty})
+	if !errors.Is(err, ErrInvalidTitle) {
+		t.Fatalf("expected ErrInvalidTitle, got %v", err)
+	}
+	stored, _ := templates.GetTemplateByID(template.ID)
+	if stored.Title != title || stored.Description == description {
+		t.Errorf("expected a failed update to keep the template, got %+v", stored)
+	}
+}
+
+func TestTaskServiceCreateTaskFromTemplate(t *testing.T) {
+	tasks, templates := newTemplateTestServices()
+	template, _ := templates.CreateTemplate(bugReportTemplate())
+
+	task, err := tasks.CreateTaskFromTemplate(template.ID, models.InstantiateTemplateRequest{
+		Variables: map[string]string{"component": "login", "summary": "500 on submit"},
+	})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	if task.Title != "[Bug] login: 500 on submit" {
+		t.Errorf("unexpected title %q", task.Title)
+	}
+	if task.Description != "Reported on 2025-01-01\n\n## Steps to reproduce\n" {
+		t.Errorf("unexpected description %q", task.Description)
+	}
+	if task.Priority != models.PriorityHigh {
+		t.Errorf("expected priority %s, got %s", models.PriorityHigh, task.Priority)
+	}
+	if len(task.Checklist) != 2 || task.Checklist[0].Text != "Reproduce on login" {
+		t.Errorf("unexpected checklist %+v", task.Checklist)
+	}
+}
+
+func TestTaskServiceCreateTaskFromTemplateMissingVariable(t *testing.T) {
+	tasks, templates := newTemplateTestServices()
+	template, _ := templates.CreateTemplate(bugReportTemplate())
+
+	_, err := tasks.CreateTaskFromTemplate(template.ID, models.InstantiateTemplateRequest{
+		Variables: map[string]string{"component": "login"},
+	})
+	if !errors.Is(err, ErrMissingTemplateVariable) {
+		t.Errorf("expected ErrMissingTemplateVariable, got %v", err)
+	}
+
+	_, err = tasks.CreateTaskFromTemplate("missing", models.InstantiateTemplateRequest{})
+	if err != repository.ErrTaskTemplateNotFound {
+		t.Errorf("expected ErrTaskTemplateNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+// updateCopy aplica a atualização numa cópia de current e grava a cópia com
+// save. O registro original só é substituído quando apply (que também valida)
+// e save dão certo, então um erro não deixa o modelo pela metade.
+func updateCopy[T any](current *T, apply func(*T) error, save func(*T) error) (*T, error) {
+	updated := *current
+	if err := apply(&updated); err != nil {
+		return nil, err
+	}
+	if err := save(&updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// setIfPresent copia um campo opcional de um pedido de atualização parcial;
+// nil mantém o valor atual
+func setIfPresent[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limites dos campos de texto e listas das tarefas
+const (
+	MaxTitleLength         = 200
+	MaxDescriptionLength   = 10000
+	MaxChecklistTextLength = 500
+	MaxChecklistItems      = 100
+	MaxTaskLabels          = 20
+)
+
+var (
+	ErrInvalidUTF8  = errors.New("value is not valid UTF-8")
+	ErrFieldTooLong = errors.New("value is too long")
+	ErrTooManyItems = errors.New("list has too many items")
+)
+
+// ValidationErrors reúne os erros de todos os campos inválidos de uma
+// requisição. errors.Is e errors.As enxergam cada FieldError.
+type ValidationErrors []*FieldError
+
+// Error lista os campos e seus erros, ex: "title: title is required; priority: invalid priority"
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap retorna os erros de cada campo
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// validator acumula os erros de campo de uma requisição, para que todos
+// sejam informados de uma vez
+type validator struct {
+	errs ValidationErrors
+}
+
+// add registra um erro no campo
+func (v *validator) add(field string, err error) {
+	v.errs = append(v.errs, &FieldError{Field: field, Err: err})
+}
+
+// merge acumula os erros de campo de uma validação feita à parte, como a das
+// etiquetas. Outros erros (ex: falha do repositório) são retornados para
+// interromper a operação.
+func (v *validator) merge(err error) error {
+	var fieldErr *FieldError
+	var fieldErrs ValidationErrors
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &fieldErrs):
+		v.errs = append(v.errs, fieldErrs...)
+		return nil
+	case errors.As(err, &fieldErr):
+		v.errs = append(v.errs, fieldErr)
+		return nil
+	default:
+		return err
+	}
+}
+
+// text valida UTF-8 e tamanho máximo em caracteres. Com required informado,
+// um valor vazio ou só com espaços é rejeitado com esse erro.
+func (v *validator) text(field, value string, maxLength int, required error) {
+	switch {
+	case !utf8.ValidString(value):
+		v.add(field, ErrInvalidUTF8)
+	case required != nil && strings.TrimSpace(value) == "":
+		v.add(field, required)
+	case utf8.RuneCountInString(value) > maxLength:
+		v.add(field, fmt.Errorf("%w: %s accepts at most %d characters", ErrFieldTooLong, field, maxLength))
+	}
+}
+
+// count valida o número máximo de itens de uma lista
+func (v *validator) count(field string, n, maxItems int) {
+	if n > maxItems {
+		v.add(field, fmt.Errorf("%w: %s accepts at most %d items", ErrTooManyItems, field, maxItems))
+	}
+}
+
+// err retorna nil, o único erro de campo ou ValidationErrors com todos eles
+func (v *validator) err() error {
+	switch len(v.errs) {
+	case 0:
+		return nil
+	case 1:
+		return v.errs[0]
+	default:
+		return v.errs
+	}
+}
+
+// title aplica as regras do título de tarefas, modelos e tarefas recorrentes
+func (v *validator) title(value string) {
+	v.text("title", value, MaxTitleLength, ErrInvalidTitle)
+}
+
+// checklistItem aplica as regras do texto de um item do checklist
+func (v *validator) checklistItem(field, value string) {
+	v.text(field, value, MaxChecklistTextLength, ErrInvalidChecklistText)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/acauhi/kanban-backend/models"
+	"github.com/acauhi/kanban-backend/repository"
+)
+
+// fieldNames lista os campos dos erros de validação, na ordem em que foram reportados
+func fieldNames(err error) []string {
+	var fieldErrs ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		names := make([]string, len(fieldErrs))
+		for i, fieldErr := range fieldErrs {
+			names[i] = fieldErr.Field
+		}
+		return names
+	}
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return []string{fieldErr.Field}
+	}
+	return nil
+}
+
+func TestTaskServiceCreateTaskReportsAllInvalidFields(t *testing.T) {
+	svc, _ := newLabelTestServices()
+
+	_, err := svc.CreateTask(models.CreateTaskRequest{
+		Title:     "   ",
+		Priority:  "meh",
+		Checklist: []string{"ok", ""},
+		Labels:    []string{"missing"},
+	})
+
+	want := []string{"title", "priority", "checklist[1]", "labels"}
+	if got := fieldNames(err); !slices.Equal(got, want) {
+		t.Fatalf("expected fields %v, got %v (%v)", want, got, err)
+	}
+	for _, target := range []error{ErrInvalidTitle, ErrInvalidPriority, ErrInvalidChecklistText, repository.ErrLabelNotFound} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected the error to match %v, got %v", target, err)
+		}
+	}
+	if tasks, _ := svc.GetAllTasks(); len(tasks) != 0 {
+		t.Errorf("expected no task to be created, got %d", len(tasks))
+	}
+}
+
+func TestTaskServiceTrimsTitles(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+
+	task, err := svc.CreateTask(models.CreateTaskRequest{Title: "  Write docs \n", Checklist: []string{" draft "}})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if task.Title != "Write docs" || task.Checklist[0].Text != "draft" {
+		t.Errorf("expected trimmed title and item, got %q and %q", task.Title, task.Checklist[0].Text)
+	}
+
+	title := "\t"
+	if _, err := svc.UpdateTask(task.ID, models.UpdateTaskRequest{Title: &title}); !errors.Is(err, ErrInvalidTitle) {
+		t.Errorf("expected ErrInvalidTitle for a whitespace-only title, got %v", err)
+	}
+}
+
+func TestTaskServiceEnforcesFieldLimits(t *testing.T) {
+	svc := NewTaskService(repository.NewInMemoryTaskRepository())
+	task, _ := svc.CreateTask(models.CreateTaskRequest{Title: "x"})
+
+	tests := []struct {
+		name  string
+		req   models.ReplaceTaskRequest
+		want  error
+		field string
+	}{
+		{"long title", models.ReplaceTaskRequest{Title: strings.Repeat("a", MaxTitleLength+1)}, ErrFieldTooLong, "title"},
+		{"long description", models.ReplaceTaskRequest{Title: "x", Description: strings.Repeat("a", MaxDescriptionLength+1)}, ErrFieldTooLong, "description"},
+		{"invalid UTF-8", models.ReplaceTaskRequest{Title: "x\xff"}, ErrInvalidUTF8, "title"},
+		{
+			"long checklist item",
+			models.ReplaceTaskRequest{Title: "x", Checklist: []models.ChecklistItemInput{{Text: strings.Repeat("a", MaxChecklistTextLength+1)}}},
+			ErrFieldTooLong,
+			"checklist[0].text",
+		},
+		{
+			"too many checklist items",
+			models.ReplaceTaskRequest{Title: "x", Checklist: make([]models.ChecklistItemInput, MaxChecklistItems+1)},
+			ErrTooManyItems,
+			"checklist",
+		},
+		{"too many labels", models.ReplaceTaskRequest{Title: "x", Labels: make([]string, MaxTaskLabels+1)}, ErrTooManyItems, "labels"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ReplaceTask(task.ID, tt.req)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if !slices.Contains(fieldNames(err), tt.field) {
+				t.Errorf("expected the error to name field %q, got %v", tt.field, fieldNames(err))
+			}
+		})
+	}
+
+	// O limite conta caracteres, não bytes
+	if _, err := svc.ReplaceTask(task.ID, models.ReplaceTaskRequest{Title: strings.Repeat("é", MaxTitleLength)}); err != nil {
+		t.Errorf(msgExpectedNoError, err)
+	}
+}
+
+func TestValidationErrorsMessage(t *testing.T) {
+	err := ValidationErrors{
+		{Field: "title", Err: ErrInvalidTitle},
+		{Field: "priority", Err: ErrInvalidPriority},
+	}
+
+	if got, want := err.Error(), "title: title is required; priority: invalid priority"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestTaskServiceUpdateTaskRejectsWithoutChanges(t *testing.T) {
+	repo := repository.NewInMemoryTaskRepository()
+	svc := NewTaskService(repo)
+	task, err := svc.CreateTask(models.CreateTaskRequest{Title: "Original", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	before, _ := repo.Revision()
+
+	title := strings.Repeat("a", MaxTitleLength+1)
+	description := "changed"
+	priority := models.Priority("nope")
+	_, err = svc.UpdateTask(task.ID, models.UpdateTaskRequest{Title: &title, Description: &description, Priority: &priority})
+	if !errors.Is(err, ErrFieldTooLong) || !errors.Is(err, ErrInvalidPriority) {
+		t.Fatalf("expected ErrFieldTooLong and ErrInvalidPriority, got %v", err)
+	}
+
+	stored, err := svc.GetTaskByID(task.ID)
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if stored.Title != "Original" || stored.Description != "" || stored.Priority != models.PriorityLow {
+		t.Errorf("expected the stored task to be unchanged, got %q, %q and %q", stored.Title, stored.Description, stored.Priority)
+	}
+	if after, _ := repo.Revision(); after.Number != before.Number {
+		t.Errorf("expected revision %d, got %d", before.Number, after.Number)
+	}
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	ErrBlobNotFound = errors.New("blob not found")
+	ErrInvalidKey   = errors.New("invalid blob key")
+)
+
+// BlobStore armazena o conteúdo binário dos anexos, identificado por chave
+type BlobStore interface {
+	// Put grava todo o conteúdo de r sob a chave e retorna o tamanho gravado
+	Put(key string, r io.Reader) (int64, error)
+	// Get abre o conteúdo para leitura com suporte a Seek (usado em downloads com Range)
+	Get(key string) (Blob, error)
+	// Delete remove o conteúdo; chaves inexistentes retornam ErrBlobNotFound
+	Delete(key string) error
+}
+
+// Blob é um conteúdo aberto para leitura aleatória
+type Blob interface {
+	io.ReadSeekCloser
+	Size() int64
+}
+
+// validateKey aceita apenas chaves relativas no formato "a/b/c", sem "..",
+// para que nenhuma implementação escape de seu diretório ou bucket
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." || part == "." || strings.ContainsAny(part, "\\\x00") {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
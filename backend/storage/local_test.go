@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const msgExpectedNoError = "expected no error, got %v"
+
+func TestLocalBlobStorePutGetDelete(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+
+	size, err := store.Put("task/1", strings.NewReader("hello world"))
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if size != 11 {
+		t.Errorf("expected size 11, got %d", size)
+	}
+
+	blob, err := store.Get("task/1")
+	if err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := blob.Seek(6, io.SeekStart); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	if string(data) != "world" || blob.Size() != 11 {
+		t.Errorf("unexpected content %q (size %d)", data, blob.Size())
+	}
+
+	if err := store.Delete("task/1"); err != nil {
+		t.Fatalf(msgExpectedNoError, err)
+	}
+	if _, err := store.Get("task/1"); err != ErrBlobNotFound {
+		t.Errorf("expected ErrBlobNotFound, got %v", err)
+	}
+}
+
+func TestLocalBlobStoreRejectsInvalidKeys(t *testing.T) {
+	store, _ := NewLocalBlobStore(t.TempDir())
+
+	for _, key := range []string{"", "../escape", "/abs", "a/../../b", "a//b"} {
+		if _, err := store.Put(key, strings.NewReader("x")); err != ErrInvalidKey {
+			t.Errorf("key %q: expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+type MemoryBlobStore struct {
+	blobs map[string][]byte
+	mu    sync.RWMutex
+}
+
+// NewMemoryBlobStore cria um BlobStore em memória (padrão para testes e desenvolvimento)
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{
+		blobs: make(map[string][]byte),
+		mu:    sync.RWMutex{},
+	}
+}
+
+// Put grava o conteúdo em memória
+func (s *MemoryBlobStore) Put(key string, r io.Reader) (int64, error) {
+	if err := validateKey(key); err != nil {
+		return 0, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return int64(len(data)), nil
+}
+
+// Get abre o conteúdo armazenado em memória
+func (s *MemoryBlobStore) Get(key string) (Blob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, exists := s.blobs[key]
+	if !exists {
+		return nil, ErrBlobNotFound
+	}
+	return &memoryBlob{Reader: bytes.NewReader(data)}, nil
+}
+
+// Delete remove o conteúdo da memória
+func (s *MemoryBlobStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.blobs[key]; !exists {
+		return ErrBlobNotFound
+	}
+	delete(s.blobs, key)
+	return nil
+}
+
+type memoryBlob struct {
+	*bytes.Reader
+}
+
+// Close não faz nada: o conteúdo continua em memória
+func (b *memoryBlob) Close() error {
+	return nil
+}
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config reúne os parâmetros de acesso a um serviço compatível com S3
+// (AWS S3, MinIO, Ceph...). As URLs são montadas no estilo path:
+// {Endpoint}/{Bucket}/{key}.
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+type S3BlobStore struct {
+	endpoint *url.URL
+	bucket   string
+	signer   *sigV4Signer
+	client   *http.Client
+}
+
+// NewS3BlobStore cria um BlobStore que fala a API REST do S3 assinando as
+// requisições com AWS Signature Version 4
+func NewS3BlobStore(cfg S3Config, client *http.Client) (*S3BlobStore, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("S3 bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3BlobStore{
+		endpoint: endpoint,
+		bucket:   cfg.Bucket,
+		signer: &sigV4Signer{
+			accessKey: cfg.AccessKey,
+			secretKey: cfg.SecretKey,
+			region:    cfg.Region,
+			service:   "s3",
+			now:       time.Now,
+		},
+		client: client,
+	}, nil
+}
+
+// Put envia o conteúdo para o bucket. O S3 exige Content-Length em PUT, então
+// o conteúdo é primeiro gravado em um arquivo temporário.
+func (s *S3BlobStore) Put(key string, r io.Reader) (int64, error) {
+	if err := validateKey(key); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp("", "s3-upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	req, err := s.newRequest(http.MethodPut, key, tmp)
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = size
+
+	resp, err := s.do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return size, nil
+}
+
+// Get consulta o tamanho do objeto e retorna um leitor que busca o conteúdo
+// sob demanda com requisições Range
+func (s *S3BlobStore) Get(key string) (Blob, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return &s3Blob{store: s, key: key, size: resp.ContentLength}, nil
+}
+
+// Delete remove o objeto do bucket
+func (s *S3BlobStore) Delete(key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	// DELETE no S3 é idempotente, então verificamos a existência antes
+	if _, err := s.Get(key); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// newRequest monta a requisição para o objeto no estilo path
+func (s *S3BlobStore) newRequest(method, key string, body io.Reader) (*http.Request, error) {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	u.RawPath = s.endpoint.Path + "/" + awsURIEscape(s.bucket) + "/" + awsURIEscapePath(key)
+	return http.NewRequest(method, u.String(), body)
+}
+
+// do assina e executa a requisição, convertendo respostas de erro
+func (s *S3BlobStore) do(req *http.Request) (*http.Response, error) {
+	s.signer.sign(req, unsignedPayload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrBlobNotFound
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// s3Blob lê o objeto sob demanda. Cada Seek descarta a resposta atual e a
+// próxima leitura abre um GET com "Range: bytes={offset}-".
+type s3Blob struct {
+	store  *S3BlobStore
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+// Size retorna o tamanho informado pelo HEAD
+func (b *s3Blob) Size() int64 {
+	return b.size
+}
+
+// Read lê a partir da posição atual, abrindo a requisição se necessário
+func (b *s3Blob) Read(p []byte) (int, error) {
+	if b.offset >= b.size {
+		return 0, io.EOF
+	}
+	if b.body == nil {
+		req, err := b.store.newRequest(http.MethodGet, b.key, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(b.offset, 10)+"-")
+		resp, err := b.store.do(req)
+		if err != nil {
+			return 0, err
+		}
+		b.body = resp.Body
+	}
+
+	n, err := b.body.Read(p)
+	b.offset += int64(n)
+	return n, err
+}
+
+// Seek reposiciona a leitura sem tocar na rede
+func (b *s3Blob) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = b.offset + offset
+	case io.SeekEnd:
+		next = b.size + offset
+	default:
+		return 0, errors.New("s3: invalid whence")
+	}
+	if next < 0 {
+		return 0, errors.New("s3: negative position")
+	}
+
+	if next != b.offset {
+		b.closeBody()
+		b.offset = next
+	}
+	return next, nil
+}
+
+// Close libera a resposta em andamento, se houver
+func (b *s3Blob) Close() error {
+	b.closeBody()
+	return nil
+}
+
+// closeBody fecha a resposta HTTP atual
+func (b *s3Blob) closeBody() {
+	if b.body != nil {
+		b.body.Close()
+		b.body = nil
+	}
+}
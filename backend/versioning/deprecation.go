@@ -0,0 +1,51 @@
+package versioning
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers que anunciam a descontinuação de uma rota
+const (
+	// HeaderDeprecation traz o instante da descontinuação, ex: "@1792368000" (RFC 9745)
+	HeaderDeprecation = "Deprecation"
+	// HeaderSunset traz a data em que a rota deixa de responder (RFC 8594)
+	HeaderSunset = "Sunset"
+	// HeaderLink aponta a rota equivalente na versão que substitui a antiga
+	HeaderLink = "Link"
+)
+
+// Deprecation descreve uma rota antiga mantida por compatibilidade
+type Deprecation struct {
+	// Since é quando a rota foi descontinuada
+	Since time.Time
+	// Sunset é quando a rota deixará de responder; zero omite o header
+	Sunset time.Time
+	// Successor é o prefixo da versão que substitui a rota, ex: "/api/v1".
+	// Vazio omite o Link.
+	Successor string
+}
+
+// Deprecated repassa a requisição a next e marca a resposta com os headers
+// da descontinuação. O Link com rel="successor-version" leva o mesmo caminho
+// sob o prefixo da versão nova, para que o cliente saiba para onde migrar.
+func Deprecated(next http.Handler, d Deprecation) http.Handler {
+	deprecation := "@" + strconv.FormatInt(d.Since.Unix(), 10)
+	var sunset string
+	if !d.Sunset.IsZero() {
+		sunset = d.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set(HeaderDeprecation, deprecation)
+		if sunset != "" {
+			header.Set(HeaderSunset, sunset)
+		}
+		if d.Successor != "" {
+			header.Add(HeaderLink, "<"+d.Successor+r.URL.EscapedPath()+`>; rel="successor-version"`)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
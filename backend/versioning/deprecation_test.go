@@ -0,0 +1,50 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecatedSetsHeaders(t *testing.T) {
+	since := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := Deprecated(next, Deprecation{Since: since, Sunset: sunset, Successor: "/api/v1"})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/a%2Fb?archived=true", nil))
+
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("expected the wrapped handler to answer, got %d", rec.Code)
+	}
+	tests := map[string]string{
+		HeaderDeprecation: "@1792368000",
+		HeaderSunset:      "Mon, 19 Apr 2027 00:00:00 GMT",
+		HeaderLink:        `</api/v1/tasks/a%2Fb>; rel="successor-version"`,
+	}
+	for name, want := range tests {
+		if got := rec.Header().Get(name); got != want {
+			t.Errorf("expected %s %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestDeprecatedOmitsOptionalHeaders(t *testing.T) {
+	handler := Deprecated(http.NotFoundHandler(), Deprecation{Since: time.Unix(0, 0)})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+
+	if got := rec.Header().Get(HeaderDeprecation); got != "@0" {
+		t.Errorf("expected Deprecation @0, got %q", got)
+	}
+	for _, name := range []string{HeaderSunset, HeaderLink} {
+		if got := rec.Header().Get(name); got != "" {
+			t.Errorf("expected no %s header, got %q", name, got)
+		}
+	}
+}
@@ -66,6 +66,7 @@ export function AddTodoTaskModal({
               onChange={(e) => setTitle(e.target.value)}
               className="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-purple-500"
               placeholder="Digite o título da tarefa..."
+              maxLength={200}
               required
             />
           </div>
@@ -79,6 +80,7 @@ export function AddTodoTaskModal({
               onChange={(e) => setDescription(e.target.value)}
                 className="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-purple-500"
               rows={3}
+              maxLength={10000}
               placeholder="Digite uma descrição..."
             />
           </div>
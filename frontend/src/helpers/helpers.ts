@@ -11,14 +11,52 @@ export interface Task {
 export type ColumnType = "todo" | "in_progress" | "done";
 
 // URL base da API do backend
-const API_BASE_URL = "http://localhost:8080";
+const API_BASE_URL = "http://localhost:8080/api/v1";
+
+// Campo da requisição rejeitado pela validação do backend
+export interface FieldError {
+  field: string;
+  code: string;
+  message: string;
+}
+
+// Erro da API no formato application/problem+json (RFC 7807). Use `code`,
+// que é estável, para decidir o que mostrar; `message` é só para log.
+export class ApiError extends Error {
+  constructor(
+    public status: number,
+    public code: string,
+    message: string,
+    public errors: FieldError[] = []
+  ) {
+    super(message);
+    this.name = "ApiError";
+    // Com target ES5 a subclasse de Error perde o protótipo sem isto
+    Object.setPrototypeOf(this, ApiError.prototype);
+  }
+}
+
+// Lê o corpo de erro da resposta
+const toApiError = async (response: Response): Promise<ApiError> => {
+  try {
+    const problem = await response.json();
+    return new ApiError(
+      response.status,
+      problem.code ?? "",
+      problem.detail ?? response.statusText,
+      problem.errors ?? []
+    );
+  } catch {
+    return new ApiError(response.status, "", response.statusText);
+  }
+};
 
 // Função para fazer requisição GET de todas as tarefas
 export const fetchAllTasks = async (): Promise<Task[]> => {
   try {
     const response = await fetch(`${API_BASE_URL}/tasks`);
     if (!response.ok) {
-      throw new Error(`Erro ao buscar tarefas: ${response.statusText}`);
+      throw await toApiError(response);
     }
     return await response.json();
   } catch (error) {
@@ -27,29 +65,48 @@ export const fetchAllTasks = async (): Promise<Task[]> => {
   }
 };
 
-// Função para criar uma nova tarefa
+// Tentativas de criação quando a conexão falha
+const CREATE_TASK_ATTEMPTS = 3;
+
+// Gera uma Idempotency-Key aleatória (crypto.randomUUID só existe em HTTPS e localhost)
+const newIdempotencyKey = (): string =>
+  Array.from(crypto.getRandomValues(new Uint8Array(16)), (byte) =>
+    ("0" + byte.toString(16)).slice(-2)
+  ).join("");
+
+// Função para criar uma nova tarefa. Todas as tentativas usam a mesma
+// Idempotency-Key: se a resposta se perder mas a tarefa tiver sido criada, o
+// backend devolve a mesma tarefa em vez de criar um cartão duplicado.
 export const createTask = async (
   title: string,
   description?: string
 ): Promise<Task | null> => {
-  try {
-    const response = await fetch(`${API_BASE_URL}/tasks`, {
-      method: "POST",
-      headers: {
-        "Content-Type": "application/json",
-      },
-      body: JSON.stringify({
-        title,
-        description: description || "",
-      }),
-    });
-    if (!response.ok) {
-      throw new Error(`Erro ao criar tarefa: ${response.statusText}`);
+  const idempotencyKey = newIdempotencyKey();
+  for (let attempt = 1; ; attempt++) {
+    try {
+      const response = await fetch(`${API_BASE_URL}/tasks`, {
+        method: "POST",
+        headers: {
+          "Content-Type": "application/json",
+          "Idempotency-Key": idempotencyKey,
+        },
+        body: JSON.stringify({
+          title,
+          description: description || "",
+        }),
+      });
+      if (!response.ok) {
+        throw await toApiError(response);
+      }
+      return await response.json();
+    } catch (error) {
+      // fetch rejeita com TypeError apenas em falhas de rede
+      if (error instanceof TypeError && attempt < CREATE_TASK_ATTEMPTS) {
+        continue;
+      }
+      console.error("Erro ao criar tarefa:", error);
+      return null;
     }
-    return await response.json();
-  } catch (error) {
-    console.error("Erro ao criar tarefa:", error);
-    return null;
   }
 };
 
@@ -64,14 +121,14 @@ export const updateTask = async (
 ): Promise<Task | null> => {
   try {
     const response = await fetch(`${API_BASE_URL}/tasks/${id}`, {
-      method: "PUT",
+      method: "PATCH",
       headers: {
-        "Content-Type": "application/json",
+        "Content-Type": "application/merge-patch+json",
       },
       body: JSON.stringify(updates),
     });
     if (!response.ok) {
-      throw new Error(`Erro ao atualizar tarefa: ${response.statusText}`);
+      throw await toApiError(response);
     }
     return await response.json();
   } catch (error) {
@@ -87,7 +144,7 @@ export const deleteTask = async (id: string): Promise<boolean> => {
       method: "DELETE",
     });
     if (!response.ok) {
-      throw new Error(`Erro ao deletar tarefa: ${response.statusText}`);
+      throw await toApiError(response);
     }
     return true;
   } catch (error) {